@@ -0,0 +1,316 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/rs/zerolog/log"
+)
+
+type Relayer interface {
+	PendingMessages() ([]*message.Message, error)
+	DeadLetters() ([]*message.Message, error)
+	HeldMessages() ([]*message.Message, error)
+	RequeueDeadLetter(source, destination uint8, depositNonce uint64) error
+	ApproveHeldMessage(source, destination uint8, depositNonce uint64) error
+	RejectHeldMessage(source, destination uint8, depositNonce uint64) error
+	ReplayMessage(m *message.Message) error
+	PauseChain(domainID uint8) error
+	ResumeChain(domainID uint8) error
+	IsChainPaused(domainID uint8) bool
+	PauseRoute(source, destination uint8)
+	ResumeRoute(source, destination uint8)
+}
+
+type BlockStore interface {
+	StoreBlock(block *big.Int, domainID uint8) error
+}
+
+type DepositFetcher interface {
+	FetchDeposits(ctx context.Context, block *big.Int, depositNonce uint64) ([]*message.Message, error)
+}
+
+// MessageID identifies deposit message
+type MessageID struct {
+	Source       uint8  `json:"source"`
+	Destination  uint8  `json:"destination"`
+	DepositNonce uint64 `json:"depositNonce"`
+}
+
+// ReplayRequest identifies deposit to replay by its source chain, nonce and the block it was made in
+type ReplayRequest struct {
+	Source       uint8  `json:"source"`
+	DepositNonce uint64 `json:"depositNonce"`
+	Block        uint64 `json:"block"`
+}
+
+// ChainRequest identifies chain and optionally block to rewind it to
+type ChainRequest struct {
+	DomainID uint8  `json:"domainId"`
+	Block    uint64 `json:"block"`
+}
+
+// RouteRequest identifies route from source to destination chain
+type RouteRequest struct {
+	Source      uint8 `json:"source"`
+	Destination uint8 `json:"destination"`
+}
+
+// Message is a readable representation of the relayer message
+type Message struct {
+	Source       uint8    `json:"source"`
+	Destination  uint8    `json:"destination"`
+	DepositNonce uint64   `json:"depositNonce"`
+	ResourceID   string   `json:"resourceId"`
+	Type         string   `json:"type"`
+	Sender       string   `json:"sender"`
+	Payload      []string `json:"payload"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+var errBadRequest = errors.New("bad request")
+
+// API is an authenticated REST API for operating a running relayer.
+// Requests have to provide the configured token as a bearer token.
+type API struct {
+	relayer    Relayer
+	blockstore BlockStore
+	token      string
+	fetchers   map[uint8]DepositFetcher
+	lock       sync.RWMutex
+}
+
+func NewAPI(relayer Relayer, blockstore BlockStore, token string) *API {
+	return &API{
+		relayer:    relayer,
+		blockstore: blockstore,
+		token:      token,
+		fetchers:   make(map[uint8]DepositFetcher),
+	}
+}
+
+// RegisterChain enables replaying deposits of the chain
+func (a *API) RegisterChain(domainID uint8, fetcher DepositFetcher) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.fetchers[domainID] = fetcher
+}
+
+// UnregisterChain disables replaying deposits of the chain
+func (a *API) UnregisterChain(domainID uint8) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	delete(a.fetchers, domainID)
+}
+
+// Handler serves the admin API:
+//
+//	GET  /messages/pending         messages not written to their destination yet
+//	GET  /messages/failed          messages that exhausted all write retries
+//	GET  /messages/held            messages held for manual approval
+//	POST /messages/failed/requeue  routes failed message again
+//	POST /messages/held/approve    relays held message
+//	POST /messages/held/reject     drops held message
+//	POST /deposits/replay          fetches deposit from its block and relays it again
+//	POST /chains/pause             stops listening to and writing to the chain
+//	POST /chains/resume            resumes paused chain
+//	POST /chains/rewind            sets last processed block of the paused chain
+//	POST /routes/pause             stops routing messages from source to destination
+//	POST /routes/resume            resumes paused route
+func (a *API) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/messages/pending", a.handle(http.MethodGet, func(r *http.Request) (interface{}, error) {
+		return newMessages(a.relayer.PendingMessages())
+	}))
+	mux.HandleFunc("/messages/failed", a.handle(http.MethodGet, func(r *http.Request) (interface{}, error) {
+		return newMessages(a.relayer.DeadLetters())
+	}))
+	mux.HandleFunc("/messages/held", a.handle(http.MethodGet, func(r *http.Request) (interface{}, error) {
+		return newMessages(a.relayer.HeldMessages())
+	}))
+	mux.HandleFunc("/messages/failed/requeue", a.handleMessage(a.relayer.RequeueDeadLetter))
+	mux.HandleFunc("/messages/held/approve", a.handleMessage(a.relayer.ApproveHeldMessage))
+	mux.HandleFunc("/messages/held/reject", a.handleMessage(a.relayer.RejectHeldMessage))
+	mux.HandleFunc("/deposits/replay", a.handle(http.MethodPost, a.replayDeposit))
+	mux.HandleFunc("/chains/pause", a.handle(http.MethodPost, func(r *http.Request) (interface{}, error) {
+		var req ChainRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return nil, a.relayer.PauseChain(req.DomainID)
+	}))
+	mux.HandleFunc("/chains/resume", a.handle(http.MethodPost, func(r *http.Request) (interface{}, error) {
+		var req ChainRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		return nil, a.relayer.ResumeChain(req.DomainID)
+	}))
+	mux.HandleFunc("/chains/rewind", a.handle(http.MethodPost, a.rewindChain))
+	mux.HandleFunc("/routes/pause", a.handle(http.MethodPost, func(r *http.Request) (interface{}, error) {
+		var req RouteRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		a.relayer.PauseRoute(req.Source, req.Destination)
+		return nil, nil
+	}))
+	mux.HandleFunc("/routes/resume", a.handle(http.MethodPost, func(r *http.Request) (interface{}, error) {
+		var req RouteRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		a.relayer.ResumeRoute(req.Source, req.Destination)
+		return nil, nil
+	}))
+	return mux
+}
+
+// replayDeposit fetches deposit from its block on the source chain and relays it
+// again even if it was already processed
+func (a *API) replayDeposit(r *http.Request) (interface{}, error) {
+	var req ReplayRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+
+	a.lock.RLock()
+	fetcher, ok := a.fetchers[req.Source]
+	a.lock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: chain with domain ID %d not found", errBadRequest, req.Source)
+	}
+
+	msgs, err := fetcher.FetchDeposits(r.Context(), new(big.Int).SetUint64(req.Block), req.DepositNonce)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range msgs {
+		err = a.relayer.ReplayMessage(m)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newMessages(msgs, nil)
+}
+
+// rewindChain sets last processed block of the chain. Chain has to be paused so that its
+// listener does not overwrite the block, listening continues from the next block once it is resumed.
+func (a *API) rewindChain(r *http.Request) (interface{}, error) {
+	var req ChainRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if !a.relayer.IsChainPaused(req.DomainID) {
+		return nil, fmt.Errorf("%w: chain with domain ID %d has to be paused before rewinding", errBadRequest, req.DomainID)
+	}
+
+	log.Info().Msgf("Rewinding chain %v to block %d", req.DomainID, req.Block)
+	return nil, a.blockstore.StoreBlock(new(big.Int).SetUint64(req.Block), req.DomainID)
+}
+
+func (a *API) handleMessage(f func(source, destination uint8, depositNonce uint64) error) http.HandlerFunc {
+	return a.handle(http.MethodPost, func(r *http.Request) (interface{}, error) {
+		var id MessageID
+		if err := decode(r, &id); err != nil {
+			return nil, err
+		}
+		return nil, f(id.Source, id.Destination, id.DepositNonce)
+	})
+}
+
+// handle authenticates the request and writes result of f as JSON response
+func (a *API) handle(method string, f func(r *http.Request) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !a.authenticated(r) {
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "unauthorized"})
+			return
+		}
+		if r.Method != method {
+			writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+			return
+		}
+
+		result, err := f(r)
+		switch {
+		case errors.Is(err, errBadRequest):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		case errors.Is(err, store.ErrNotFound):
+			writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+		case err != nil:
+			log.Error().Err(err).Msgf("admin request %s %s failed", r.Method, r.URL.Path)
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Error: err.Error()})
+		case result == nil:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, result)
+		}
+	}
+}
+
+func (a *API) authenticated(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return a.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) == 1
+}
+
+func decode(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errBadRequest, err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Error().Err(err).Msg("failed writing admin response")
+	}
+}
+
+func newMessages(msgs []*message.Message, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Message, len(msgs))
+	for i, m := range msgs {
+		payload := make([]string, len(m.Payload))
+		for j, p := range m.Payload {
+			if b, ok := p.([]byte); ok {
+				payload[j] = fmt.Sprintf("0x%x", b)
+			} else {
+				payload[j] = fmt.Sprintf("%v", p)
+			}
+		}
+		result[i] = Message{
+			Source:       m.Source,
+			Destination:  m.Destination,
+			DepositNonce: m.DepositNonce,
+			ResourceID:   fmt.Sprintf("0x%x", m.ResourceId),
+			Type:         string(m.Type),
+			Sender:       m.Sender.Hex(),
+			Payload:      payload,
+		}
+	}
+	return result, nil
+}
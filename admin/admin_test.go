@@ -0,0 +1,184 @@
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/admin"
+	mock_admin "github.com/VaivalGithub/chainsafe-core/admin/mock"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+const testToken = "secret"
+
+type APITestSuite struct {
+	suite.Suite
+	api                *admin.API
+	mockRelayer        *mock_admin.MockRelayer
+	mockBlockStore     *mock_admin.MockBlockStore
+	mockDepositFetcher *mock_admin.MockDepositFetcher
+}
+
+func TestRunAPITestSuite(t *testing.T) {
+	suite.Run(t, new(APITestSuite))
+}
+
+func (s *APITestSuite) SetupSuite()    {}
+func (s *APITestSuite) TearDownSuite() {}
+func (s *APITestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockRelayer = mock_admin.NewMockRelayer(gomockController)
+	s.mockBlockStore = mock_admin.NewMockBlockStore(gomockController)
+	s.mockDepositFetcher = mock_admin.NewMockDepositFetcher(gomockController)
+	s.api = admin.NewAPI(s.mockRelayer, s.mockBlockStore, testToken)
+	s.api.RegisterChain(1, s.mockDepositFetcher)
+}
+func (s *APITestSuite) TearDownTest() {}
+
+func (s *APITestSuite) request(method, path string, body interface{}) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, path, bytes.NewReader(b))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	recorder := httptest.NewRecorder()
+	s.api.Handler().ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *APITestSuite) TestRejectsRequestWithoutToken() {
+	recorder := httptest.NewRecorder()
+	s.api.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/messages/pending", nil))
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *APITestSuite) TestRejectsRequestWithInvalidToken() {
+	req := httptest.NewRequest(http.MethodGet, "/messages/pending", nil)
+	req.Header.Set("Authorization", "Bearer invalid")
+	recorder := httptest.NewRecorder()
+	s.api.Handler().ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusUnauthorized, recorder.Code)
+}
+
+func (s *APITestSuite) TestRejectsInvalidMethod() {
+	recorder := s.request(http.MethodGet, "/chains/pause", nil)
+
+	s.Equal(http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func (s *APITestSuite) TestListsPendingMessages() {
+	s.mockRelayer.EXPECT().PendingMessages().Return([]*message.Message{{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		Type:         message.FungibleTransfer,
+		Payload:      []interface{}{[]byte{1}},
+	}}, nil)
+
+	recorder := s.request(http.MethodGet, "/messages/pending", nil)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	var msgs []admin.Message
+	s.Nil(json.NewDecoder(recorder.Body).Decode(&msgs))
+	s.Equal(1, len(msgs))
+	s.Equal(uint64(3), msgs[0].DepositNonce)
+	s.Equal([]string{"0x01"}, msgs[0].Payload)
+}
+
+func (s *APITestSuite) TestListsFailedMessages() {
+	s.mockRelayer.EXPECT().DeadLetters().Return([]*message.Message{}, nil)
+
+	recorder := s.request(http.MethodGet, "/messages/failed", nil)
+
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *APITestSuite) TestRequeueReturnsNotFoundForUnknownMessage() {
+	s.mockRelayer.EXPECT().RequeueDeadLetter(uint8(1), uint8(2), uint64(3)).Return(store.ErrNotFound)
+
+	recorder := s.request(http.MethodPost, "/messages/failed/requeue", admin.MessageID{Source: 1, Destination: 2, DepositNonce: 3})
+
+	s.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (s *APITestSuite) TestApprovesHeldMessage() {
+	s.mockRelayer.EXPECT().ApproveHeldMessage(uint8(1), uint8(2), uint64(3)).Return(nil)
+
+	recorder := s.request(http.MethodPost, "/messages/held/approve", admin.MessageID{Source: 1, Destination: 2, DepositNonce: 3})
+
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *APITestSuite) TestReplaysDepositFromBlock() {
+	m := &message.Message{Source: 1, Destination: 2, DepositNonce: 3}
+	s.mockDepositFetcher.EXPECT().FetchDeposits(gomock.Any(), big.NewInt(100), uint64(3)).Return([]*message.Message{m}, nil)
+	s.mockRelayer.EXPECT().ReplayMessage(m).Return(nil)
+
+	recorder := s.request(http.MethodPost, "/deposits/replay", admin.ReplayRequest{Source: 1, DepositNonce: 3, Block: 100})
+
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *APITestSuite) TestReplayReturnsErrorIfFetchingFails() {
+	s.mockDepositFetcher.EXPECT().FetchDeposits(gomock.Any(), big.NewInt(100), uint64(3)).Return(nil, errors.New("error"))
+
+	recorder := s.request(http.MethodPost, "/deposits/replay", admin.ReplayRequest{Source: 1, DepositNonce: 3, Block: 100})
+
+	s.Equal(http.StatusInternalServerError, recorder.Code)
+}
+
+func (s *APITestSuite) TestReplayReturnsBadRequestForUnknownChain() {
+	recorder := s.request(http.MethodPost, "/deposits/replay", admin.ReplayRequest{Source: 2, DepositNonce: 3, Block: 100})
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *APITestSuite) TestPausesAndResumesChain() {
+	s.mockRelayer.EXPECT().PauseChain(uint8(1)).Return(nil)
+	s.mockRelayer.EXPECT().ResumeChain(uint8(1)).Return(nil)
+
+	s.Equal(http.StatusNoContent, s.request(http.MethodPost, "/chains/pause", admin.ChainRequest{DomainID: 1}).Code)
+	s.Equal(http.StatusNoContent, s.request(http.MethodPost, "/chains/resume", admin.ChainRequest{DomainID: 1}).Code)
+}
+
+func (s *APITestSuite) TestPausesAndResumesRoute() {
+	s.mockRelayer.EXPECT().PauseRoute(uint8(1), uint8(2))
+	s.mockRelayer.EXPECT().ResumeRoute(uint8(1), uint8(2))
+
+	s.Equal(http.StatusNoContent, s.request(http.MethodPost, "/routes/pause", admin.RouteRequest{Source: 1, Destination: 2}).Code)
+	s.Equal(http.StatusNoContent, s.request(http.MethodPost, "/routes/resume", admin.RouteRequest{Source: 1, Destination: 2}).Code)
+}
+
+func (s *APITestSuite) TestRewindsPausedChain() {
+	s.mockRelayer.EXPECT().IsChainPaused(uint8(1)).Return(true)
+	s.mockBlockStore.EXPECT().StoreBlock(big.NewInt(50), uint8(1)).Return(nil)
+
+	recorder := s.request(http.MethodPost, "/chains/rewind", admin.ChainRequest{DomainID: 1, Block: 50})
+
+	s.Equal(http.StatusNoContent, recorder.Code)
+}
+
+func (s *APITestSuite) TestRewindRequiresPausedChain() {
+	s.mockRelayer.EXPECT().IsChainPaused(uint8(1)).Return(false)
+
+	recorder := s.request(http.MethodPost, "/chains/rewind", admin.ChainRequest{DomainID: 1, Block: 50})
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (s *APITestSuite) TestReturnsBadRequestForInvalidBody() {
+	req := httptest.NewRequest(http.MethodPost, "/chains/pause", bytes.NewReader([]byte("invalid")))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	recorder := httptest.NewRecorder()
+	s.api.Handler().ServeHTTP(recorder, req)
+
+	s.Equal(http.StatusBadRequest, recorder.Code)
+}
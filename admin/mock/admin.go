@@ -0,0 +1,279 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./admin/admin.go
+
+// Package mock_admin is a generated GoMock package.
+package mock_admin
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	message "github.com/VaivalGithub/chainsafe-core/relayer/message"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRelayer is a mock of Relayer interface.
+type MockRelayer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelayerMockRecorder
+}
+
+// MockRelayerMockRecorder is the mock recorder for MockRelayer.
+type MockRelayerMockRecorder struct {
+	mock *MockRelayer
+}
+
+// NewMockRelayer creates a new mock instance.
+func NewMockRelayer(ctrl *gomock.Controller) *MockRelayer {
+	mock := &MockRelayer{ctrl: ctrl}
+	mock.recorder = &MockRelayerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelayer) EXPECT() *MockRelayerMockRecorder {
+	return m.recorder
+}
+
+// ApproveHeldMessage mocks base method.
+func (m *MockRelayer) ApproveHeldMessage(source, destination uint8, depositNonce uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveHeldMessage", source, destination, depositNonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveHeldMessage indicates an expected call of ApproveHeldMessage.
+func (mr *MockRelayerMockRecorder) ApproveHeldMessage(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveHeldMessage", reflect.TypeOf((*MockRelayer)(nil).ApproveHeldMessage), source, destination, depositNonce)
+}
+
+// DeadLetters mocks base method.
+func (m *MockRelayer) DeadLetters() ([]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters")
+	ret0, _ := ret[0].([]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockRelayerMockRecorder) DeadLetters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockRelayer)(nil).DeadLetters))
+}
+
+// HeldMessages mocks base method.
+func (m *MockRelayer) HeldMessages() ([]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeldMessages")
+	ret0, _ := ret[0].([]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeldMessages indicates an expected call of HeldMessages.
+func (mr *MockRelayerMockRecorder) HeldMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeldMessages", reflect.TypeOf((*MockRelayer)(nil).HeldMessages))
+}
+
+// IsChainPaused mocks base method.
+func (m *MockRelayer) IsChainPaused(domainID uint8) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsChainPaused", domainID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsChainPaused indicates an expected call of IsChainPaused.
+func (mr *MockRelayerMockRecorder) IsChainPaused(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsChainPaused", reflect.TypeOf((*MockRelayer)(nil).IsChainPaused), domainID)
+}
+
+// PauseChain mocks base method.
+func (m *MockRelayer) PauseChain(domainID uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseChain", domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseChain indicates an expected call of PauseChain.
+func (mr *MockRelayerMockRecorder) PauseChain(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseChain", reflect.TypeOf((*MockRelayer)(nil).PauseChain), domainID)
+}
+
+// PauseRoute mocks base method.
+func (m *MockRelayer) PauseRoute(source, destination uint8) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PauseRoute", source, destination)
+}
+
+// PauseRoute indicates an expected call of PauseRoute.
+func (mr *MockRelayerMockRecorder) PauseRoute(source, destination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseRoute", reflect.TypeOf((*MockRelayer)(nil).PauseRoute), source, destination)
+}
+
+// PendingMessages mocks base method.
+func (m *MockRelayer) PendingMessages() ([]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingMessages")
+	ret0, _ := ret[0].([]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingMessages indicates an expected call of PendingMessages.
+func (mr *MockRelayerMockRecorder) PendingMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingMessages", reflect.TypeOf((*MockRelayer)(nil).PendingMessages))
+}
+
+// RejectHeldMessage mocks base method.
+func (m *MockRelayer) RejectHeldMessage(source, destination uint8, depositNonce uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectHeldMessage", source, destination, depositNonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RejectHeldMessage indicates an expected call of RejectHeldMessage.
+func (mr *MockRelayerMockRecorder) RejectHeldMessage(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectHeldMessage", reflect.TypeOf((*MockRelayer)(nil).RejectHeldMessage), source, destination, depositNonce)
+}
+
+// ReplayMessage mocks base method.
+func (m_2 *MockRelayer) ReplayMessage(m *message.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "ReplayMessage", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplayMessage indicates an expected call of ReplayMessage.
+func (mr *MockRelayerMockRecorder) ReplayMessage(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayMessage", reflect.TypeOf((*MockRelayer)(nil).ReplayMessage), m)
+}
+
+// RequeueDeadLetter mocks base method.
+func (m *MockRelayer) RequeueDeadLetter(source, destination uint8, depositNonce uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueDeadLetter", source, destination, depositNonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueDeadLetter indicates an expected call of RequeueDeadLetter.
+func (mr *MockRelayerMockRecorder) RequeueDeadLetter(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueDeadLetter", reflect.TypeOf((*MockRelayer)(nil).RequeueDeadLetter), source, destination, depositNonce)
+}
+
+// ResumeChain mocks base method.
+func (m *MockRelayer) ResumeChain(domainID uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeChain", domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeChain indicates an expected call of ResumeChain.
+func (mr *MockRelayerMockRecorder) ResumeChain(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeChain", reflect.TypeOf((*MockRelayer)(nil).ResumeChain), domainID)
+}
+
+// ResumeRoute mocks base method.
+func (m *MockRelayer) ResumeRoute(source, destination uint8) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResumeRoute", source, destination)
+}
+
+// ResumeRoute indicates an expected call of ResumeRoute.
+func (mr *MockRelayerMockRecorder) ResumeRoute(source, destination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeRoute", reflect.TypeOf((*MockRelayer)(nil).ResumeRoute), source, destination)
+}
+
+// MockBlockStore is a mock of BlockStore interface.
+type MockBlockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStoreMockRecorder
+}
+
+// MockBlockStoreMockRecorder is the mock recorder for MockBlockStore.
+type MockBlockStoreMockRecorder struct {
+	mock *MockBlockStore
+}
+
+// NewMockBlockStore creates a new mock instance.
+func NewMockBlockStore(ctrl *gomock.Controller) *MockBlockStore {
+	mock := &MockBlockStore{ctrl: ctrl}
+	mock.recorder = &MockBlockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStore) EXPECT() *MockBlockStoreMockRecorder {
+	return m.recorder
+}
+
+// StoreBlock mocks base method.
+func (m *MockBlockStore) StoreBlock(block *big.Int, domainID uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlock", block, domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreBlock indicates an expected call of StoreBlock.
+func (mr *MockBlockStoreMockRecorder) StoreBlock(block, domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlock", reflect.TypeOf((*MockBlockStore)(nil).StoreBlock), block, domainID)
+}
+
+// MockDepositFetcher is a mock of DepositFetcher interface.
+type MockDepositFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositFetcherMockRecorder
+}
+
+// MockDepositFetcherMockRecorder is the mock recorder for MockDepositFetcher.
+type MockDepositFetcherMockRecorder struct {
+	mock *MockDepositFetcher
+}
+
+// NewMockDepositFetcher creates a new mock instance.
+func NewMockDepositFetcher(ctrl *gomock.Controller) *MockDepositFetcher {
+	mock := &MockDepositFetcher{ctrl: ctrl}
+	mock.recorder = &MockDepositFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositFetcher) EXPECT() *MockDepositFetcherMockRecorder {
+	return m.recorder
+}
+
+// FetchDeposits mocks base method.
+func (m *MockDepositFetcher) FetchDeposits(ctx context.Context, block *big.Int, depositNonce uint64) ([]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDeposits", ctx, block, depositNonce)
+	ret0, _ := ret[0].([]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDeposits indicates an expected call of FetchDeposits.
+func (mr *MockDepositFetcherMockRecorder) FetchDeposits(ctx, block, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeposits", reflect.TypeOf((*MockDepositFetcher)(nil).FetchDeposits), ctx, block, depositNonce)
+}
@@ -302,6 +302,18 @@ func (c *BridgeContract) IsRelayer(relayerAddress common.Address) (bool, error)
 	return *out, nil
 }
 
+// IsAdmin checks whether the address holds the bridge DEFAULT_ADMIN_ROLE
+// that is required for calling admin methods such as adminSetResource
+func (c *BridgeContract) IsAdmin(address common.Address) (bool, error) {
+	log.Debug().Msgf("Getting is %s an admin", address.String())
+	res, err := c.CallContract("hasRole", [32]byte{}, address)
+	if err != nil {
+		return false, err
+	}
+	out := abi.ConvertType(res[0], new(bool)).(*bool)
+	return *out, nil
+}
+
 func (c *BridgeContract) ProposalStatus(p *proposal.Proposal) (message.ProposalStatus, error) {
 	log.Debug().
 		Str("depositNonce", strconv.FormatUint(p.DepositNonce, 10)).
@@ -1,6 +1,8 @@
 package events
 
 import (
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/VaivalGithub/chainsafe-core/types"
@@ -18,8 +20,13 @@ const (
 	ProposalEventSig    EventSig = "ProposalEvent(uint8,uint64,uint8,bytes32)"
 	ProposalVoteSig     EventSig = "ProposalVote(uint8,uint64,uint8,bytes32)"
 	RegisterTokenSig    EventSig = "RegisterToken(uint8,uint8,uint64,address,address,address,address,address,address)"
+	RoleGrantedSig      EventSig = "RoleGranted(bytes32,address,address)"
+	RoleRevokedSig      EventSig = "RoleRevoked(bytes32,address,address)"
 )
 
+// RelayerRole is the bridge access control role that allows voting on proposals
+var RelayerRole = crypto.Keccak256Hash([]byte("RELAYER_ROLE"))
+
 // Deposit struct holds event data with all necessary parameters and a handler response
 // https://github.com/devanshubhadouria/chainbridge-solidity/blob/develop/contracts/Bridge.sol#L47
 type Deposit struct {
@@ -37,8 +44,11 @@ type Deposit struct {
 	// ERC721Handler: responds with deposited token metadata acquired by calling a tokenURI method in the token contract
 	// GenericHandler: responds with the raw bytes returned from the call to the target contract
 	HandlerResponse []byte
+	// Block the deposit was made in
+	Block uint64
 }
 
+// RegisterToken struct holds event data of a token pair registered on the source bridge
 type RegisterToken struct {
 	DomainId uint8
 
@@ -52,4 +62,46 @@ type RegisterToken struct {
 	SourceBridgeContract common.Address
 	SourceToken          common.Address
 	DestToken            common.Address
+
+	// ResourceID is not emitted with the event and is resolved from the registerToken call of the transaction
+	ResourceID types.ResourceID
+	// Block the token was registered in
+	Block uint64
+}
+
+// ProposalEvent struct holds event data of a proposal status change or a vote emitted by the destination bridge
+type ProposalEvent struct {
+	OriginDomainID uint8
+	DepositNonce   uint64
+	Status         uint8
+	DataHash       [32]byte
+
+	// Voter is not emitted with the event and is resolved from the sender of the vote transaction
+	Voter common.Address
+	// Block the event was emitted in
+	Block uint64
+	// Index of the event log in the block
+	Index uint
+}
+
+// ThresholdChanged struct holds event data of a relayer threshold change emitted by the bridge
+type ThresholdChanged struct {
+	NewThreshold *big.Int
+
+	// Block the event was emitted in
+	Block uint64
+	// Index of the event log in the block
+	Index uint
+}
+
+// RelayerChanged struct holds event data of the relayer role granted to or revoked from an address
+type RelayerChanged struct {
+	Relayer common.Address
+	// Granted is true if the relayer role was granted and false if it was revoked
+	Granted bool
+
+	// Block the event was emitted in
+	Block uint64
+	// Index of the event log in the block
+	Index uint
 }
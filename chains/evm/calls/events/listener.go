@@ -1,19 +1,24 @@
 package events
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/consts"
+	"github.com/VaivalGithub/chainsafe-core/types"
 	"github.com/rs/zerolog/log"
 )
 
 type ChainClient interface {
 	FetchEventLogs(ctx context.Context, contractAddress common.Address, event string, startBlock *big.Int, endBlock *big.Int) ([]ethTypes.Log, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *ethTypes.Transaction, isPending bool, err error)
 }
 
 type Listener struct {
@@ -37,6 +42,10 @@ func (l *Listener) FetchDeposits(ctx context.Context, contractAddress common.Add
 	deposits := make([]*Deposit, 0)
 
 	for _, dl := range logs {
+		// logs of blocks removed by a chain reorganisation are not relayed
+		if dl.Removed {
+			continue
+		}
 		d, err := l.UnpackDeposit(l.abi, dl.Data)
 		if err != nil {
 			log.Error().Msgf("failed unpacking deposit event log: %v", err)
@@ -44,6 +53,7 @@ func (l *Listener) FetchDeposits(ctx context.Context, contractAddress common.Add
 		}
 
 		d.SenderAddress = common.BytesToAddress(dl.Topics[1].Bytes())
+		d.Block = dl.BlockNumber
 		log.Debug().Msgf("Found deposit log in block: %d, TxHash: %s, contractAddress: %s, sender: %s", dl.BlockNumber, dl.TxHash, dl.Address, d.SenderAddress)
 
 		deposits = append(deposits, d)
@@ -62,3 +72,240 @@ func (l *Listener) UnpackDeposit(abi abi.ABI, data []byte) (*Deposit, error) {
 
 	return &dl, nil
 }
+
+// FetchRegisterTokens fetches token registrations from the provided block range.
+// Resource ID of each registration is resolved from the registerToken call of the transaction that emitted it.
+// Registrations made by other transactions return an error so that the block range is not skipped.
+func (l *Listener) FetchRegisterTokens(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]*RegisterToken, error) {
+	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(RegisterTokenSig), startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	registrations := make([]*RegisterToken, 0)
+
+	for _, rl := range logs {
+		if rl.Removed {
+			continue
+		}
+		rt, err := l.UnpackRegisterToken(l.abi, rl.Data)
+		if err != nil {
+			log.Error().Msgf("failed unpacking register token event log: %v", err)
+			continue
+		}
+
+		tx, _, err := l.client.TransactionByHash(ctx, rl.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch register token transaction %s: %w", rl.TxHash, err)
+		}
+		rt.ResourceID, err = l.UnpackRegisterTokenResourceID(l.abi, tx.Data())
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve resource ID of register token transaction %s: %w", rl.TxHash, err)
+		}
+		rt.Block = rl.BlockNumber
+		log.Debug().Msgf("Found register token log in block: %d, TxHash: %s, contractAddress: %s, sourceToken: %s", rl.BlockNumber, rl.TxHash, rl.Address, rt.SourceToken)
+
+		registrations = append(registrations, rt)
+	}
+
+	return registrations, nil
+}
+
+func (l *Listener) UnpackRegisterToken(abi abi.ABI, data []byte) (*RegisterToken, error) {
+	var rt RegisterToken
+
+	err := abi.UnpackIntoInterface(&rt, "RegisterToken", data)
+	if err != nil {
+		return &RegisterToken{}, err
+	}
+
+	return &rt, nil
+}
+
+// UnpackRegisterTokenResourceID returns resource ID argument of the registerToken call data
+func (l *Listener) UnpackRegisterTokenResourceID(abi abi.ABI, input []byte) (types.ResourceID, error) {
+	method := abi.Methods["registerToken"]
+	if len(input) < 4 || !bytes.Equal(input[:4], method.ID) {
+		return types.ResourceID{}, fmt.Errorf("transaction is not a registerToken call")
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return types.ResourceID{}, err
+	}
+	resourceID, ok := args[2].([32]byte)
+	if !ok {
+		return types.ResourceID{}, fmt.Errorf("wrong registerToken resourceId format")
+	}
+	return resourceID, nil
+}
+
+// FetchProposalEvents fetches proposal status changes from the provided block range
+func (l *Listener) FetchProposalEvents(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]*ProposalEvent, error) {
+	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(ProposalEventSig), startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	proposalEvents := make([]*ProposalEvent, 0)
+
+	for _, pl := range logs {
+		if pl.Removed {
+			continue
+		}
+		pe, err := l.UnpackProposalEvent(l.abi, "ProposalEvent", pl.Data)
+		if err != nil {
+			log.Error().Msgf("failed unpacking proposal event log: %v", err)
+			continue
+		}
+		pe.Block = pl.BlockNumber
+		pe.Index = pl.Index
+		log.Debug().Msgf("Found proposal log in block: %d, TxHash: %s, source: %d, nonce: %d, status: %d", pl.BlockNumber, pl.TxHash, pe.OriginDomainID, pe.DepositNonce, pe.Status)
+
+		proposalEvents = append(proposalEvents, pe)
+	}
+
+	return proposalEvents, nil
+}
+
+// FetchProposalVotes fetches proposal votes from the provided block range.
+// Voter of each vote is resolved from the sender of the transaction that emitted it.
+func (l *Listener) FetchProposalVotes(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]*ProposalEvent, error) {
+	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(ProposalVoteSig), startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	votes := make([]*ProposalEvent, 0)
+
+	for _, vl := range logs {
+		if vl.Removed {
+			continue
+		}
+		pe, err := l.UnpackProposalEvent(l.abi, "ProposalVote", vl.Data)
+		if err != nil {
+			log.Error().Msgf("failed unpacking proposal vote log: %v", err)
+			continue
+		}
+
+		tx, _, err := l.client.TransactionByHash(ctx, vl.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch proposal vote transaction %s: %w", vl.TxHash, err)
+		}
+		pe.Voter, err = ethTypes.Sender(ethTypes.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			log.Error().Msgf("failed resolving sender of proposal vote transaction %s: %v", vl.TxHash, err)
+			continue
+		}
+		pe.Block = vl.BlockNumber
+		pe.Index = vl.Index
+		log.Debug().Msgf("Found proposal vote log in block: %d, TxHash: %s, source: %d, nonce: %d, voter: %s", vl.BlockNumber, vl.TxHash, pe.OriginDomainID, pe.DepositNonce, pe.Voter)
+
+		votes = append(votes, pe)
+	}
+
+	return votes, nil
+}
+
+// UnpackProposalEvent unpacks ProposalEvent or ProposalVote event data as both have the same arguments
+func (l *Listener) UnpackProposalEvent(abi abi.ABI, event string, data []byte) (*ProposalEvent, error) {
+	var pe ProposalEvent
+
+	err := abi.UnpackIntoInterface(&pe, event, data)
+	if err != nil {
+		return &ProposalEvent{}, err
+	}
+
+	return &pe, nil
+}
+
+// FetchThresholdChanges fetches relayer threshold changes from the provided block range
+func (l *Listener) FetchThresholdChanges(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]*ThresholdChanged, error) {
+	logs, err := l.client.FetchEventLogs(ctx, contractAddress, string(ThresholdChangedSig), startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	thresholdChanges := make([]*ThresholdChanged, 0)
+
+	for _, tl := range logs {
+		if tl.Removed {
+			continue
+		}
+		tc, err := l.UnpackThresholdChanged(l.abi, tl.Data)
+		if err != nil {
+			log.Error().Msgf("failed unpacking threshold changed event log: %v", err)
+			continue
+		}
+		tc.Block = tl.BlockNumber
+		tc.Index = tl.Index
+		log.Debug().Msgf("Found threshold changed log in block: %d, TxHash: %s, threshold: %s", tl.BlockNumber, tl.TxHash, tc.NewThreshold)
+
+		thresholdChanges = append(thresholdChanges, tc)
+	}
+
+	return thresholdChanges, nil
+}
+
+// FetchRelayerChanges fetches relayer role grants and revocations from the provided block range
+// ordered by the block and log index they were emitted at
+func (l *Listener) FetchRelayerChanges(ctx context.Context, contractAddress common.Address, startBlock *big.Int, endBlock *big.Int) ([]*RelayerChanged, error) {
+	grantedLogs, err := l.client.FetchEventLogs(ctx, contractAddress, string(RoleGrantedSig), startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	revokedLogs, err := l.client.FetchEventLogs(ctx, contractAddress, string(RoleRevokedSig), startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	relayerChanges := make([]*RelayerChanged, 0)
+
+	for i, logs := range [][]ethTypes.Log{grantedLogs, revokedLogs} {
+		granted := i == 0
+		for _, rl := range logs {
+			if rl.Removed {
+				continue
+			}
+			rc, err := l.UnpackRelayerChanged(rl)
+			if err != nil {
+				continue
+			}
+			rc.Granted = granted
+			log.Debug().Msgf("Found relayer role log in block: %d, TxHash: %s, relayer: %s, granted: %t", rl.BlockNumber, rl.TxHash, rc.Relayer, rc.Granted)
+
+			relayerChanges = append(relayerChanges, rc)
+		}
+	}
+
+	sort.SliceStable(relayerChanges, func(i, j int) bool {
+		if relayerChanges[i].Block != relayerChanges[j].Block {
+			return relayerChanges[i].Block < relayerChanges[j].Block
+		}
+		return relayerChanges[i].Index < relayerChanges[j].Index
+	})
+	return relayerChanges, nil
+}
+
+func (l *Listener) UnpackThresholdChanged(abi abi.ABI, data []byte) (*ThresholdChanged, error) {
+	var tc ThresholdChanged
+
+	err := abi.UnpackIntoInterface(&tc, "RelayerThresholdChanged", data)
+	if err != nil {
+		return &ThresholdChanged{}, err
+	}
+
+	return &tc, nil
+}
+
+// UnpackRelayerChanged resolves the account of a RoleGranted or RoleRevoked log from its indexed topics.
+// Logs of roles other than the relayer role are rejected.
+func (l *Listener) UnpackRelayerChanged(rl ethTypes.Log) (*RelayerChanged, error) {
+	if len(rl.Topics) < 3 {
+		return nil, fmt.Errorf("role log %s has %d topics", rl.TxHash, len(rl.Topics))
+	}
+	if rl.Topics[1] != RelayerRole {
+		return nil, fmt.Errorf("role %s is not the relayer role", rl.Topics[1])
+	}
+
+	return &RelayerChanged{
+		Relayer: common.BytesToAddress(rl.Topics[2].Bytes()),
+		Block:   rl.BlockNumber,
+		Index:   rl.Index,
+	}, nil
+}
@@ -2,10 +2,13 @@ package events_test
 
 import (
 	"encoding/hex"
+	"math/big"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/golang/mock/gomock"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/consts"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/events"
@@ -50,3 +53,99 @@ func (s *EvmClientTestSuite) TestUnpackDepositEventLogValidData() {
 	s.Equal(dl.ResourceID, expectedRID)
 	s.Equal(dl.HandlerResponse, []byte{})
 }
+
+func (s *EvmClientTestSuite) TestUnpackRegisterTokenEventLogValidData() {
+	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	sourceToken := common.HexToAddress("0x1")
+	destToken := common.HexToAddress("0x2")
+	data, err := abi.Events["RegisterToken"].Inputs.Pack(
+		uint8(1), uint8(2), uint64(3),
+		common.HexToAddress("0x3"), common.HexToAddress("0x4"), common.HexToAddress("0x5"), common.HexToAddress("0x6"),
+		sourceToken, destToken,
+	)
+	s.Nil(err)
+
+	rt, err := s.listener.UnpackRegisterToken(abi, data)
+	s.Nil(err)
+	s.Equal(rt.DomainId, uint8(1))
+	s.Equal(rt.DestinationDomainId, uint8(2))
+	s.Equal(rt.DepositNounce, uint64(3))
+	s.Equal(rt.DestHandler, common.HexToAddress("0x4"))
+	s.Equal(rt.SourceToken, sourceToken)
+	s.Equal(rt.DestToken, destToken)
+}
+
+func (s *EvmClientTestSuite) TestUnpackRegisterTokenResourceID() {
+	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	resourceID := types.ResourceID{31: 1}
+	input, err := abi.Pack(
+		"registerToken",
+		uint8(2), common.HexToAddress("0x1"), resourceID,
+		common.HexToAddress("0x2"), common.HexToAddress("0x3"), common.HexToAddress("0x4"), common.HexToAddress("0x5"),
+	)
+	s.Nil(err)
+
+	rID, err := s.listener.UnpackRegisterTokenResourceID(abi, input)
+	s.Nil(err)
+	s.Equal(rID, resourceID)
+}
+
+func (s *EvmClientTestSuite) TestUnpackRegisterTokenResourceIDOtherCall() {
+	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	input, err := abi.Pack("adminPauseTransfers")
+	s.Nil(err)
+
+	_, err = s.listener.UnpackRegisterTokenResourceID(abi, input)
+	s.NotNil(err)
+}
+
+func (s *EvmClientTestSuite) TestUnpackProposalVoteEventLogValidData() {
+	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	dataHash := [32]byte{31: 1}
+	data, err := abi.Events["ProposalVote"].Inputs.Pack(uint8(1), uint64(2), uint8(3), dataHash)
+	s.Nil(err)
+
+	pe, err := s.listener.UnpackProposalEvent(abi, "ProposalVote", data)
+	s.Nil(err)
+	s.Equal(pe.OriginDomainID, uint8(1))
+	s.Equal(pe.DepositNonce, uint64(2))
+	s.Equal(pe.Status, uint8(3))
+	s.Equal(pe.DataHash, dataHash)
+}
+
+func (s *EvmClientTestSuite) TestUnpackProposalEventLogFailedUnpack() {
+	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	_, err := s.listener.UnpackProposalEvent(abi, "ProposalEvent", []byte("invalid"))
+	s.NotNil(err)
+}
+
+func (s *EvmClientTestSuite) TestUnpackThresholdChangedEventLogValidData() {
+	abi, _ := abi.JSON(strings.NewReader(consts.BridgeABI))
+	data, err := abi.Events["RelayerThresholdChanged"].Inputs.Pack(big.NewInt(3))
+	s.Nil(err)
+
+	tc, err := s.listener.UnpackThresholdChanged(abi, data)
+	s.Nil(err)
+	s.Equal(tc.NewThreshold, big.NewInt(3))
+}
+
+func (s *EvmClientTestSuite) TestUnpackRelayerChangedRelayerRole() {
+	relayer := common.HexToAddress("0x1")
+
+	rc, err := s.listener.UnpackRelayerChanged(ethTypes.Log{
+		Topics:      []common.Hash{events.RoleGrantedSig.GetTopic(), events.RelayerRole, common.BytesToHash(relayer.Bytes()), {}},
+		BlockNumber: 10,
+		Index:       2,
+	})
+	s.Nil(err)
+	s.Equal(rc.Relayer, relayer)
+	s.Equal(rc.Block, uint64(10))
+	s.Equal(rc.Index, uint(2))
+}
+
+func (s *EvmClientTestSuite) TestUnpackRelayerChangedOtherRole() {
+	_, err := s.listener.UnpackRelayerChanged(ethTypes.Log{
+		Topics: []common.Hash{events.RoleGrantedSig.GetTopic(), {}, common.HexToHash("0x1"), {}},
+	})
+	s.NotNil(err)
+}
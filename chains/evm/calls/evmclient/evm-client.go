@@ -60,8 +60,45 @@ func (c *EVMClient) SubscribePendingTransactions(ctx context.Context, ch chan<-
 
 // LatestBlock returns the latest block from the current chain
 func (c *EVMClient) LatestBlock() (*big.Int, error) {
+	return c.blockNumberByTag(context.Background(), toBlockNumArg(nil))
+}
+
+// FinalizedBlock returns the latest block tagged as finalized by the chain
+func (c *EVMClient) FinalizedBlock(ctx context.Context) (*big.Int, error) {
+	return c.blockNumberByTag(ctx, "finalized")
+}
+
+// SafeBlock returns the latest block tagged as safe by the chain
+func (c *EVMClient) SafeBlock(ctx context.Context) (*big.Int, error) {
+	return c.blockNumberByTag(ctx, "safe")
+}
+
+// LatestBlockBefore returns the highest block from the from-to range that was mined at or before
+// the timestamp. Blocks before from are assumed to be mined before the timestamp so from-1 is
+// returned if none of the range blocks were.
+func (c *EVMClient) LatestBlockBefore(ctx context.Context, from *big.Int, to *big.Int, timestamp time.Time) (*big.Int, error) {
+	low := new(big.Int).Sub(from, big.NewInt(1))
+	high := new(big.Int).Set(to)
+	for low.Cmp(high) == -1 {
+		mid := new(big.Int).Add(low, high)
+		mid.Add(mid, big.NewInt(1))
+		mid.Rsh(mid, 1)
+		header, err := c.HeaderByNumber(ctx, mid)
+		if err != nil {
+			return nil, err
+		}
+		if header.Time <= uint64(timestamp.Unix()) {
+			low = mid
+		} else {
+			high = mid.Sub(mid, big.NewInt(1))
+		}
+	}
+	return low, nil
+}
+
+func (c *EVMClient) blockNumberByTag(ctx context.Context, tag string) (*big.Int, error) {
 	var head *headerNumber
-	err := c.rpClient.CallContext(context.Background(), &head, "eth_getBlockByNumber", toBlockNumArg(nil), false)
+	err := c.rpClient.CallContext(ctx, &head, "eth_getBlockByNumber", tag, false)
 	if err == nil && head == nil {
 		err = ethereum.NotFound
 	}
@@ -76,6 +76,10 @@ func (itx *ITXTransactor) Transact(to *common.Address, data []byte, opts transac
 	if err != nil {
 		return nil, err
 	}
+	ctx := transactor.OptionsContext(opts)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	opts.ChainID = itx.forwarder.ChainId()
 
 	defer itx.forwarder.UnlockNonce()
@@ -103,7 +107,7 @@ func (itx *ITXTransactor) Transact(to *common.Address, data []byte, opts transac
 		return nil, err
 	}
 
-	h, err := itx.sendTransaction(context.Background(), signedTx)
+	h, err := itx.sendTransaction(ctx, signedTx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,7 +1,6 @@
 package signAndSend
 
 import (
-	"context"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -31,14 +30,18 @@ func NewSignAndSendTransactor(txFabric calls.TxFabric, gasPriceClient calls.GasP
 }
 
 func (t *signAndSendTransactor) Transact(to *common.Address, data []byte, opts transactor.TransactOptions) (*common.Hash, error) {
-	t.client.LockNonce()
-	n, err := t.client.UnsafeNonce()
+	err := transactor.MergeTransactionOptions(&opts, &DefaultTransactionOptions)
 	if err != nil {
-		t.client.UnlockNonce()
+		return &common.Hash{}, err
+	}
+	// transaction is not sent if it was cancelled before acquiring the nonce
+	ctx := transactor.OptionsContext(opts)
+	if err := ctx.Err(); err != nil {
 		return &common.Hash{}, err
 	}
 
-	err = transactor.MergeTransactionOptions(&opts, &DefaultTransactionOptions)
+	t.client.LockNonce()
+	n, err := t.client.UnsafeNonce()
 	if err != nil {
 		t.client.UnlockNonce()
 		return &common.Hash{}, err
@@ -59,7 +62,7 @@ func (t *signAndSendTransactor) Transact(to *common.Address, data []byte, opts t
 		return &common.Hash{}, err
 	}
 
-	h, err := t.client.SignAndSendTransaction(context.TODO(), tx)
+	h, err := t.client.SignAndSendTransaction(ctx, tx)
 	if err != nil {
 		t.client.UnlockNonce()
 		log.Error().Err(err)
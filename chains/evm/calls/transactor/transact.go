@@ -1,6 +1,7 @@
 package transactor
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/imdario/mergo"
@@ -15,6 +16,8 @@ type TransactOptions struct {
 	Nonce    *big.Int
 	ChainID  *big.Int
 	Priority uint8
+	// Ctx cancels sending the transaction and waiting for its receipt
+	Ctx context.Context
 }
 
 // to save on data, we encode uin8 for transaction priority
@@ -33,6 +36,14 @@ func MergeTransactionOptions(primary *TransactOptions, additional *TransactOptio
 	return nil
 }
 
+// OptionsContext returns context of the transaction options or background context if it is not set
+func OptionsContext(opts TransactOptions) context.Context {
+	if opts.Ctx == nil {
+		return context.Background()
+	}
+	return opts.Ctx
+}
+
 type Transactor interface {
 	Transact(to *common.Address, data []byte, opts TransactOptions) (*common.Hash, error)
 }
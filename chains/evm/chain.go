@@ -9,48 +9,57 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
-	"strings"
 	"strconv"
 
-	secp256k1 "github.com/ethereum/go-ethereum/crypto"
-
-	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/consts"
-	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/evmclient"
-	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/evmtransaction"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/executor"
 	"github.com/VaivalGithub/chainsafe-core/config/chain"
 	"github.com/VaivalGithub/chainsafe-core/relayer/message"
 	"github.com/VaivalGithub/chainsafe-core/store"
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rs/zerolog/log"
-
-	"github.com/VaivalGithub/chainsafe-core/e2e/dummy"
-
-	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor/signAndSend"
-
-	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/contracts/bridge"
 )
 
 type EventListener interface {
 	ListenToEvents(ctx context.Context, startBlock *big.Int, msgChan chan *message.Message, errChan chan<- error)
 }
 
+type EventReplayer interface {
+	Replay(ctx context.Context, from *big.Int, to *big.Int, msgChan chan *message.Message) error
+}
+
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+type BridgeContract interface {
+	ContractAddress() *common.Address
+	PackMethod(method string, args ...interface{}) ([]byte, error)
+}
+
 type ProposalExecutor interface {
-	Execute(message *message.Message, opts transactor.TransactOptions) error
+	Execute(ctx context.Context, message *message.Message, opts transactor.TransactOptions) error
 	// FeeClaimByRelayer(p *message.Message) error
 	// IsFeeThresholdReached() bool
 }
 
+// Waiter is implemented by writers that follow up on written messages in the background
+type Waiter interface {
+	Wait()
+}
+
 // EVMChain is struct that aggregates all data required for interacting with target chains.
 type EVMChain struct {
 	listener   EventListener
 	writer     ProposalExecutor
+	registrar  ProposalExecutor
 	blockstore *store.BlockStore
 	config     *chain.EVMConfig
+
+	gasEstimator   GasEstimator
+	messageHandler executor.MessageHandler
+	bridge         BridgeContract
 }
 
 func NewEVMChain(listener EventListener, writer ProposalExecutor, blockstore *store.BlockStore, config *chain.EVMConfig) *EVMChain {
@@ -59,8 +68,38 @@ func NewEVMChain(listener EventListener, writer ProposalExecutor, blockstore *st
 	return &EVMChain{listener: listener, writer: writer, blockstore: blockstore, config: config}
 }
 
-// PollEvents is the goroutine that polls blocks and searches Deposit events in them.
-// Events are then sent to eventsChan.
+// SetObserver passes observer to the listener and the writer if they report message lifecycle transitions
+func (c *EVMChain) SetObserver(o message.Observer) {
+	if observable, ok := c.listener.(message.Observable); ok {
+		observable.SetObserver(o)
+	}
+	if observable, ok := c.writer.(message.Observable); ok {
+		observable.SetObserver(o)
+	}
+}
+
+// Wait blocks until the writer stops following up on written messages in the background
+func (c *EVMChain) Wait() {
+	if waiter, ok := c.writer.(Waiter); ok {
+		waiter.Wait()
+	}
+}
+
+// SetTokenRegistrar sets executor that mirrors token registrations relayed to this chain
+func (c *EVMChain) SetTokenRegistrar(registrar ProposalExecutor) {
+	c.registrar = registrar
+}
+
+// SetVoteGasEstimator enables estimating gas of votes with the chain client. Without it votes
+// are sent with the configured gas limit and price and vote costs can not be estimated.
+func (c *EVMChain) SetVoteGasEstimator(gasEstimator GasEstimator, messageHandler executor.MessageHandler, bridge BridgeContract) {
+	c.gasEstimator = gasEstimator
+	c.messageHandler = messageHandler
+	c.bridge = bridge
+}
+
+// PollEvents polls blocks and searches Deposit events in them.
+// Events are then sent to eventsChan. It returns once ctx is cancelled and the listener stopped.
 func (c *EVMChain) PollEvents(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
 	log.Info().Msg("Polling Blocks...")
 
@@ -75,177 +114,172 @@ func (c *EVMChain) PollEvents(ctx context.Context, sysErr chan<- error, msgChan
 		return
 	}
 
-	go c.listener.ListenToEvents(ctx, startBlock, msgChan, sysErr)
+	c.listener.ListenToEvents(ctx, startBlock, msgChan, sysErr)
+}
+
+// Replay emits messages of the from-to block range again without moving the blockstore cursor
+func (c *EVMChain) Replay(ctx context.Context, from *big.Int, to *big.Int, msgChan chan *message.Message) error {
+	replayer, ok := c.listener.(EventReplayer)
+	if !ok {
+		return fmt.Errorf("listener of domain %d does not support replaying blocks", c.DomainID())
+	}
+	return replayer.Replay(ctx, from, to, msgChan)
 }
 
-func (c *EVMChain) Write(msg *message.Message) error {
-	/*
-		GasPrice and GasLimit need to be dynamically calculated
-		For this we need a method that returns the gas prices for all chains dynamically.
-	*/
-	fmt.Printf("\nCalculating GasPrice and GasLimit...\n")
-	chainProvider, err := ethclient.Dial(c.config.GeneralChainConfig.Endpoint)
+func (c *EVMChain) Write(ctx context.Context, msg *message.Message) error {
+	if msg.Type == message.TokenRegistration {
+		return c.writeTokenRegistration(ctx, msg)
+	}
+
+	gasLimit, gasPrice, err := c.voteGas(ctx, msg)
 	if err != nil {
-		fmt.Println("\nFailed to create HTTP provider, resorting to default values:", err)
-	} else {
-		// Create a new HTTP request
-		req, err := http.NewRequest("GET", c.config.GeneralChainConfig.EgsApi, nil)
-		if err != nil {
-			fmt.Println("\nError creating HTTP request for fetching gas:", err)
-		}
-		gasProvider := http.DefaultClient
-		resp, err := gasProvider.Do(req)
+		log.Warn().Err(err).Uint8("domainID", c.DomainID()).Msg("Failed estimating vote gas, using configured gas limit and price")
+		return c.writer.Execute(ctx, msg, transactor.TransactOptions{
+			GasLimit: c.config.GasLimit.Uint64(),
+			GasPrice: c.config.MaxGasPrice,
+			Priority: txPriority(msg),
+		})
+	}
+	return c.writer.Execute(ctx, msg, transactor.TransactOptions{
+		GasLimit: gasLimit,
+		GasPrice: gasPrice,
+		Priority: txPriority(msg),
+	})
+}
+
+// EstimateVoteCost returns the maximum cost of voting on the message proposal
+// in wei of the native token based on the gas used for writing the message
+func (c *EVMChain) EstimateVoteCost(ctx context.Context, msg *message.Message) (*big.Int, error) {
+	gasLimit, gasPrice, err := c.voteGas(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice), nil
+}
+
+// voteGas estimates gas limit of the voteProposal transaction multiplied by the configured gas multiplier
+// and fetches max fee per gas of the gas station tier matching the message priority
+func (c *EVMChain) voteGas(ctx context.Context, msg *message.Message) (uint64, *big.Int, error) {
+	if c.gasEstimator == nil {
+		return 0, nil, fmt.Errorf("vote gas estimation is not configured on domain %d", c.DomainID())
+	}
+
+	maxFeePerGas, err := c.gasStationPrice(ctx, txPriority(msg))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	proposal, err := c.messageHandler.HandleMessage(msg)
+	if err != nil {
+		return 0, nil, err
+	}
+	encodedPayload, err := c.bridge.PackMethod("voteProposal", proposal.Source, proposal.DepositNonce, proposal.ResourceId, proposal.Data)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error encoding calldata: %w", err)
+	}
+	callMsg := ethereum.CallMsg{
+		From:  common.HexToAddress(c.config.GeneralChainConfig.From),
+		To:    c.bridge.ContractAddress(),
+		Data:  encodedPayload,
+		Value: big.NewInt(0),
+	}
+	estimatedGas, err := c.gasEstimator.EstimateGas(ctx, callMsg)
+	if err != nil {
+		return 0, nil, fmt.Errorf("error while estimating gas: %w", err)
+	}
+
+	// Multiplying with gas multiplier
+	gasEstimateFloat := new(big.Float).SetUint64(estimatedGas)
+	totalGasFloat := new(big.Float).Mul(gasEstimateFloat, c.config.GasMultiplier)
+	totalGasInt := new(big.Int)
+	totalGasFloat.Int(totalGasInt)
+	gasLimit := totalGasInt.Uint64()
+
+	log.Debug().Uint8("domainID", c.DomainID()).Msgf("Estimated vote gas of message %+v: estimated gas %d, gas limit %d, max fee per gas %s", msg, estimatedGas, gasLimit, maxFeePerGas)
+	return gasLimit, maxFeePerGas, nil
+}
+
+// gasStationPrice fetches max fee per gas in wei from the gas station tier matching the priority
+func (c *EVMChain) gasStationPrice(ctx context.Context, priority uint8) (*big.Int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.config.GeneralChainConfig.EgsApi, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating HTTP request for fetching gas: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching gas: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var dataJson map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&dataJson)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding JSON response: %w", err)
+	}
+
+	tierGas, err := gasStationTier(dataJson, priority)
+	if err != nil {
+		return nil, err
+	}
+	var maxGasFloat float64
+	switch maxGas := tierGas["maxFee"].(type) {
+	case string:
+		maxGasFloat, err = strconv.ParseFloat(maxGas, 64)
 		if err != nil {
-			fmt.Println("\nError fetching gas:", err)
-		} else {
-			defer resp.Body.Close()
-			var dataJson map[string]interface{}
-			err = json.NewDecoder(resp.Body).Decode(&dataJson)
-			if err != nil {
-				fmt.Println("\nError decoding JSON response:", err)
-			}
-			// Next we fetch the maxFeePerGas and maxPriorityFeePerGas from the JSON
-			// fastGas := dataJson["fast"].(map[string]interface{})
-			// maxFastGas := fastGas["maxFee"].(string)
-			// maxPriorityGas := fastGas["maxPriorityFee"].(string)
-			// fmt.Println("Max Fast Gas:", maxFastGas)
-			// fmt.Println("Max Priority Gas:", maxPriorityGas)
-
-			// maxFastGasFloat, err := strconv.ParseFloat(maxFastGas, 64)
-			// if err != nil {
-			// 	fmt.Println("\nError parsing maxFastGas:", err)
-			// }
-			// maxFastGasWei := int64(maxFastGasFloat * 1000000000)
-
-
-			// // maxFastGasWei := maxFastGas * 1000000000
-			// maxFeePerGas := big.NewInt(int64(maxFastGasWei))
-
-            // maxFastGasFloat, err := strconv.ParseFloat(maxFastGas, 64)
-			// if err != nil {
-			// 	fmt.Println("\nError parsing maxFastGas:", err)
-			// }
-			// maxFastGasWei := int64(maxFastGasFloat * 1000000000)
-			
-			// maxFeePerGas := big.NewInt(maxFastGasWei)
-
-
-
-
-
-			fastGas := dataJson["fast"].(map[string]interface{})
-			maxFastGas := fastGas["maxFee"]
-			
-			var maxFastGasFloat float64
-			var maxFastGasWei int64
-			var err error
-			
-			if maxFastGasStr, ok := maxFastGas.(string); ok {
-				maxFastGasFloat, err = strconv.ParseFloat(maxFastGasStr, 64)
-				if err != nil {
-					fmt.Println("\nError parsing maxFastGas:", err)
-				}
-				maxFastGasWei = int64(maxFastGasFloat * 1000000000)
-			} else if maxFastGasFloat, ok := maxFastGas.(float64); ok {
-				maxFastGasWei = int64(maxFastGasFloat * 1000000000)
-			} else {
-				fmt.Println("\nInvalid type for maxFastGas")
-				// Handle the error accordingly
-			}
-			
-			maxFeePerGas := big.NewInt(maxFastGasWei)
-			
-
-
-
-
-
-
-			// Estimating gasLimit
-			fromAddress := common.HexToAddress(c.config.GeneralChainConfig.From)
-			toAddress := common.HexToAddress(c.config.Bridge)
-			bridgeABI, err := abi.JSON(strings.NewReader(consts.BridgeABI))
-			if err != nil {
-				fmt.Println("\nError parsng Bridge ABI:", err)
-			}
-			privateKey, err := secp256k1.HexToECDSA(c.config.GeneralChainConfig.Key)
-			if err != nil {
-				panic(err)
-			}
-			client, err := evmclient.NewEVMClient(c.config.GeneralChainConfig.Endpoint, privateKey)
-			if err != nil {
-				panic(err)
-			}
-			dummyGasPricer := dummy.NewStaticGasPriceDeterminant(client, nil)
-			t := signAndSend.NewSignAndSendTransactor(evmtransaction.NewTransaction, dummyGasPricer, client)
-			bridgeContract := bridge.NewBridgeContract(client, common.HexToAddress(c.config.Bridge), t)
-			fmt.Println("Message Payload:", msg)
-			mh := executor.NewEVMMessageHandler(bridgeContract)
-			mh.RegisterMessageHandler(c.config.Erc20Handler, executor.ERC20MessageHandler)
-			mh.RegisterMessageHandler(c.config.Erc721Handler, executor.ERC721MessageHandler)
-			mh.RegisterMessageHandler(c.config.GenericHandler, executor.GenericMessageHandler)
-			proposal, err := mh.HandleMessage(msg)
-			encodedPayload, err := bridgeABI.Pack("voteProposal", proposal.Source, proposal.DepositNonce, proposal.ResourceId, proposal.Data)
-			if err != nil {
-				fmt.Println("\nError Encoding Calldata:", err)
-			}
-			value := big.NewInt(0)
-			fmt.Printf("\nFrom: %+v \n To: %+v \n Data: %+v \n Value: %+v \n GasPrice: %+v", fromAddress, &toAddress, encodedPayload, value, maxFeePerGas)
-			estimatedGas, err := chainProvider.EstimateGas(context.Background(), ethereum.CallMsg{
-				From:  fromAddress,
-				To:    &toAddress,
-				Data:  encodedPayload,
-				Value: value,
-				// GasPrice: maxFeePerGas,
-			})
-			if err != nil {
-				fmt.Println("\nError while estimating Gas:", err)
-			}
-			// Multiplying with gas multiplier
-			// multiplier := c.config.GasMultiplier
-
-			// Convert the estimatedGas to a big.Float
-	       // gasEstimateFloat := new(big.Float).SetUint64(estimatedGas)
-	        // multiplier := big.NewInt(10)
-
-		multiplier := c.config.GasMultiplier
-		gasEstimateFloat := new(big.Float).SetUint64(estimatedGas)
-	//	totalGasLimit := gasEstimateFloat.Mul(gasEstimateFloat, multiplier)
-	//	gasLimit := uint64(totalGasLimit.Acc())
-
-			
-		//multiplierInt := big.NewInt(c.config.GasMultiplier)
-               // multiplierFloat := new(big.Float).SetInt(multiplierInt)
-		//multiplier := c.config.GasMultiplier
-	        // Convert multiplierBigInt to *big.Float
-	        //multiplierFloat := new(big.Float).SetInt(multiplier)
-
-        	// Multiply gasEstimateFloat by the multiplierFloat and store the result in totalGasFloat
-	        totalGasFloat := new(big.Float).Mul(gasEstimateFloat, multiplier)
-
-	        // Convert totalGasFloat to a *big.Int
-	        totalGasInt := new(big.Int)
-	        totalGasFloat.Int(totalGasInt)
-	        gasLimit := totalGasInt.Uint64()
-
-
-			// gasLimit := uint64(totalGasLimit.Acc())
-			fmt.Printf("\nGas Limit: [%+v], Gas Price: [%+v], Multiplied Gas: [%+v]\n", estimatedGas, maxFeePerGas, gasLimit)
-			return c.writer.Execute(msg, transactor.TransactOptions{
-				// GasLimit: estimatedGas,
-				GasLimit: gasLimit,
-                GasPrice: maxFeePerGas,
-			})
+			return nil, fmt.Errorf("error parsing maxFee: %w", err)
 		}
+	case float64:
+		maxGasFloat = maxGas
+	default:
+		return nil, fmt.Errorf("invalid type %T for maxFee", maxGas)
 	}
-	// the EVMChain contains the config. Let's log it.
-	//fmt.Printf("\nDefault Config for VoteProposal: [%+v]\n", c.config)
-	return c.writer.Execute(msg, transactor.TransactOptions{
+	// gas station prices are in gwei
+	return big.NewInt(int64(maxGasFloat * 1000000000)), nil
+}
+
+func (c *EVMChain) writeTokenRegistration(ctx context.Context, msg *message.Message) error {
+	if c.registrar == nil {
+		return fmt.Errorf("token registrations are not supported on domain %d", c.DomainID())
+	}
+	return c.registrar.Execute(ctx, msg, transactor.TransactOptions{
 		GasLimit: c.config.GasLimit.Uint64(),
 		GasPrice: c.config.MaxGasPrice,
+		Priority: txPriority(msg),
 	})
 }
 
+// gasStationTiers maps transaction priorities to gas station tiers,
+// deposits without priority use the fast tier
+var gasStationTiers = map[uint8]string{
+	transactor.TxPriorities["none"]:   "fast",
+	transactor.TxPriorities["slow"]:   "safeLow",
+	transactor.TxPriorities["medium"]: "standard",
+	transactor.TxPriorities["fast"]:   "fast",
+}
+
+// txPriority returns transaction priority paid for by the depositor, unknown priorities are treated as none
+func txPriority(msg *message.Message) uint8 {
+	if msg.Metadata.Priority > transactor.TxPriorities["fast"] {
+		return transactor.TxPriorities["none"]
+	}
+	return msg.Metadata.Priority
+}
+
+// gasStationTier returns gas station prices of the tier matching message priority
+// falling back to the fast tier if the gas station does not provide it
+func gasStationTier(prices map[string]interface{}, priority uint8) (map[string]interface{}, error) {
+	if tier, ok := gasStationTiers[priority]; ok {
+		if tierPrices, ok := prices[tier].(map[string]interface{}); ok {
+			return tierPrices, nil
+		}
+	}
+	fastPrices, ok := prices["fast"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("gas station response is missing fast tier")
+	}
+	return fastPrices, nil
+}
+
 func (c *EVMChain) DomainID() uint8 {
 	return *c.config.GeneralChainConfig.Id
 }
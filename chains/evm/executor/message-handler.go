@@ -2,7 +2,6 @@ package executor
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"math/big"
 
@@ -76,17 +75,12 @@ func (mh *EVMMessageHandler) RegisterMessageHandler(address string, handler Mess
 }
 
 func ERC20MessageHandler(m *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
-	if len(m.Payload) != 2 {
-		return nil, errors.New("malformed payload. Len  of payload should be 2")
-	}
-	amount, ok := m.Payload[0].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payload amount format")
-	}
-	recipient, ok := m.Payload[1].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payload recipient format")
+	payload, err := m.FungiblePayload()
+	if err != nil {
+		return nil, err
 	}
+	amount := payload.Amount.Bytes()
+	recipient := payload.Recipient
 	var data []byte
 	data = append(data, common.LeftPadBytes(amount, 32)...) // amount (uint256)
 	recipientLen := big.NewInt(int64(len(recipient))).Bytes()
@@ -96,22 +90,13 @@ func ERC20MessageHandler(m *message.Message, handlerAddr, bridgeAddress common.A
 }
 
 func ERC721MessageHandler(msg *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
-
-	if len(msg.Payload) != 3 {
-		return nil, errors.New("malformed payload. Len  of payload should be 3")
-	}
-	tokenID, ok := msg.Payload[0].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payload tokenID format")
-	}
-	recipient, ok := msg.Payload[1].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payload recipient format")
-	}
-	metadata, ok := msg.Payload[2].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payload metadata format")
+	payload, err := msg.NonFungiblePayload()
+	if err != nil {
+		return nil, err
 	}
+	tokenID := payload.TokenID.Bytes()
+	recipient := payload.Recipient
+	metadata := payload.Metadata
 	data := bytes.Buffer{}
 	data.Write(common.LeftPadBytes(tokenID, 32))
 	recipientLen := big.NewInt(int64(len(recipient))).Bytes()
@@ -124,13 +109,11 @@ func ERC721MessageHandler(msg *message.Message, handlerAddr, bridgeAddress commo
 }
 
 func GenericMessageHandler(msg *message.Message, handlerAddr, bridgeAddress common.Address) (*proposal.Proposal, error) {
-	if len(msg.Payload) != 1 {
-		return nil, errors.New("malformed payload. Len  of payload should be 1")
-	}
-	metadata, ok := msg.Payload[0].([]byte)
-	if !ok {
-		return nil, errors.New("wrong payload metadata format")
+	payload, err := msg.GenericPayload()
+	if err != nil {
+		return nil, err
 	}
+	metadata := payload.Metadata
 	data := bytes.Buffer{}
 	metadataLen := big.NewInt(int64(len(metadata))).Bytes()
 	data.Write(common.LeftPadBytes(metadataLen, 32)) // length of metadata (uint256)
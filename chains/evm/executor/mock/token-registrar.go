@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/executor/token-registrar.go
+
+// Package mock_executor is a generated GoMock package.
+package mock_executor
+
+import (
+	reflect "reflect"
+
+	transactor "github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor"
+	types "github.com/VaivalGithub/chainsafe-core/types"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRelayerAddresser is a mock of RelayerAddresser interface.
+type MockRelayerAddresser struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelayerAddresserMockRecorder
+}
+
+// MockRelayerAddresserMockRecorder is the mock recorder for MockRelayerAddresser.
+type MockRelayerAddresserMockRecorder struct {
+	mock *MockRelayerAddresser
+}
+
+// NewMockRelayerAddresser creates a new mock instance.
+func NewMockRelayerAddresser(ctrl *gomock.Controller) *MockRelayerAddresser {
+	mock := &MockRelayerAddresser{ctrl: ctrl}
+	mock.recorder = &MockRelayerAddresserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelayerAddresser) EXPECT() *MockRelayerAddresserMockRecorder {
+	return m.recorder
+}
+
+// RelayerAddress mocks base method.
+func (m *MockRelayerAddresser) RelayerAddress() common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayerAddress")
+	ret0, _ := ret[0].(common.Address)
+	return ret0
+}
+
+// RelayerAddress indicates an expected call of RelayerAddress.
+func (mr *MockRelayerAddresserMockRecorder) RelayerAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayerAddress", reflect.TypeOf((*MockRelayerAddresser)(nil).RelayerAddress))
+}
+
+// MockAdminBridgeContract is a mock of AdminBridgeContract interface.
+type MockAdminBridgeContract struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminBridgeContractMockRecorder
+}
+
+// MockAdminBridgeContractMockRecorder is the mock recorder for MockAdminBridgeContract.
+type MockAdminBridgeContractMockRecorder struct {
+	mock *MockAdminBridgeContract
+}
+
+// NewMockAdminBridgeContract creates a new mock instance.
+func NewMockAdminBridgeContract(ctrl *gomock.Controller) *MockAdminBridgeContract {
+	mock := &MockAdminBridgeContract{ctrl: ctrl}
+	mock.recorder = &MockAdminBridgeContractMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminBridgeContract) EXPECT() *MockAdminBridgeContractMockRecorder {
+	return m.recorder
+}
+
+// AdminSetResource mocks base method.
+func (m *MockAdminBridgeContract) AdminSetResource(handlerAddr common.Address, rID types.ResourceID, targetContractAddr common.Address, opts transactor.TransactOptions) (*common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdminSetResource", handlerAddr, rID, targetContractAddr, opts)
+	ret0, _ := ret[0].(*common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdminSetResource indicates an expected call of AdminSetResource.
+func (mr *MockAdminBridgeContractMockRecorder) AdminSetResource(handlerAddr, rID, targetContractAddr, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdminSetResource", reflect.TypeOf((*MockAdminBridgeContract)(nil).AdminSetResource), handlerAddr, rID, targetContractAddr, opts)
+}
+
+// ContractAddress mocks base method.
+func (m *MockAdminBridgeContract) ContractAddress() *common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContractAddress")
+	ret0, _ := ret[0].(*common.Address)
+	return ret0
+}
+
+// ContractAddress indicates an expected call of ContractAddress.
+func (mr *MockAdminBridgeContractMockRecorder) ContractAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractAddress", reflect.TypeOf((*MockAdminBridgeContract)(nil).ContractAddress))
+}
+
+// GetHandlerAddressForResourceID mocks base method.
+func (m *MockAdminBridgeContract) GetHandlerAddressForResourceID(resourceID types.ResourceID) (common.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHandlerAddressForResourceID", resourceID)
+	ret0, _ := ret[0].(common.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHandlerAddressForResourceID indicates an expected call of GetHandlerAddressForResourceID.
+func (mr *MockAdminBridgeContractMockRecorder) GetHandlerAddressForResourceID(resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHandlerAddressForResourceID", reflect.TypeOf((*MockAdminBridgeContract)(nil).GetHandlerAddressForResourceID), resourceID)
+}
+
+// IsAdmin mocks base method.
+func (m *MockAdminBridgeContract) IsAdmin(address common.Address) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdmin", address)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAdmin indicates an expected call of IsAdmin.
+func (mr *MockAdminBridgeContractMockRecorder) IsAdmin(address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockAdminBridgeContract)(nil).IsAdmin), address)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/chainbridge-core/chains/evm/executor (interfaces: ChainClient,MessageHandler,BridgeContract)
+// Source: ./chains/evm/executor/voter.go
 
 // Package mock_executor is a generated GoMock package.
 package mock_executor
@@ -13,6 +13,7 @@ import (
 	transactor "github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor"
 	proposal "github.com/VaivalGithub/chainsafe-core/chains/evm/executor/proposal"
 	message "github.com/VaivalGithub/chainsafe-core/relayer/message"
+	store "github.com/VaivalGithub/chainsafe-core/store"
 	common "github.com/ethereum/go-ethereum/common"
 	types "github.com/ethereum/go-ethereum/core/types"
 	rpc "github.com/ethereum/go-ethereum/rpc"
@@ -43,33 +44,33 @@ func (m *MockChainClient) EXPECT() *MockChainClientMockRecorder {
 }
 
 // CallContract mocks base method.
-func (m *MockChainClient) CallContract(arg0 context.Context, arg1 map[string]interface{}, arg2 *big.Int) ([]byte, error) {
+func (m *MockChainClient) CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CallContract", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CallContract", ctx, callArgs, blockNumber)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CallContract indicates an expected call of CallContract.
-func (mr *MockChainClientMockRecorder) CallContract(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockChainClientMockRecorder) CallContract(ctx, callArgs, blockNumber interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallContract", reflect.TypeOf((*MockChainClient)(nil).CallContract), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CallContract", reflect.TypeOf((*MockChainClient)(nil).CallContract), ctx, callArgs, blockNumber)
 }
 
 // CodeAt mocks base method.
-func (m *MockChainClient) CodeAt(arg0 context.Context, arg1 common.Address, arg2 *big.Int) ([]byte, error) {
+func (m *MockChainClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CodeAt", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CodeAt", ctx, contract, blockNumber)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CodeAt indicates an expected call of CodeAt.
-func (mr *MockChainClientMockRecorder) CodeAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockChainClientMockRecorder) CodeAt(ctx, contract, blockNumber interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CodeAt", reflect.TypeOf((*MockChainClient)(nil).CodeAt), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CodeAt", reflect.TypeOf((*MockChainClient)(nil).CodeAt), ctx, contract, blockNumber)
 }
 
 // From mocks base method.
@@ -87,9 +88,9 @@ func (mr *MockChainClientMockRecorder) From() *gomock.Call {
 }
 
 // GetTransactionByHash mocks base method.
-func (m *MockChainClient) GetTransactionByHash(arg0 common.Hash) (*types.Transaction, bool, error) {
+func (m *MockChainClient) GetTransactionByHash(h common.Hash) (*types.Transaction, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransactionByHash", arg0)
+	ret := m.ctrl.Call(m, "GetTransactionByHash", h)
 	ret0, _ := ret[0].(*types.Transaction)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -97,9 +98,9 @@ func (m *MockChainClient) GetTransactionByHash(arg0 common.Hash) (*types.Transac
 }
 
 // GetTransactionByHash indicates an expected call of GetTransactionByHash.
-func (mr *MockChainClientMockRecorder) GetTransactionByHash(arg0 interface{}) *gomock.Call {
+func (mr *MockChainClientMockRecorder) GetTransactionByHash(h interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockChainClient)(nil).GetTransactionByHash), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionByHash", reflect.TypeOf((*MockChainClient)(nil).GetTransactionByHash), h)
 }
 
 // LockNonce mocks base method.
@@ -129,39 +130,39 @@ func (mr *MockChainClientMockRecorder) RelayerAddress() *gomock.Call {
 }
 
 // SignAndSendTransaction mocks base method.
-func (m *MockChainClient) SignAndSendTransaction(arg0 context.Context, arg1 evmclient.CommonTransaction) (common.Hash, error) {
+func (m *MockChainClient) SignAndSendTransaction(ctx context.Context, tx evmclient.CommonTransaction) (common.Hash, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SignAndSendTransaction", arg0, arg1)
+	ret := m.ctrl.Call(m, "SignAndSendTransaction", ctx, tx)
 	ret0, _ := ret[0].(common.Hash)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SignAndSendTransaction indicates an expected call of SignAndSendTransaction.
-func (mr *MockChainClientMockRecorder) SignAndSendTransaction(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockChainClientMockRecorder) SignAndSendTransaction(ctx, tx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAndSendTransaction", reflect.TypeOf((*MockChainClient)(nil).SignAndSendTransaction), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignAndSendTransaction", reflect.TypeOf((*MockChainClient)(nil).SignAndSendTransaction), ctx, tx)
 }
 
 // SubscribePendingTransactions mocks base method.
-func (m *MockChainClient) SubscribePendingTransactions(arg0 context.Context, arg1 chan<- common.Hash) (*rpc.ClientSubscription, error) {
+func (m *MockChainClient) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (*rpc.ClientSubscription, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubscribePendingTransactions", arg0, arg1)
+	ret := m.ctrl.Call(m, "SubscribePendingTransactions", ctx, ch)
 	ret0, _ := ret[0].(*rpc.ClientSubscription)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SubscribePendingTransactions indicates an expected call of SubscribePendingTransactions.
-func (mr *MockChainClientMockRecorder) SubscribePendingTransactions(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockChainClientMockRecorder) SubscribePendingTransactions(ctx, ch interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePendingTransactions", reflect.TypeOf((*MockChainClient)(nil).SubscribePendingTransactions), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePendingTransactions", reflect.TypeOf((*MockChainClient)(nil).SubscribePendingTransactions), ctx, ch)
 }
 
 // TransactionByHash mocks base method.
-func (m *MockChainClient) TransactionByHash(arg0 context.Context, arg1 common.Hash) (*types.Transaction, bool, error) {
+func (m *MockChainClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "TransactionByHash", arg0, arg1)
+	ret := m.ctrl.Call(m, "TransactionByHash", ctx, hash)
 	ret0, _ := ret[0].(*types.Transaction)
 	ret1, _ := ret[1].(bool)
 	ret2, _ := ret[2].(error)
@@ -169,9 +170,24 @@ func (m *MockChainClient) TransactionByHash(arg0 context.Context, arg1 common.Ha
 }
 
 // TransactionByHash indicates an expected call of TransactionByHash.
-func (mr *MockChainClientMockRecorder) TransactionByHash(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockChainClientMockRecorder) TransactionByHash(ctx, hash interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionByHash", reflect.TypeOf((*MockChainClient)(nil).TransactionByHash), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionByHash", reflect.TypeOf((*MockChainClient)(nil).TransactionByHash), ctx, hash)
+}
+
+// TransactionReceipt mocks base method.
+func (m *MockChainClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransactionReceipt", ctx, txHash)
+	ret0, _ := ret[0].(*types.Receipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransactionReceipt indicates an expected call of TransactionReceipt.
+func (mr *MockChainClientMockRecorder) TransactionReceipt(ctx, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransactionReceipt", reflect.TypeOf((*MockChainClient)(nil).TransactionReceipt), ctx, txHash)
 }
 
 // UnlockNonce mocks base method.
@@ -216,18 +232,18 @@ func (mr *MockChainClientMockRecorder) UnsafeNonce() *gomock.Call {
 }
 
 // WaitAndReturnTxReceipt mocks base method.
-func (m *MockChainClient) WaitAndReturnTxReceipt(arg0 common.Hash) (*types.Receipt, error) {
+func (m *MockChainClient) WaitAndReturnTxReceipt(h common.Hash) (*types.Receipt, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WaitAndReturnTxReceipt", arg0)
+	ret := m.ctrl.Call(m, "WaitAndReturnTxReceipt", h)
 	ret0, _ := ret[0].(*types.Receipt)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // WaitAndReturnTxReceipt indicates an expected call of WaitAndReturnTxReceipt.
-func (mr *MockChainClientMockRecorder) WaitAndReturnTxReceipt(arg0 interface{}) *gomock.Call {
+func (mr *MockChainClientMockRecorder) WaitAndReturnTxReceipt(h interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitAndReturnTxReceipt", reflect.TypeOf((*MockChainClient)(nil).WaitAndReturnTxReceipt), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitAndReturnTxReceipt", reflect.TypeOf((*MockChainClient)(nil).WaitAndReturnTxReceipt), h)
 }
 
 // MockMessageHandler is a mock of MessageHandler interface.
@@ -254,18 +270,18 @@ func (m *MockMessageHandler) EXPECT() *MockMessageHandlerMockRecorder {
 }
 
 // HandleMessage mocks base method.
-func (m *MockMessageHandler) HandleMessage(arg0 *message.Message) (*proposal.Proposal, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HandleMessage", arg0)
+func (m_2 *MockMessageHandler) HandleMessage(m *message.Message) (*proposal.Proposal, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "HandleMessage", m)
 	ret0, _ := ret[0].(*proposal.Proposal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HandleMessage indicates an expected call of HandleMessage.
-func (mr *MockMessageHandlerMockRecorder) HandleMessage(arg0 interface{}) *gomock.Call {
+func (mr *MockMessageHandlerMockRecorder) HandleMessage(m interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMessage", reflect.TypeOf((*MockMessageHandler)(nil).HandleMessage), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleMessage", reflect.TypeOf((*MockMessageHandler)(nil).HandleMessage), m)
 }
 
 // MockBridgeContract is a mock of BridgeContract interface.
@@ -292,10 +308,10 @@ func (m *MockBridgeContract) EXPECT() *MockBridgeContractMockRecorder {
 }
 
 // GetThreshold mocks base method.
-func (m *MockBridgeContract) GetThreshold() (byte, error) {
+func (m *MockBridgeContract) GetThreshold() (uint8, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetThreshold")
-	ret0, _ := ret[0].(byte)
+	ret0, _ := ret[0].(uint8)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -307,60 +323,190 @@ func (mr *MockBridgeContractMockRecorder) GetThreshold() *gomock.Call {
 }
 
 // IsProposalVotedBy mocks base method.
-func (m *MockBridgeContract) IsProposalVotedBy(arg0 common.Address, arg1 *proposal.Proposal) (bool, error) {
+func (m *MockBridgeContract) IsProposalVotedBy(by common.Address, p *proposal.Proposal) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsProposalVotedBy", arg0, arg1)
+	ret := m.ctrl.Call(m, "IsProposalVotedBy", by, p)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // IsProposalVotedBy indicates an expected call of IsProposalVotedBy.
-func (mr *MockBridgeContractMockRecorder) IsProposalVotedBy(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockBridgeContractMockRecorder) IsProposalVotedBy(by, p interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProposalVotedBy", reflect.TypeOf((*MockBridgeContract)(nil).IsProposalVotedBy), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsProposalVotedBy", reflect.TypeOf((*MockBridgeContract)(nil).IsProposalVotedBy), by, p)
+}
+
+// IsRelayer mocks base method.
+func (m *MockBridgeContract) IsRelayer(relayerAddress common.Address) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRelayer", relayerAddress)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRelayer indicates an expected call of IsRelayer.
+func (mr *MockBridgeContractMockRecorder) IsRelayer(relayerAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRelayer", reflect.TypeOf((*MockBridgeContract)(nil).IsRelayer), relayerAddress)
 }
 
 // ProposalStatus mocks base method.
-func (m *MockBridgeContract) ProposalStatus(arg0 *proposal.Proposal) (message.ProposalStatus, error) {
+func (m *MockBridgeContract) ProposalStatus(p *proposal.Proposal) (message.ProposalStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProposalStatus", arg0)
+	ret := m.ctrl.Call(m, "ProposalStatus", p)
 	ret0, _ := ret[0].(message.ProposalStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ProposalStatus indicates an expected call of ProposalStatus.
-func (mr *MockBridgeContractMockRecorder) ProposalStatus(arg0 interface{}) *gomock.Call {
+func (mr *MockBridgeContractMockRecorder) ProposalStatus(p interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposalStatus", reflect.TypeOf((*MockBridgeContract)(nil).ProposalStatus), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposalStatus", reflect.TypeOf((*MockBridgeContract)(nil).ProposalStatus), p)
 }
 
 // SimulateVoteProposal mocks base method.
-func (m *MockBridgeContract) SimulateVoteProposal(arg0 *proposal.Proposal) error {
+func (m *MockBridgeContract) SimulateVoteProposal(proposal *proposal.Proposal) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SimulateVoteProposal", arg0)
+	ret := m.ctrl.Call(m, "SimulateVoteProposal", proposal)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // SimulateVoteProposal indicates an expected call of SimulateVoteProposal.
-func (mr *MockBridgeContractMockRecorder) SimulateVoteProposal(arg0 interface{}) *gomock.Call {
+func (mr *MockBridgeContractMockRecorder) SimulateVoteProposal(proposal interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateVoteProposal", reflect.TypeOf((*MockBridgeContract)(nil).SimulateVoteProposal), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateVoteProposal", reflect.TypeOf((*MockBridgeContract)(nil).SimulateVoteProposal), proposal)
 }
 
 // VoteProposal mocks base method.
-func (m *MockBridgeContract) VoteProposal(arg0 *proposal.Proposal, arg1 transactor.TransactOptions) (*common.Hash, error) {
+func (m *MockBridgeContract) VoteProposal(proposal *proposal.Proposal, opts transactor.TransactOptions) (*common.Hash, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VoteProposal", arg0, arg1)
+	ret := m.ctrl.Call(m, "VoteProposal", proposal, opts)
 	ret0, _ := ret[0].(*common.Hash)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // VoteProposal indicates an expected call of VoteProposal.
-func (mr *MockBridgeContractMockRecorder) VoteProposal(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockBridgeContractMockRecorder) VoteProposal(proposal, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteProposal", reflect.TypeOf((*MockBridgeContract)(nil).VoteProposal), proposal, opts)
+}
+
+// MockProposalStore is a mock of ProposalStore interface.
+type MockProposalStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalStoreMockRecorder
+}
+
+// MockProposalStoreMockRecorder is the mock recorder for MockProposalStore.
+type MockProposalStoreMockRecorder struct {
+	mock *MockProposalStore
+}
+
+// NewMockProposalStore creates a new mock instance.
+func NewMockProposalStore(ctrl *gomock.Controller) *MockProposalStore {
+	mock := &MockProposalStore{ctrl: ctrl}
+	mock.recorder = &MockProposalStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalStore) EXPECT() *MockProposalStoreMockRecorder {
+	return m.recorder
+}
+
+// GetProposal mocks base method.
+func (m *MockProposalStore) GetProposal(domainID, source uint8, depositNonce uint64) (*store.ProposalState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposal", domainID, source, depositNonce)
+	ret0, _ := ret[0].(*store.ProposalState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposal indicates an expected call of GetProposal.
+func (mr *MockProposalStoreMockRecorder) GetProposal(domainID, source, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposal", reflect.TypeOf((*MockProposalStore)(nil).GetProposal), domainID, source, depositNonce)
+}
+
+// MockRelayerSet is a mock of RelayerSet interface.
+type MockRelayerSet struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelayerSetMockRecorder
+}
+
+// MockRelayerSetMockRecorder is the mock recorder for MockRelayerSet.
+type MockRelayerSetMockRecorder struct {
+	mock *MockRelayerSet
+}
+
+// NewMockRelayerSet creates a new mock instance.
+func NewMockRelayerSet(ctrl *gomock.Controller) *MockRelayerSet {
+	mock := &MockRelayerSet{ctrl: ctrl}
+	mock.recorder = &MockRelayerSetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelayerSet) EXPECT() *MockRelayerSetMockRecorder {
+	return m.recorder
+}
+
+// IsRelayer mocks base method.
+func (m *MockRelayerSet) IsRelayer(relayer common.Address) (bool, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRelayer", relayer)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// IsRelayer indicates an expected call of IsRelayer.
+func (mr *MockRelayerSetMockRecorder) IsRelayer(relayer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRelayer", reflect.TypeOf((*MockRelayerSet)(nil).IsRelayer), relayer)
+}
+
+// SetRelayer mocks base method.
+func (m *MockRelayerSet) SetRelayer(relayer common.Address, isRelayer bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRelayer", relayer, isRelayer)
+}
+
+// SetRelayer indicates an expected call of SetRelayer.
+func (mr *MockRelayerSetMockRecorder) SetRelayer(relayer, isRelayer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRelayer", reflect.TypeOf((*MockRelayerSet)(nil).SetRelayer), relayer, isRelayer)
+}
+
+// SetThreshold mocks base method.
+func (m *MockRelayerSet) SetThreshold(threshold uint8) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetThreshold", threshold)
+}
+
+// SetThreshold indicates an expected call of SetThreshold.
+func (mr *MockRelayerSetMockRecorder) SetThreshold(threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetThreshold", reflect.TypeOf((*MockRelayerSet)(nil).SetThreshold), threshold)
+}
+
+// Threshold mocks base method.
+func (m *MockRelayerSet) Threshold() (uint8, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Threshold")
+	ret0, _ := ret[0].(uint8)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Threshold indicates an expected call of Threshold.
+func (mr *MockRelayerSetMockRecorder) Threshold() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteProposal", reflect.TypeOf((*MockBridgeContract)(nil).VoteProposal), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Threshold", reflect.TypeOf((*MockRelayerSet)(nil).Threshold))
 }
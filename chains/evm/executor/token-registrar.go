@@ -0,0 +1,88 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rs/zerolog/log"
+)
+
+type RelayerAddresser interface {
+	RelayerAddress() common.Address
+}
+
+type AdminBridgeContract interface {
+	ContractAddress() *common.Address
+	IsAdmin(address common.Address) (bool, error)
+	GetHandlerAddressForResourceID(resourceID types.ResourceID) (common.Address, error)
+	AdminSetResource(handlerAddr common.Address, rID types.ResourceID, targetContractAddr common.Address, opts transactor.TransactOptions) (*common.Hash, error)
+}
+
+// EVMTokenRegistrar mirrors token pairs registered on the source bridge
+// by setting the resource on the destination bridge.
+type EVMTokenRegistrar struct {
+	client         RelayerAddresser
+	bridgeContract AdminBridgeContract
+}
+
+func NewTokenRegistrar(client RelayerAddresser, bridgeContract AdminBridgeContract) *EVMTokenRegistrar {
+	return &EVMTokenRegistrar{
+		client:         client,
+		bridgeContract: bridgeContract,
+	}
+}
+
+// Execute calls adminSetResource for the token registration if the relayer key
+// is a bridge admin. Registrations that are not meant for this bridge, are already
+// set or cannot be set by the relayer are skipped.
+func (r *EVMTokenRegistrar) Execute(ctx context.Context, m *message.Message, opts transactor.TransactOptions) error {
+	registration, err := m.Message2()
+	if err != nil {
+		return err
+	}
+
+	bridgeAddress := r.bridgeContract.ContractAddress()
+	if bridgeAddress == nil || *bridgeAddress != registration.DestBridgeAddress {
+		log.Debug().Msgf("Token registration for resource %x is meant for bridge %s, skipping", registration.ResourceId, registration.DestBridgeAddress)
+		return nil
+	}
+
+	isAdmin, err := r.bridgeContract.IsAdmin(r.client.RelayerAddress())
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		log.Warn().Msgf("Relayer %s is not authorised to register resource %x on domain %d, skipping token registration", r.client.RelayerAddress(), registration.ResourceId, registration.Destination)
+		return nil
+	}
+
+	handler, err := r.bridgeContract.GetHandlerAddressForResourceID(registration.ResourceId)
+	if err != nil {
+		return err
+	}
+	if handler == registration.Desthandler {
+		log.Debug().Msgf("Resource %x is already registered with handler %s", registration.ResourceId, handler)
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	opts.Ctx = ctx
+	hash, err := r.bridgeContract.AdminSetResource(registration.Desthandler, registration.ResourceId, registration.DestTokenAddress, opts)
+	if err != nil {
+		return fmt.Errorf("failed setting resource %x: %w", registration.ResourceId, err)
+	}
+	log.Info().Str("hash", hash.String()).Msgf("Registered resource %x with token %s on domain %d", registration.ResourceId, registration.DestTokenAddress, registration.Destination)
+	return nil
+}
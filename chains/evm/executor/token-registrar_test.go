@@ -0,0 +1,116 @@
+package executor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor"
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/executor"
+	mock_executor "github.com/VaivalGithub/chainsafe-core/chains/evm/executor/mock"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenRegistrarTestSuite struct {
+	suite.Suite
+	registrar          *executor.EVMTokenRegistrar
+	mockClient         *mock_executor.MockRelayerAddresser
+	mockBridgeContract *mock_executor.MockAdminBridgeContract
+	bridgeAddress      common.Address
+	relayerAddress     common.Address
+	registration       *message.Message2
+}
+
+func TestRunTokenRegistrarTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenRegistrarTestSuite))
+}
+
+func (s *TokenRegistrarTestSuite) SetupSuite()    {}
+func (s *TokenRegistrarTestSuite) TearDownSuite() {}
+func (s *TokenRegistrarTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockClient = mock_executor.NewMockRelayerAddresser(gomockController)
+	s.mockBridgeContract = mock_executor.NewMockAdminBridgeContract(gomockController)
+	s.registrar = executor.NewTokenRegistrar(s.mockClient, s.mockBridgeContract)
+	s.bridgeAddress = common.HexToAddress("0x9000000000000000000000000000000000000000")
+	s.relayerAddress = common.HexToAddress("0x8000000000000000000000000000000000000000")
+	s.registration = message.NewMessage1(
+		1, 2, 3, types.ResourceID{31: 1},
+		common.HexToAddress("0x1"), common.HexToAddress("0x2"), s.bridgeAddress,
+		common.HexToAddress("0x3"), common.HexToAddress("0x4"), common.HexToAddress("0x5"),
+	)
+	s.mockBridgeContract.EXPECT().ContractAddress().Return(&s.bridgeAddress).AnyTimes()
+	s.mockClient.EXPECT().RelayerAddress().Return(s.relayerAddress).AnyTimes()
+}
+func (s *TokenRegistrarTestSuite) TearDownTest() {}
+
+func (s *TokenRegistrarTestSuite) TestExecute_InvalidMessage() {
+	err := s.registrar.Execute(context.Background(), &message.Message{Type: message.FungibleTransfer}, transactor.TransactOptions{})
+
+	s.NotNil(err)
+}
+
+func (s *TokenRegistrarTestSuite) TestExecute_DifferentBridge() {
+	s.registration.DestBridgeAddress = common.HexToAddress("0x7")
+
+	err := s.registrar.Execute(context.Background(), message.NewTokenRegistrationMessage(s.registration), transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *TokenRegistrarTestSuite) TestExecute_RelayerNotAdmin() {
+	s.mockBridgeContract.EXPECT().IsAdmin(s.relayerAddress).Return(false, nil)
+
+	err := s.registrar.Execute(context.Background(), message.NewTokenRegistrationMessage(s.registration), transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *TokenRegistrarTestSuite) TestExecute_ResourceAlreadySet() {
+	s.mockBridgeContract.EXPECT().IsAdmin(s.relayerAddress).Return(true, nil)
+	s.mockBridgeContract.EXPECT().GetHandlerAddressForResourceID(s.registration.ResourceId).Return(s.registration.Desthandler, nil)
+
+	err := s.registrar.Execute(context.Background(), message.NewTokenRegistrationMessage(s.registration), transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *TokenRegistrarTestSuite) TestExecute_AdminSetResourceError() {
+	s.mockBridgeContract.EXPECT().IsAdmin(s.relayerAddress).Return(true, nil)
+	s.mockBridgeContract.EXPECT().GetHandlerAddressForResourceID(s.registration.ResourceId).Return(common.Address{}, nil)
+	s.mockBridgeContract.EXPECT().AdminSetResource(s.registration.Desthandler, s.registration.ResourceId, s.registration.DestTokenAddress, gomock.Any()).Return(nil, errors.New("error"))
+
+	err := s.registrar.Execute(context.Background(), message.NewTokenRegistrationMessage(s.registration), transactor.TransactOptions{})
+
+	s.NotNil(err)
+}
+
+func (s *TokenRegistrarTestSuite) TestExecute_SetsResource() {
+	s.mockBridgeContract.EXPECT().IsAdmin(s.relayerAddress).Return(true, nil)
+	s.mockBridgeContract.EXPECT().GetHandlerAddressForResourceID(s.registration.ResourceId).Return(common.Address{}, nil)
+	s.mockBridgeContract.EXPECT().AdminSetResource(s.registration.Desthandler, s.registration.ResourceId, s.registration.DestTokenAddress, gomock.Any()).Return(&common.Hash{}, nil)
+
+	err := s.registrar.Execute(context.Background(), message.NewTokenRegistrationMessage(s.registration), transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *TokenRegistrarTestSuite) TestExecute_SetsResourceWithExecuteContext() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.mockBridgeContract.EXPECT().IsAdmin(s.relayerAddress).Return(true, nil)
+	s.mockBridgeContract.EXPECT().GetHandlerAddressForResourceID(s.registration.ResourceId).Return(common.Address{}, nil)
+	s.mockBridgeContract.EXPECT().AdminSetResource(s.registration.Desthandler, s.registration.ResourceId, s.registration.DestTokenAddress, gomock.Any()).DoAndReturn(
+		func(handlerAddr common.Address, rID types.ResourceID, targetContractAddr common.Address, opts transactor.TransactOptions) (*common.Hash, error) {
+			s.Equal(ctx, opts.Ctx)
+			return &common.Hash{}, nil
+		})
+
+	err := s.registrar.Execute(ctx, message.NewTokenRegistrationMessage(s.registration), transactor.TransactOptions{})
+
+	s.Nil(err)
+}
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls"
@@ -16,6 +17,7 @@ import (
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/executor/proposal"
 	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	ethereumTypes "github.com/ethereum/go-ethereum/core/types"
@@ -27,17 +29,34 @@ const (
 	maxSimulateVoteChecks = 5
 	maxShouldVoteChecks   = 40
 	shouldVoteCheckPeriod = 15
+	maxReceiptChecks      = 50
+	receiptCheckPeriod    = 5 * time.Second
 )
 
 var (
-	Sleep = time.Sleep
+	// Sleep waits for the duration or until ctx is cancelled
+	Sleep = func(ctx context.Context, d time.Duration) error {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
 )
 
+// ErrRelayerRemoved is returned when the relayer no longer holds the relayer role on the bridge.
+// Retrying the vote can not succeed so the message is dead-lettered right away
+var ErrRelayerRemoved = fmt.Errorf("relayer removed from the bridge: %w", message.ErrNotRetryable)
+
 type ChainClient interface {
 	RelayerAddress() common.Address
 	CallContract(ctx context.Context, callArgs map[string]interface{}, blockNumber *big.Int) ([]byte, error)
 	SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (*rpc.ClientSubscription, error)
 	TransactionByHash(ctx context.Context, hash common.Hash) (tx *ethereumTypes.Transaction, isPending bool, err error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethereumTypes.Receipt, error)
 	calls.ContractCallerDispatcher
 }
 
@@ -51,6 +70,7 @@ type BridgeContract interface {
 	SimulateVoteProposal(proposal *proposal.Proposal) error
 	ProposalStatus(p *proposal.Proposal) (message.ProposalStatus, error)
 	GetThreshold() (uint8, error)
+	IsRelayer(relayerAddress common.Address) (bool, error)
 	// IsFeeClaimThresholdReached() (bool, error)
 	// RelayerClaimFees(
 	// 	destDomainID uint8,
@@ -58,11 +78,27 @@ type BridgeContract interface {
 	// ) (*common.Hash, error)
 }
 
+type ProposalStore interface {
+	GetProposal(domainID uint8, source uint8, depositNonce uint64) (*store.ProposalState, error)
+}
+
+type RelayerSet interface {
+	Threshold() (uint8, bool)
+	SetThreshold(threshold uint8)
+	IsRelayer(relayer common.Address) (bool, bool)
+	SetRelayer(relayer common.Address, isRelayer bool)
+}
+
 type EVMVoter struct {
 	mh                   MessageHandler
 	client               ChainClient
 	bridgeContract       BridgeContract
 	pendingProposalVotes map[common.Hash]uint8
+	observer             message.Observer
+	proposalStore        ProposalStore
+	domainID             uint8
+	relayerSet           RelayerSet
+	followUps            sync.WaitGroup
 }
 
 // NewVoterWithSubscription creates an instance of EVMVoter that votes for
@@ -108,12 +144,27 @@ func NewVoter(mh MessageHandler, client ChainClient, bridgeContract BridgeContra
 
 // Execute checks if relayer already voted and is threshold
 // satisfied and casts a vote if it isn't.
-func (v *EVMVoter) Execute(m *message.Message, opts transactor.TransactOptions) error {
+// Cancelling ctx stops waiting for the threshold check and aborts sending the vote.
+func (v *EVMVoter) Execute(ctx context.Context, m *message.Message, opts transactor.TransactOptions) error {
 	prop, err := v.mh.HandleMessage(m)
 	if err != nil {
 		return err
 	}
 
+	isRelayer, err := v.isRelayer()
+	if err != nil {
+		return err
+	}
+	if !isRelayer {
+		log.Error().Str("relayer", v.client.RelayerAddress().Hex()).Msgf("Relayer was removed from the bridge relayers, not voting for proposal %+v", prop)
+		return ErrRelayerRemoved
+	}
+
+	tracked := v.trackedProposal(prop)
+	if tracked != nil && tracked.IsVotedBy(v.client.RelayerAddress()) {
+		return nil
+	}
+
 	votedByTheRelayer, err := v.bridgeContract.IsProposalVotedBy(v.client.RelayerAddress(), prop)
 	if err != nil {
 		return err
@@ -122,7 +173,7 @@ func (v *EVMVoter) Execute(m *message.Message, opts transactor.TransactOptions)
 		return nil
 	}
 
-	shouldVote, err := v.shouldVoteForProposal(prop, 0)
+	shouldVote, err := v.shouldVoteForProposal(ctx, prop, 0)
 	if err != nil {
 		log.Error().Err(err)
 		return err
@@ -141,28 +192,184 @@ func (v *EVMVoter) Execute(m *message.Message, opts transactor.TransactOptions)
 	// since the EVMVoter abstraction does not have contain chain config it has to be passed as a param in the Execute function
 	fmt.Printf("VoteProposal OPTS BEING PASSED: [%+v\n]", opts)
 
+	opts.Ctx = ctx
 	hash, err := v.bridgeContract.VoteProposal(prop, opts)
 	if err != nil {
 		return fmt.Errorf("voting failed. Err: %w", err)
 	}
 
 	log.Debug().Str("hash", hash.String()).Uint64("nonce", prop.DepositNonce).Msgf("Voted")
+	if v.observer != nil {
+		v.observer.VoteSubmitted(m, *hash)
+		// vote is followed up in the background so that the writer is not held until it is mined
+		v.followUps.Add(1)
+		go func() {
+			defer v.followUps.Done()
+			v.observeVote(ctx, m, prop, *hash)
+		}()
+	}
 	return nil
 }
 
+// Wait blocks until background follow-ups of submitted votes return.
+// Follow-ups stop once ctx passed to Execute is cancelled.
+func (v *EVMVoter) Wait() {
+	v.followUps.Wait()
+}
+
+// SetObserver sets observer notified about votes and proposal state changes
+func (v *EVMVoter) SetObserver(o message.Observer) {
+	v.observer = o
+}
+
+// SetProposalStore makes the voter consult proposal states tracked from events of the domain bridge
+// before querying the bridge contract
+func (v *EVMVoter) SetProposalStore(domainID uint8, proposalStore ProposalStore) {
+	v.domainID = domainID
+	v.proposalStore = proposalStore
+}
+
+// SetRelayerSet makes the voter use relayer threshold and relayer role membership tracked
+// from bridge events and stop voting once the relayer is removed from the bridge relayers
+func (v *EVMVoter) SetRelayerSet(relayerSet RelayerSet) {
+	v.relayerSet = relayerSet
+}
+
+// threshold returns tracked relayer threshold or fetches it from the bridge contract
+// and seeds the relayer set with it if no threshold change was tracked yet
+func (v *EVMVoter) threshold() (uint8, error) {
+	if v.relayerSet == nil {
+		return v.bridgeContract.GetThreshold()
+	}
+	if threshold, ok := v.relayerSet.Threshold(); ok {
+		return threshold, nil
+	}
+
+	threshold, err := v.bridgeContract.GetThreshold()
+	if err != nil {
+		return 0, err
+	}
+	v.relayerSet.SetThreshold(threshold)
+	return threshold, nil
+}
+
+// isRelayer checks whether the relayer holds the relayer role. Role membership is fetched from the
+// bridge contract and seeded into the relayer set only if no role change of the relayer was tracked yet.
+func (v *EVMVoter) isRelayer() (bool, error) {
+	if v.relayerSet == nil {
+		return true, nil
+	}
+	relayer := v.client.RelayerAddress()
+	if isRelayer, ok := v.relayerSet.IsRelayer(relayer); ok {
+		return isRelayer, nil
+	}
+
+	isRelayer, err := v.bridgeContract.IsRelayer(relayer)
+	if err != nil {
+		return false, err
+	}
+	v.relayerSet.SetRelayer(relayer, isRelayer)
+	return isRelayer, nil
+}
+
+// trackedProposal returns state of the proposal tracked from bridge events
+// or nil if it is not tracked or was proposed with different data
+func (v *EVMVoter) trackedProposal(prop *proposal.Proposal) *store.ProposalState {
+	if v.proposalStore == nil {
+		return nil
+	}
+	tracked, err := v.proposalStore.GetProposal(v.domainID, prop.Source, prop.DepositNonce)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed fetching tracked state of proposal %+v", prop)
+		return nil
+	}
+	if tracked == nil || tracked.DataHash != prop.GetDataHash() {
+		return nil
+	}
+	return tracked
+}
+
+// proposalStatus returns status of the proposal tracked from bridge events once it passed, was executed
+// or canceled. Otherwise it is fetched from the bridge contract as tracked proposals are behind the chain
+// head by the block confirmations and can miss recent votes.
+func (v *EVMVoter) proposalStatus(prop *proposal.Proposal) (message.ProposalStatus, error) {
+	tracked := v.trackedProposal(prop)
+	if tracked != nil && tracked.Status >= message.ProposalStatusPassed {
+		return message.ProposalStatus{
+			Status:        tracked.Status,
+			YesVotesTotal: tracked.VoteCount(),
+			ProposedBlock: new(big.Int).SetUint64(tracked.ProposedBlock),
+		}, nil
+	}
+	return v.bridgeContract.ProposalStatus(prop)
+}
+
+// observeVote notifies observer about the proposal state once the vote is mined.
+// It stops waiting for the vote once ctx is cancelled.
+func (v *EVMVoter) observeVote(ctx context.Context, m *message.Message, prop *proposal.Proposal, hash common.Hash) {
+	receipt, err := v.waitForReceipt(ctx, hash)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed fetching receipt of vote %s", hash)
+		return
+	}
+	if receipt.Status == ethereumTypes.ReceiptStatusFailed {
+		v.observer.MessageFailed(m, fmt.Errorf("vote transaction %s reverted", hash))
+		return
+	}
+	v.observer.VoteMined(m, hash)
+
+	ps, err := v.bridgeContract.ProposalStatus(prop)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed fetching status of proposal %+v", prop)
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	switch ps.Status {
+	case message.ProposalStatusPassed:
+		v.observer.ProposalPassed(m)
+	case message.ProposalStatusExecuted:
+		// proposal is executed by the vote that passed it
+		v.observer.ProposalPassed(m)
+		v.observer.ProposalExecuted(m)
+	}
+}
+
+// waitForReceipt polls receipt of the transaction until it is mined, maxReceiptChecks
+// are exhausted or ctx is cancelled
+func (v *EVMVoter) waitForReceipt(ctx context.Context, hash common.Hash) (*ethereumTypes.Receipt, error) {
+	for i := 0; ; i++ {
+		receipt, err := v.client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return receipt, nil
+		}
+		if i+1 >= maxReceiptChecks {
+			return nil, fmt.Errorf("transaction %s not mined: %w", hash, err)
+		}
+		err = Sleep(ctx, receiptCheckPeriod)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 // shouldVoteForProposal checks if proposal already has threshold with pending
 // proposal votes from other relayers.
 // Only works properly in conjuction with NewVoterWithSubscription as without a subscription
 // no pending txs would be received and pending vote count would be 0.
-func (v *EVMVoter) shouldVoteForProposal(prop *proposal.Proposal, tries int) (bool, error) {
+func (v *EVMVoter) shouldVoteForProposal(ctx context.Context, prop *proposal.Proposal, tries int) (bool, error) {
 	propID := prop.GetID()
 	defer delete(v.pendingProposalVotes, propID)
 
 	// random delay to prevent all relayers checking for pending votes
 	// at the same time and all of them sending another tx
-	Sleep(time.Duration(rand.Intn(shouldVoteCheckPeriod)) * time.Second)
+	err := Sleep(ctx, time.Duration(rand.Intn(shouldVoteCheckPeriod))*time.Second)
+	if err != nil {
+		return false, err
+	}
 
-	ps, err := v.bridgeContract.ProposalStatus(prop)
+	ps, err := v.proposalStatus(prop)
 	if err != nil {
 		return false, err
 	}
@@ -171,7 +378,7 @@ func (v *EVMVoter) shouldVoteForProposal(prop *proposal.Proposal, tries int) (bo
 		return false, nil
 	}
 
-	threshold, err := v.bridgeContract.GetThreshold()
+	threshold, err := v.threshold()
 	if err != nil {
 		return false, err
 	}
@@ -180,8 +387,8 @@ func (v *EVMVoter) shouldVoteForProposal(prop *proposal.Proposal, tries int) (bo
 		// Wait until proposal status is finalized to prevent missing votes
 		// in case of dropped txs
 		tries++
-		log.Debug().Msgf("checking values", ps.YesVotesTotal)
-		return v.shouldVoteForProposal(prop, tries)
+		log.Debug().Msgf("checking values %v", ps.YesVotesTotal)
+		return v.shouldVoteForProposal(ctx, prop, tries)
 	}
 
 	return true, nil
@@ -1,16 +1,21 @@
 package executor_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethereumTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/golang/mock/gomock"
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/transactor"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/executor"
 	mock_voter "github.com/VaivalGithub/chainsafe-core/chains/evm/executor/mock"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/executor/proposal"
 	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	mock_message "github.com/VaivalGithub/chainsafe-core/relayer/message/mock"
+	"github.com/VaivalGithub/chainsafe-core/store"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -20,6 +25,7 @@ type VoterTestSuite struct {
 	mockMessageHandler *mock_voter.MockMessageHandler
 	mockClient         *mock_voter.MockChainClient
 	mockBridgeContract *mock_voter.MockBridgeContract
+	mockObserver       *mock_message.MockObserver
 }
 
 func TestRunVoterTestSuite(t *testing.T) {
@@ -33,36 +39,20 @@ func (s *VoterTestSuite) SetupTest() {
 	s.mockMessageHandler = mock_voter.NewMockMessageHandler(gomockController)
 	s.mockClient = mock_voter.NewMockChainClient(gomockController)
 	s.mockBridgeContract = mock_voter.NewMockBridgeContract(gomockController)
+	s.mockObserver = mock_message.NewMockObserver(gomockController)
 	s.voter = executor.NewVoter(
 		s.mockMessageHandler,
 		s.mockClient,
 		s.mockBridgeContract,
 	)
-	executor.Sleep = func(d time.Duration) {}
+	executor.Sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
 }
 func (s *VoterTestSuite) TearDownTest() {}
 
 func (s *VoterTestSuite) TestExecute_HandleMessageError() {
 	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(nil, errors.New("error"))
 
-	err := s.voter.Execute(&message.Message{})
-
-	s.NotNil(err)
-}
-
-func (s *VoterTestSuite) TestExecute_SimulateVoteProposalError() {
-	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(&proposal.Proposal{
-		Source:       0,
-		DepositNonce: 0,
-	}, nil)
-	s.mockClient.EXPECT().RelayerAddress().Return(common.Address{})
-
-	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
-	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusActive}, nil)
-	s.mockBridgeContract.EXPECT().GetThreshold().Return(uint8(1), nil)
-	s.mockBridgeContract.EXPECT().SimulateVoteProposal(gomock.Any()).Times(6).Return(errors.New("error"))
-
-	err := s.voter.Execute(&message.Message{})
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
 
 	s.NotNil(err)
 }
@@ -77,10 +67,9 @@ func (s *VoterTestSuite) TestExecute_SimulateVoteProposal() {
 	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
 	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusActive}, nil)
 	s.mockBridgeContract.EXPECT().GetThreshold().Return(uint8(1), nil)
-	s.mockBridgeContract.EXPECT().SimulateVoteProposal(gomock.Any()).Times(1).Return(nil)
 	s.mockBridgeContract.EXPECT().VoteProposal(gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
 
-	err := s.voter.Execute(&message.Message{})
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
 
 	s.Nil(err)
 }
@@ -93,7 +82,7 @@ func (s *VoterTestSuite) TestExecute_IsProposalVotedByError() {
 	s.mockClient.EXPECT().RelayerAddress().Return(common.Address{})
 	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, errors.New("error"))
 
-	err := s.voter.Execute(&message.Message{})
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
 
 	s.NotNil(err)
 }
@@ -106,7 +95,7 @@ func (s *VoterTestSuite) TestExecute_ProposalAlreadyVoted() {
 	s.mockClient.EXPECT().RelayerAddress().Return(common.Address{})
 	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(true, nil)
 
-	err := s.voter.Execute(&message.Message{})
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
 
 	s.Nil(err)
 }
@@ -120,7 +109,7 @@ func (s *VoterTestSuite) TestExecute_ProposalStatusFail() {
 	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
 	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{}, errors.New("error"))
 
-	err := s.voter.Execute(&message.Message{})
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
 
 	s.NotNil(err)
 }
@@ -134,7 +123,59 @@ func (s *VoterTestSuite) TestExecute_ExecutedProposal() {
 	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
 	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusExecuted}, nil)
 
-	err := s.voter.Execute(&message.Message{})
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *VoterTestSuite) TestExecute_TrackedProposalVotedByRelayer() {
+	prop := &proposal.Proposal{Source: 1, DepositNonce: 2}
+	relayer := common.HexToAddress("0x1")
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(prop, nil)
+	mockProposalStore := mock_voter.NewMockProposalStore(gomock.NewController(s.T()))
+	mockProposalStore.EXPECT().GetProposal(uint8(3), uint8(1), uint64(2)).Return(&store.ProposalState{
+		DataHash: prop.GetDataHash(),
+		Voters:   []common.Address{relayer},
+	}, nil)
+	s.voter.SetProposalStore(3, mockProposalStore)
+	s.mockClient.EXPECT().RelayerAddress().Return(relayer)
+
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *VoterTestSuite) TestExecute_TrackedExecutedProposal() {
+	prop := &proposal.Proposal{Source: 1, DepositNonce: 2}
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(prop, nil)
+	mockProposalStore := mock_voter.NewMockProposalStore(gomock.NewController(s.T()))
+	mockProposalStore.EXPECT().GetProposal(uint8(3), uint8(1), uint64(2)).Return(&store.ProposalState{
+		DataHash: prop.GetDataHash(),
+		Status:   message.ProposalStatusExecuted,
+	}, nil).Times(2)
+	s.voter.SetProposalStore(3, mockProposalStore)
+	s.mockClient.EXPECT().RelayerAddress().Return(common.Address{}).Times(2)
+	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
+
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *VoterTestSuite) TestExecute_TrackedProposalWithDifferentData() {
+	prop := &proposal.Proposal{Source: 1, DepositNonce: 2}
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(prop, nil)
+	mockProposalStore := mock_voter.NewMockProposalStore(gomock.NewController(s.T()))
+	mockProposalStore.EXPECT().GetProposal(uint8(3), uint8(1), uint64(2)).Return(&store.ProposalState{
+		DataHash: common.HexToHash("0x1"),
+		Status:   message.ProposalStatusExecuted,
+	}, nil).Times(2)
+	s.voter.SetProposalStore(3, mockProposalStore)
+	s.mockClient.EXPECT().RelayerAddress().Return(common.Address{})
+	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
+	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusExecuted}, nil)
+
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
 
 	s.Nil(err)
 }
@@ -149,7 +190,192 @@ func (s *VoterTestSuite) TestExecute_GetThresholdFail() {
 	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusActive}, nil)
 	s.mockBridgeContract.EXPECT().GetThreshold().Return(uint8(0), errors.New("error"))
 
-	err := s.voter.Execute(&message.Message{})
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
 
 	s.NotNil(err)
 }
+
+func (s *VoterTestSuite) TestExecute_RelayerRemoved() {
+	relayer := common.HexToAddress("0x1")
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(&proposal.Proposal{}, nil)
+	mockRelayerSet := mock_voter.NewMockRelayerSet(gomock.NewController(s.T()))
+	mockRelayerSet.EXPECT().IsRelayer(relayer).Return(false, true)
+	s.voter.SetRelayerSet(mockRelayerSet)
+	s.mockClient.EXPECT().RelayerAddress().Return(relayer).AnyTimes()
+
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
+
+	s.Equal(executor.ErrRelayerRemoved, err)
+	s.True(errors.Is(err, message.ErrNotRetryable))
+}
+
+func (s *VoterTestSuite) TestExecute_UnknownRelayerSeedsRelayerSet() {
+	relayer := common.HexToAddress("0x1")
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(&proposal.Proposal{}, nil)
+	mockRelayerSet := mock_voter.NewMockRelayerSet(gomock.NewController(s.T()))
+	mockRelayerSet.EXPECT().IsRelayer(relayer).Return(false, false)
+	mockRelayerSet.EXPECT().SetRelayer(relayer, true)
+	s.voter.SetRelayerSet(mockRelayerSet)
+	s.mockClient.EXPECT().RelayerAddress().Return(relayer).AnyTimes()
+	s.mockBridgeContract.EXPECT().IsRelayer(relayer).Return(true, nil)
+	s.mockBridgeContract.EXPECT().IsProposalVotedBy(relayer, gomock.Any()).Return(true, nil)
+
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *VoterTestSuite) TestExecute_TrackedThreshold() {
+	relayer := common.HexToAddress("0x1")
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(&proposal.Proposal{}, nil)
+	mockRelayerSet := mock_voter.NewMockRelayerSet(gomock.NewController(s.T()))
+	mockRelayerSet.EXPECT().IsRelayer(relayer).Return(true, true)
+	mockRelayerSet.EXPECT().Threshold().Return(uint8(2), true)
+	s.voter.SetRelayerSet(mockRelayerSet)
+	s.mockClient.EXPECT().RelayerAddress().Return(relayer).AnyTimes()
+	s.mockBridgeContract.EXPECT().IsProposalVotedBy(relayer, gomock.Any()).Return(false, nil)
+	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusActive}, nil)
+	s.mockBridgeContract.EXPECT().VoteProposal(gomock.Any(), gomock.Any()).Return(&common.Hash{}, nil)
+
+	err := s.voter.Execute(context.Background(), &message.Message{}, transactor.TransactOptions{})
+
+	s.Nil(err)
+}
+
+func (s *VoterTestSuite) TestExecute_CancelledWhileWaitingForThresholdCheck() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(&proposal.Proposal{}, nil)
+	s.mockClient.EXPECT().RelayerAddress().Return(common.Address{})
+	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
+
+	err := s.voter.Execute(ctx, &message.Message{}, transactor.TransactOptions{})
+
+	s.Equal(context.Canceled, err)
+}
+
+func (s *VoterTestSuite) expectVote(hash common.Hash) {
+	s.mockMessageHandler.EXPECT().HandleMessage(gomock.Any()).Return(&proposal.Proposal{
+		Source:       0,
+		DepositNonce: 0,
+	}, nil)
+	s.mockClient.EXPECT().RelayerAddress().Return(common.Address{})
+	s.mockBridgeContract.EXPECT().IsProposalVotedBy(gomock.Any(), gomock.Any()).Return(false, nil)
+	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusActive}, nil)
+	s.mockBridgeContract.EXPECT().GetThreshold().Return(uint8(2), nil)
+	s.mockBridgeContract.EXPECT().VoteProposal(gomock.Any(), gomock.Any()).Return(&hash, nil)
+}
+
+func (s *VoterTestSuite) TestExecute_NotifiesObserverAboutExecutedProposal() {
+	m := &message.Message{}
+	hash := common.HexToHash("0x1")
+	s.expectVote(hash)
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), hash).Return(&ethereumTypes.Receipt{Status: ethereumTypes.ReceiptStatusSuccessful}, nil)
+	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).Return(message.ProposalStatus{Status: message.ProposalStatusExecuted}, nil)
+	observed := make(chan struct{})
+	gomock.InOrder(
+		s.mockObserver.EXPECT().VoteSubmitted(m, hash),
+		s.mockObserver.EXPECT().VoteMined(m, hash),
+		s.mockObserver.EXPECT().ProposalPassed(m),
+		s.mockObserver.EXPECT().ProposalExecuted(m).Do(func(m *message.Message) { close(observed) }),
+	)
+	s.voter.SetObserver(s.mockObserver)
+
+	err := s.voter.Execute(context.Background(), m, transactor.TransactOptions{})
+
+	s.Nil(err)
+	<-observed
+}
+
+func (s *VoterTestSuite) TestExecute_NotifiesObserverAboutActiveProposal() {
+	m := &message.Message{}
+	hash := common.HexToHash("0x1")
+	s.expectVote(hash)
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), hash).Return(&ethereumTypes.Receipt{Status: ethereumTypes.ReceiptStatusSuccessful}, nil)
+	observed := make(chan struct{})
+	s.mockBridgeContract.EXPECT().ProposalStatus(gomock.Any()).DoAndReturn(func(p *proposal.Proposal) (message.ProposalStatus, error) {
+		close(observed)
+		return message.ProposalStatus{Status: message.ProposalStatusActive}, nil
+	})
+	s.mockObserver.EXPECT().VoteSubmitted(m, hash)
+	s.mockObserver.EXPECT().VoteMined(m, hash)
+	s.voter.SetObserver(s.mockObserver)
+
+	err := s.voter.Execute(context.Background(), m, transactor.TransactOptions{})
+
+	s.Nil(err)
+	<-observed
+}
+
+func (s *VoterTestSuite) TestExecute_NotifiesObserverAboutRevertedVote() {
+	m := &message.Message{}
+	hash := common.HexToHash("0x1")
+	s.expectVote(hash)
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), hash).Return(&ethereumTypes.Receipt{Status: ethereumTypes.ReceiptStatusFailed}, nil)
+	observed := make(chan struct{})
+	s.mockObserver.EXPECT().VoteSubmitted(m, hash)
+	s.mockObserver.EXPECT().MessageFailed(m, gomock.Any()).Do(func(m *message.Message, err error) { close(observed) })
+	s.voter.SetObserver(s.mockObserver)
+
+	err := s.voter.Execute(context.Background(), m, transactor.TransactOptions{})
+
+	s.Nil(err)
+	<-observed
+}
+
+func (s *VoterTestSuite) TestWait_ReturnsOnceVoteFollowUpIsCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &message.Message{}
+	hash := common.HexToHash("0x1")
+	s.expectVote(hash)
+	polled := make(chan struct{})
+	waiting := make(chan struct{})
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), hash).DoAndReturn(func(ctx context.Context, hash common.Hash) (*ethereumTypes.Receipt, error) {
+		close(polled)
+		return nil, errors.New("not found")
+	})
+	s.mockObserver.EXPECT().VoteSubmitted(m, hash)
+	s.voter.SetObserver(s.mockObserver)
+	executor.Sleep = func(ctx context.Context, d time.Duration) error {
+		select {
+		case <-polled:
+		default:
+			return ctx.Err()
+		}
+		close(waiting)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	err := s.voter.Execute(ctx, m, transactor.TransactOptions{})
+	s.Nil(err)
+	<-waiting
+	cancel()
+
+	s.voter.Wait()
+}
+
+func (s *VoterTestSuite) TestExecute_StopsWaitingForVoteOnceCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &message.Message{}
+	hash := common.HexToHash("0x1")
+	s.expectVote(hash)
+	stopped := make(chan struct{})
+	s.mockClient.EXPECT().TransactionReceipt(gomock.Any(), hash).DoAndReturn(func(ctx context.Context, hash common.Hash) (*ethereumTypes.Receipt, error) {
+		cancel()
+		return nil, errors.New("not found")
+	})
+	s.mockObserver.EXPECT().VoteSubmitted(m, hash)
+	s.voter.SetObserver(s.mockObserver)
+	executor.Sleep = func(ctx context.Context, d time.Duration) error {
+		if ctx.Err() != nil {
+			close(stopped)
+		}
+		return ctx.Err()
+	}
+
+	err := s.voter.Execute(ctx, m, transactor.TransactOptions{})
+
+	s.Nil(err)
+	<-stopped
+}
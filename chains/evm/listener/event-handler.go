@@ -3,11 +3,14 @@ package listener
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
+	"sort"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/events"
 	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
 	"github.com/VaivalGithub/chainsafe-core/types"
 	"github.com/rs/zerolog/log"
 )
@@ -16,6 +19,29 @@ type EventListener interface {
 	FetchDeposits(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*events.Deposit, error)
 }
 
+type RegisterTokenListener interface {
+	FetchRegisterTokens(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*events.RegisterToken, error)
+}
+
+type ProposalEventListener interface {
+	FetchProposalEvents(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*events.ProposalEvent, error)
+	FetchProposalVotes(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*events.ProposalEvent, error)
+}
+
+type RelayerSetListener interface {
+	FetchThresholdChanges(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*events.ThresholdChanged, error)
+	FetchRelayerChanges(ctx context.Context, address common.Address, startBlock *big.Int, endBlock *big.Int) ([]*events.RelayerChanged, error)
+}
+
+type ProposalStore interface {
+	GetProposal(domainID uint8, source uint8, depositNonce uint64) (*store.ProposalState, error)
+	StoreProposal(domainID uint8, p *store.ProposalState) error
+}
+
+type MessageStatusStore interface {
+	StoreMessageStatus(m *message.Message, status message.MessageStatus) error
+}
+
 type DepositHandler interface {
 	HandleDeposit(sourceID, destID uint8, nonce uint64, resourceID types.ResourceID, calldata, handlerResponse []byte) (*message.Message, error)
 }
@@ -25,6 +51,7 @@ type DepositEventHandler struct {
 	depositHandler DepositHandler
 	bridgeAddress  common.Address
 	domainID       uint8
+	observer       message.Observer
 }
 
 func NewDepositEventHandler(eventListener EventListener, depositHandler DepositHandler, bridgeAddress common.Address, domainID uint8) *DepositEventHandler {
@@ -36,21 +63,303 @@ func NewDepositEventHandler(eventListener EventListener, depositHandler DepositH
 	}
 }
 
-func (eh *DepositEventHandler) HandleEvent(block *big.Int, msgChan chan *message.Message) error {
-	deposits, err := eh.eventListener.FetchDeposits(context.Background(), eh.bridgeAddress, block, block)
+// HandleEvents resolves deposits made in the block range into messages grouped by block
+func (eh *DepositEventHandler) HandleEvents(ctx context.Context, startBlock *big.Int, endBlock *big.Int) (map[uint64][]*message.Message, error) {
+	deposits, err := eh.eventListener.FetchDeposits(ctx, eh.bridgeAddress, startBlock, endBlock)
 	if err != nil {
-		return fmt.Errorf("unable to fetch deposit events because of: %+v", err)
+		return nil, fmt.Errorf("unable to fetch deposit events because of: %+v", err)
 	}
 
+	msgs := make(map[uint64][]*message.Message)
 	for _, d := range deposits {
 		m, err := eh.depositHandler.HandleDeposit(eh.domainID, d.DestinationDomainID, d.DepositNonce, d.ResourceID, d.Data, d.HandlerResponse)
 		if err != nil {
-			log.Error().Str("block", block.String()).Uint8("domainID", eh.domainID).Msgf("%v", err)
+			log.Error().Uint64("block", d.Block).Uint8("domainID", eh.domainID).Msgf("%v", err)
+			continue
+		}
+		m.Sender = d.SenderAddress
+		log.Debug().Msgf("Resolved message %+v in block %d", m, d.Block)
+		if eh.observer != nil {
+			eh.observer.DepositDetected(m)
+		}
+		msgs[d.Block] = append(msgs[d.Block], m)
+	}
+	log.Debug().Msgf("Queried blocks %s-%s", startBlock, endBlock)
+	return msgs, nil
+}
+
+// SetObserver sets observer notified about detected deposits
+func (eh *DepositEventHandler) SetObserver(o message.Observer) {
+	eh.observer = o
+}
+
+// FetchDeposits fetches deposits with the provided nonce from the block again and resolves them into messages.
+// Block can contain multiple deposits with the same nonce if they are sent to different destinations.
+func (eh *DepositEventHandler) FetchDeposits(ctx context.Context, block *big.Int, depositNonce uint64) ([]*message.Message, error) {
+	deposits, err := eh.eventListener.FetchDeposits(ctx, eh.bridgeAddress, block, block)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch deposit events because of: %+v", err)
+	}
+
+	msgs := make([]*message.Message, 0)
+	for _, d := range deposits {
+		if d.DepositNonce != depositNonce {
 			continue
 		}
-		log.Debug().Msgf("Resolved message %+v in block %s", m, block.String())
-		msgChan <- m
+		m, err := eh.depositHandler.HandleDeposit(eh.domainID, d.DestinationDomainID, d.DepositNonce, d.ResourceID, d.Data, d.HandlerResponse)
+		if err != nil {
+			return nil, err
+		}
+		m.Sender = d.SenderAddress
+		msgs = append(msgs, m)
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("deposit with nonce %d not found in block %s", depositNonce, block)
+	}
+	return msgs, nil
+}
+
+type RegisterTokenEventHandler struct {
+	eventListener RegisterTokenListener
+	bridgeAddress common.Address
+	domainID      uint8
+}
+
+func NewRegisterTokenEventHandler(eventListener RegisterTokenListener, bridgeAddress common.Address, domainID uint8) *RegisterTokenEventHandler {
+	return &RegisterTokenEventHandler{
+		eventListener: eventListener,
+		bridgeAddress: bridgeAddress,
+		domainID:      domainID,
+	}
+}
+
+// HandleEvents resolves token registrations made in the block range into messages grouped by block
+// so that token pairs registered on this chain are mirrored on their destination chains
+func (eh *RegisterTokenEventHandler) HandleEvents(ctx context.Context, startBlock *big.Int, endBlock *big.Int) (map[uint64][]*message.Message, error) {
+	registrations, err := eh.eventListener.FetchRegisterTokens(ctx, eh.bridgeAddress, startBlock, endBlock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch register token events because of: %+v", err)
+	}
+
+	msgs := make(map[uint64][]*message.Message)
+	for _, rt := range registrations {
+		m2 := message.NewMessage1(
+			eh.domainID,
+			rt.DestinationDomainId,
+			rt.DepositNounce,
+			rt.ResourceID,
+			rt.SourceHandler,
+			rt.DestHandler,
+			rt.DestBridgeContract,
+			rt.SourceBridgeContract,
+			rt.SourceToken,
+			rt.DestToken,
+		)
+		log.Debug().Msgf("Resolved token registration %+v in block %d", m2, rt.Block)
+		msgs[rt.Block] = append(msgs[rt.Block], message.NewTokenRegistrationMessage(m2))
+	}
+	return msgs, nil
+}
+
+type ProposalEventHandler struct {
+	eventListener      ProposalEventListener
+	proposalStore      ProposalStore
+	messageStatusStore MessageStatusStore
+	bridgeAddress      common.Address
+	domainID           uint8
+}
+
+func NewProposalEventHandler(eventListener ProposalEventListener, proposalStore ProposalStore, bridgeAddress common.Address, domainID uint8) *ProposalEventHandler {
+	return &ProposalEventHandler{
+		eventListener: eventListener,
+		proposalStore: proposalStore,
+		bridgeAddress: bridgeAddress,
+		domainID:      domainID,
+	}
+}
+
+// HandleEvents applies proposal status changes and votes emitted in the block range to
+// local states of proposals on this chain. No messages are resolved from them.
+func (eh *ProposalEventHandler) HandleEvents(ctx context.Context, startBlock *big.Int, endBlock *big.Int) (map[uint64][]*message.Message, error) {
+	proposalEvents, err := eh.eventListener.FetchProposalEvents(ctx, eh.bridgeAddress, startBlock, endBlock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch proposal events because of: %+v", err)
+	}
+	votes, err := eh.eventListener.FetchProposalVotes(ctx, eh.bridgeAddress, startBlock, endBlock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch proposal votes because of: %+v", err)
+	}
+
+	// votes and status changes are applied in the order they were emitted
+	all := append(votes, proposalEvents...)
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Block != all[j].Block {
+			return all[i].Block < all[j].Block
+		}
+		return all[i].Index < all[j].Index
+	})
+	for _, pe := range all {
+		err = eh.applyEvent(pe)
+		if err != nil {
+			return nil, fmt.Errorf("unable to store proposal %d-%d state because of: %+v", pe.OriginDomainID, pe.DepositNonce, err)
+		}
+		err = eh.storeExecutedStatus(pe)
+		if err != nil {
+			return nil, fmt.Errorf("unable to store executed status of deposit %d-%d because of: %+v", pe.OriginDomainID, pe.DepositNonce, err)
+		}
+	}
+	return make(map[uint64][]*message.Message), nil
+}
+
+// SetMessageStatusStore makes the handler mark deposits executed on this chain as executed
+// in the message status store
+func (eh *ProposalEventHandler) SetMessageStatusStore(messageStatusStore MessageStatusStore) {
+	eh.messageStatusStore = messageStatusStore
+}
+
+// storeExecutedStatus marks deposit of the proposal as executed if the event reports its execution
+func (eh *ProposalEventHandler) storeExecutedStatus(pe *events.ProposalEvent) error {
+	if eh.messageStatusStore == nil || pe.Status != message.ProposalStatusExecuted {
+		return nil
+	}
+	return eh.messageStatusStore.StoreMessageStatus(&message.Message{
+		Source:       pe.OriginDomainID,
+		Destination:  eh.domainID,
+		DepositNonce: pe.DepositNonce,
+	}, message.MessageStatusExecuted)
+}
+
+// applyEvent updates state of the event proposal. Applying the same event
+// again does not change the state so block ranges can be handled again.
+func (eh *ProposalEventHandler) applyEvent(pe *events.ProposalEvent) error {
+	p, err := eh.proposalStore.GetProposal(eh.domainID, pe.OriginDomainID, pe.DepositNonce)
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		p = &store.ProposalState{
+			Source:        pe.OriginDomainID,
+			DepositNonce:  pe.DepositNonce,
+			Voters:        make([]common.Address, 0),
+			ProposedBlock: pe.Block,
+		}
+	}
+
+	p.DataHash = pe.DataHash
+	// proposal status only moves forward on the bridge
+	if pe.Status > p.Status {
+		p.Status = pe.Status
+	}
+	if pe.Voter != (common.Address{}) && !p.IsVotedBy(pe.Voter) {
+		p.Voters = append(p.Voters, pe.Voter)
+	}
+	if pe.Block > p.UpdatedBlock {
+		p.UpdatedBlock = pe.Block
+	}
+	log.Debug().Uint8("domainID", eh.domainID).Msgf("Proposal %d-%d is %s with %d votes", p.Source, p.DepositNonce, message.StatusMap[p.Status], p.VoteCount())
+	return eh.proposalStore.StoreProposal(eh.domainID, p)
+}
+
+type RelayerSetEventHandler struct {
+	eventListener RelayerSetListener
+	relayerSet    *RelayerSet
+	bridgeAddress common.Address
+	domainID      uint8
+	relayer       common.Address
+	observer      message.Observer
+}
+
+// NewRelayerSetEventHandler creates a handler that keeps the relayer set up to date. The relayer
+// is the address of this relayer so removing its relayer role is reported to the observer.
+func NewRelayerSetEventHandler(eventListener RelayerSetListener, relayerSet *RelayerSet, bridgeAddress common.Address, domainID uint8, relayer common.Address) *RelayerSetEventHandler {
+	return &RelayerSetEventHandler{
+		eventListener: eventListener,
+		relayerSet:    relayerSet,
+		bridgeAddress: bridgeAddress,
+		domainID:      domainID,
+		relayer:       relayer,
+	}
+}
+
+// SetObserver sets observer notified when the relayer role of this relayer is revoked
+func (eh *RelayerSetEventHandler) SetObserver(o message.Observer) {
+	eh.observer = o
+}
+
+// SkipsReplay keeps replayed blocks from moving the relayer set back to past state
+func (eh *RelayerSetEventHandler) SkipsReplay() bool {
+	return true
+}
+
+// relayerSetChange is a threshold or relayer role change applied at the position it was emitted at
+type relayerSetChange struct {
+	block uint64
+	index uint
+	apply func()
+}
+
+// HandleEvents applies relayer threshold changes and relayer role grants and revocations
+// emitted in the block range to the relayer set in the order they were emitted.
+// Changes older than the ones already applied are ignored. No messages are resolved from them.
+func (eh *RelayerSetEventHandler) HandleEvents(ctx context.Context, startBlock *big.Int, endBlock *big.Int) (map[uint64][]*message.Message, error) {
+	thresholdChanges, err := eh.eventListener.FetchThresholdChanges(ctx, eh.bridgeAddress, startBlock, endBlock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch threshold changes because of: %+v", err)
+	}
+	relayerChanges, err := eh.eventListener.FetchRelayerChanges(ctx, eh.bridgeAddress, startBlock, endBlock)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch relayer changes because of: %+v", err)
+	}
+
+	all := make([]relayerSetChange, 0, len(thresholdChanges)+len(relayerChanges))
+	for _, tc := range thresholdChanges {
+		tc := tc
+		all = append(all, relayerSetChange{block: tc.Block, index: tc.Index, apply: func() { eh.applyThresholdChange(tc) }})
+	}
+	for _, rc := range relayerChanges {
+		rc := rc
+		all = append(all, relayerSetChange{block: rc.Block, index: rc.Index, apply: func() { eh.applyRelayerChange(rc) }})
+	}
+	// threshold and relayer role changes are applied in the order they were emitted
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].block != all[j].block {
+			return all[i].block < all[j].block
+		}
+		return all[i].index < all[j].index
+	})
+	for _, c := range all {
+		c.apply()
+	}
+	return make(map[uint64][]*message.Message), nil
+}
+
+func (eh *RelayerSetEventHandler) applyThresholdChange(tc *events.ThresholdChanged) {
+	if !tc.NewThreshold.IsUint64() || tc.NewThreshold.Uint64() > math.MaxUint8 {
+		log.Error().Uint8("domainID", eh.domainID).Msgf("Relayer threshold %s changed in block %d is out of range", tc.NewThreshold, tc.Block)
+		return
+	}
+	if !eh.relayerSet.ApplyThresholdChange(uint8(tc.NewThreshold.Uint64()), tc.Block, tc.Index) {
+		log.Debug().Uint8("domainID", eh.domainID).Msgf("Ignoring relayer threshold change in block %d older than the applied one", tc.Block)
+		return
+	}
+	log.Info().Uint8("domainID", eh.domainID).Msgf("Relayer threshold changed to %s in block %d", tc.NewThreshold, tc.Block)
+}
+
+func (eh *RelayerSetEventHandler) applyRelayerChange(rc *events.RelayerChanged) {
+	if !eh.relayerSet.ApplyRelayerChange(rc.Relayer, rc.Granted, rc.Block, rc.Index) {
+		log.Debug().Uint8("domainID", eh.domainID).Msgf("Ignoring relayer %s change in block %d older than the applied one", rc.Relayer, rc.Block)
+		return
+	}
+	if rc.Granted {
+		log.Info().Uint8("domainID", eh.domainID).Msgf("Relayer %s added in block %d", rc.Relayer, rc.Block)
+		return
+	}
+	if rc.Relayer != eh.relayer {
+		log.Info().Uint8("domainID", eh.domainID).Msgf("Relayer %s removed in block %d", rc.Relayer, rc.Block)
+		return
+	}
+	log.Error().Uint8("domainID", eh.domainID).Msgf("This relayer %s was removed from the bridge in block %d", rc.Relayer, rc.Block)
+	if eh.observer != nil {
+		eh.observer.RelayerRemoved(eh.domainID, rc.Relayer)
 	}
-	log.Debug().Msgf("Queried block  %s", block.String())
-	return nil
 }
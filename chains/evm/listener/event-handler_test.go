@@ -0,0 +1,444 @@
+package listener_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/events"
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/listener"
+	mock_listener "github.com/VaivalGithub/chainsafe-core/chains/evm/listener/mock"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	mock_message "github.com/VaivalGithub/chainsafe-core/relayer/message/mock"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DepositEventHandlerTestSuite struct {
+	suite.Suite
+	depositEventHandler *listener.DepositEventHandler
+	mockEventListener   *mock_listener.MockEventListener
+	mockDepositHandler  *mock_listener.MockDepositHandler
+	bridgeAddress       common.Address
+}
+
+func TestRunDepositEventHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DepositEventHandlerTestSuite))
+}
+
+func (s *DepositEventHandlerTestSuite) SetupSuite()    {}
+func (s *DepositEventHandlerTestSuite) TearDownSuite() {}
+func (s *DepositEventHandlerTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockEventListener = mock_listener.NewMockEventListener(gomockController)
+	s.mockDepositHandler = mock_listener.NewMockDepositHandler(gomockController)
+	s.bridgeAddress = common.HexToAddress("0x9000000000000000000000000000000000000000")
+	s.depositEventHandler = listener.NewDepositEventHandler(s.mockEventListener, s.mockDepositHandler, s.bridgeAddress, 1)
+}
+func (s *DepositEventHandlerTestSuite) TearDownTest() {}
+
+func (s *DepositEventHandlerTestSuite) TestFetchDepositsReturnsMatchingDeposits() {
+	block := big.NewInt(100)
+	sender := common.HexToAddress("0x4CEEf6139f00F9F4535Ad19640Ff7A0137708485")
+	s.mockEventListener.EXPECT().FetchDeposits(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.Deposit{
+		{DestinationDomainID: 2, DepositNonce: 3, SenderAddress: sender},
+		{DestinationDomainID: 2, DepositNonce: 4, SenderAddress: sender},
+	}, nil)
+	s.mockDepositHandler.EXPECT().HandleDeposit(uint8(1), uint8(2), uint64(3), gomock.Any(), gomock.Any(), gomock.Any()).Return(&message.Message{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+	}, nil)
+
+	msgs, err := s.depositEventHandler.FetchDeposits(context.Background(), block, 3)
+
+	s.Nil(err)
+	s.Equal([]*message.Message{{Source: 1, Destination: 2, DepositNonce: 3, Sender: sender}}, msgs)
+}
+
+func (s *DepositEventHandlerTestSuite) TestFetchDepositsReturnsErrorIfDepositNotFound() {
+	block := big.NewInt(100)
+	s.mockEventListener.EXPECT().FetchDeposits(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.Deposit{
+		{DestinationDomainID: 2, DepositNonce: 4},
+	}, nil)
+
+	_, err := s.depositEventHandler.FetchDeposits(context.Background(), block, 3)
+
+	s.NotNil(err)
+}
+
+func (s *DepositEventHandlerTestSuite) TestFetchDepositsReturnsErrorIfFetchingFails() {
+	block := big.NewInt(100)
+	s.mockEventListener.EXPECT().FetchDeposits(gomock.Any(), s.bridgeAddress, block, block).Return(nil, errors.New("error"))
+
+	_, err := s.depositEventHandler.FetchDeposits(context.Background(), block, 3)
+
+	s.NotNil(err)
+}
+
+func (s *DepositEventHandlerTestSuite) TestHandleEventNotifiesObserver() {
+	block := big.NewInt(100)
+	m := &message.Message{Source: 1, Destination: 2, DepositNonce: 3}
+	s.mockEventListener.EXPECT().FetchDeposits(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.Deposit{
+		{DestinationDomainID: 2, DepositNonce: 3, Block: 100},
+	}, nil)
+	s.mockDepositHandler.EXPECT().HandleDeposit(uint8(1), uint8(2), uint64(3), gomock.Any(), gomock.Any(), gomock.Any()).Return(m, nil)
+	mockObserver := mock_message.NewMockObserver(gomock.NewController(s.T()))
+	mockObserver.EXPECT().DepositDetected(m)
+	s.depositEventHandler.SetObserver(mockObserver)
+
+	msgs, err := s.depositEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.Nil(err)
+	s.Equal(map[uint64][]*message.Message{100: {m}}, msgs)
+}
+
+func (s *DepositEventHandlerTestSuite) TestHandleEventsGroupsDepositsByBlock() {
+	startBlock := big.NewInt(100)
+	endBlock := big.NewInt(110)
+	s.mockEventListener.EXPECT().FetchDeposits(gomock.Any(), s.bridgeAddress, startBlock, endBlock).Return([]*events.Deposit{
+		{DestinationDomainID: 2, DepositNonce: 3, Block: 101},
+		{DestinationDomainID: 2, DepositNonce: 4, Block: 101},
+		{DestinationDomainID: 2, DepositNonce: 5, Block: 105},
+	}, nil)
+	s.mockDepositHandler.EXPECT().HandleDeposit(uint8(1), uint8(2), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(sourceID, destID uint8, nonce uint64, resourceID types.ResourceID, calldata, handlerResponse []byte) (*message.Message, error) {
+			return &message.Message{Source: sourceID, Destination: destID, DepositNonce: nonce}, nil
+		}).Times(3)
+
+	msgs, err := s.depositEventHandler.HandleEvents(context.Background(), startBlock, endBlock)
+
+	s.Nil(err)
+	s.Equal(map[uint64][]*message.Message{
+		101: {{Source: 1, Destination: 2, DepositNonce: 3}, {Source: 1, Destination: 2, DepositNonce: 4}},
+		105: {{Source: 1, Destination: 2, DepositNonce: 5}},
+	}, msgs)
+}
+
+type RegisterTokenEventHandlerTestSuite struct {
+	suite.Suite
+	registerTokenEventHandler *listener.RegisterTokenEventHandler
+	mockEventListener         *mock_listener.MockRegisterTokenListener
+	bridgeAddress             common.Address
+}
+
+func TestRunRegisterTokenEventHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RegisterTokenEventHandlerTestSuite))
+}
+
+func (s *RegisterTokenEventHandlerTestSuite) SetupSuite()    {}
+func (s *RegisterTokenEventHandlerTestSuite) TearDownSuite() {}
+func (s *RegisterTokenEventHandlerTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockEventListener = mock_listener.NewMockRegisterTokenListener(gomockController)
+	s.bridgeAddress = common.HexToAddress("0x9000000000000000000000000000000000000000")
+	s.registerTokenEventHandler = listener.NewRegisterTokenEventHandler(s.mockEventListener, s.bridgeAddress, 1)
+}
+func (s *RegisterTokenEventHandlerTestSuite) TearDownTest() {}
+
+func (s *RegisterTokenEventHandlerTestSuite) TestHandleEventFetchFails() {
+	block := big.NewInt(100)
+	s.mockEventListener.EXPECT().FetchRegisterTokens(gomock.Any(), s.bridgeAddress, block, block).Return(nil, errors.New("error"))
+
+	_, err := s.registerTokenEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.NotNil(err)
+}
+
+func (s *RegisterTokenEventHandlerTestSuite) TestHandleEventSendsTokenRegistrations() {
+	block := big.NewInt(100)
+	rt := &events.RegisterToken{
+		DomainId:             1,
+		DestinationDomainId:  2,
+		DepositNounce:        3,
+		SourceHandler:        common.HexToAddress("0x1"),
+		DestHandler:          common.HexToAddress("0x2"),
+		DestBridgeContract:   common.HexToAddress("0x3"),
+		SourceBridgeContract: s.bridgeAddress,
+		SourceToken:          common.HexToAddress("0x4"),
+		DestToken:            common.HexToAddress("0x5"),
+		ResourceID:           [32]byte{31: 1},
+		Block:                100,
+	}
+	s.mockEventListener.EXPECT().FetchRegisterTokens(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.RegisterToken{rt}, nil)
+
+	msgs, err := s.registerTokenEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.Nil(err)
+	s.Equal(len(msgs[100]), 1)
+	m := msgs[100][0]
+	s.Equal(message.TokenRegistration, m.Type)
+	m2, err := m.Message2()
+	s.Nil(err)
+	s.Equal(message.NewMessage1(1, 2, 3, rt.ResourceID, rt.SourceHandler, rt.DestHandler, rt.DestBridgeContract, rt.SourceBridgeContract, rt.SourceToken, rt.DestToken), m2)
+}
+
+type ProposalEventHandlerTestSuite struct {
+	suite.Suite
+	proposalEventHandler *listener.ProposalEventHandler
+	mockEventListener    *mock_listener.MockProposalEventListener
+	mockProposalStore    *mock_listener.MockProposalStore
+	bridgeAddress        common.Address
+}
+
+func TestRunProposalEventHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ProposalEventHandlerTestSuite))
+}
+
+func (s *ProposalEventHandlerTestSuite) SetupSuite()    {}
+func (s *ProposalEventHandlerTestSuite) TearDownSuite() {}
+func (s *ProposalEventHandlerTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockEventListener = mock_listener.NewMockProposalEventListener(gomockController)
+	s.mockProposalStore = mock_listener.NewMockProposalStore(gomockController)
+	s.bridgeAddress = common.HexToAddress("0x9000000000000000000000000000000000000000")
+	s.proposalEventHandler = listener.NewProposalEventHandler(s.mockEventListener, s.mockProposalStore, s.bridgeAddress, 2)
+}
+func (s *ProposalEventHandlerTestSuite) TearDownTest() {}
+
+func (s *ProposalEventHandlerTestSuite) TestHandleEventFetchFails() {
+	block := big.NewInt(100)
+	s.mockEventListener.EXPECT().FetchProposalEvents(gomock.Any(), s.bridgeAddress, block, block).Return(nil, errors.New("error"))
+
+	_, err := s.proposalEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.NotNil(err)
+}
+
+func (s *ProposalEventHandlerTestSuite) TestHandleEventsAppliesEventsInOrder() {
+	start := big.NewInt(100)
+	end := big.NewInt(101)
+	dataHash := [32]byte{31: 1}
+	firstVoter := common.HexToAddress("0x1")
+	secondVoter := common.HexToAddress("0x2")
+	s.mockEventListener.EXPECT().FetchProposalEvents(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.ProposalEvent{
+		{OriginDomainID: 1, DepositNonce: 3, Status: message.ProposalStatusExecuted, DataHash: dataHash, Block: 101, Index: 2},
+		{OriginDomainID: 1, DepositNonce: 3, Status: message.ProposalStatusPassed, DataHash: dataHash, Block: 101, Index: 1},
+	}, nil)
+	s.mockEventListener.EXPECT().FetchProposalVotes(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.ProposalEvent{
+		{OriginDomainID: 1, DepositNonce: 3, Status: message.ProposalStatusActive, DataHash: dataHash, Voter: secondVoter, Block: 101, Index: 0},
+		{OriginDomainID: 1, DepositNonce: 3, Status: message.ProposalStatusActive, DataHash: dataHash, Voter: firstVoter, Block: 100, Index: 5},
+	}, nil)
+	var stored *store.ProposalState
+	s.mockProposalStore.EXPECT().GetProposal(uint8(2), uint8(1), uint64(3)).DoAndReturn(func(domainID, source uint8, depositNonce uint64) (*store.ProposalState, error) {
+		return stored, nil
+	}).Times(4)
+	statuses := make([]uint8, 0)
+	s.mockProposalStore.EXPECT().StoreProposal(uint8(2), gomock.Any()).DoAndReturn(func(domainID uint8, p *store.ProposalState) error {
+		statuses = append(statuses, p.Status)
+		stored = p
+		return nil
+	}).Times(4)
+
+	msgs, err := s.proposalEventHandler.HandleEvents(context.Background(), start, end)
+
+	s.Nil(err)
+	s.Len(msgs, 0)
+	s.Equal([]uint8{message.ProposalStatusActive, message.ProposalStatusActive, message.ProposalStatusPassed, message.ProposalStatusExecuted}, statuses)
+	s.Equal(&store.ProposalState{
+		Source:        1,
+		DepositNonce:  3,
+		DataHash:      dataHash,
+		Status:        message.ProposalStatusExecuted,
+		Voters:        []common.Address{firstVoter, secondVoter},
+		ProposedBlock: 100,
+		UpdatedBlock:  101,
+	}, stored)
+}
+
+func (s *ProposalEventHandlerTestSuite) TestHandleEventsAgainDoesNotChangeProposal() {
+	block := big.NewInt(100)
+	voter := common.HexToAddress("0x1")
+	proposal := &store.ProposalState{
+		Source:        1,
+		DepositNonce:  3,
+		Status:        message.ProposalStatusExecuted,
+		Voters:        []common.Address{voter},
+		ProposedBlock: 90,
+		UpdatedBlock:  110,
+	}
+	s.mockEventListener.EXPECT().FetchProposalEvents(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.ProposalEvent{}, nil)
+	s.mockEventListener.EXPECT().FetchProposalVotes(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.ProposalEvent{
+		{OriginDomainID: 1, DepositNonce: 3, Status: message.ProposalStatusActive, Voter: voter, Block: 100},
+	}, nil)
+	s.mockProposalStore.EXPECT().GetProposal(uint8(2), uint8(1), uint64(3)).Return(proposal, nil)
+	s.mockProposalStore.EXPECT().StoreProposal(uint8(2), &store.ProposalState{
+		Source:        1,
+		DepositNonce:  3,
+		Status:        message.ProposalStatusExecuted,
+		Voters:        []common.Address{voter},
+		ProposedBlock: 90,
+		UpdatedBlock:  110,
+	}).Return(nil)
+
+	_, err := s.proposalEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.Nil(err)
+}
+
+func (s *ProposalEventHandlerTestSuite) TestHandleEventsStoresExecutedMessageStatus() {
+	block := big.NewInt(100)
+	mockMessageStatusStore := mock_listener.NewMockMessageStatusStore(gomock.NewController(s.T()))
+	s.proposalEventHandler.SetMessageStatusStore(mockMessageStatusStore)
+	s.mockEventListener.EXPECT().FetchProposalEvents(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.ProposalEvent{
+		{OriginDomainID: 1, DepositNonce: 3, Status: message.ProposalStatusPassed, Block: 100, Index: 1},
+		{OriginDomainID: 1, DepositNonce: 3, Status: message.ProposalStatusExecuted, Block: 100, Index: 2},
+	}, nil)
+	s.mockEventListener.EXPECT().FetchProposalVotes(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.ProposalEvent{}, nil)
+	s.mockProposalStore.EXPECT().GetProposal(uint8(2), uint8(1), uint64(3)).Return(nil, nil).Times(2)
+	s.mockProposalStore.EXPECT().StoreProposal(uint8(2), gomock.Any()).Return(nil).Times(2)
+	mockMessageStatusStore.EXPECT().StoreMessageStatus(&message.Message{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+	}, message.MessageStatusExecuted).Return(nil)
+
+	_, err := s.proposalEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.Nil(err)
+}
+
+type RelayerSetEventHandlerTestSuite struct {
+	suite.Suite
+	relayerSetEventHandler *listener.RelayerSetEventHandler
+	mockEventListener      *mock_listener.MockRelayerSetListener
+	relayerSet             *listener.RelayerSet
+	mockObserver           *mock_message.MockObserver
+	bridgeAddress          common.Address
+	relayer                common.Address
+}
+
+func TestRunRelayerSetEventHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayerSetEventHandlerTestSuite))
+}
+
+func (s *RelayerSetEventHandlerTestSuite) SetupSuite()    {}
+func (s *RelayerSetEventHandlerTestSuite) TearDownSuite() {}
+func (s *RelayerSetEventHandlerTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockEventListener = mock_listener.NewMockRelayerSetListener(gomockController)
+	s.relayerSet = listener.NewRelayerSet()
+	s.mockObserver = mock_message.NewMockObserver(gomockController)
+	s.bridgeAddress = common.HexToAddress("0x9000000000000000000000000000000000000000")
+	s.relayer = common.HexToAddress("0x5")
+	s.relayerSetEventHandler = listener.NewRelayerSetEventHandler(s.mockEventListener, s.relayerSet, s.bridgeAddress, 2, s.relayer)
+	s.relayerSetEventHandler.SetObserver(s.mockObserver)
+}
+func (s *RelayerSetEventHandlerTestSuite) TearDownTest() {}
+
+func (s *RelayerSetEventHandlerTestSuite) TestHandleEventFetchFails() {
+	block := big.NewInt(100)
+	s.mockEventListener.EXPECT().FetchThresholdChanges(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.ThresholdChanged{}, nil)
+	s.mockEventListener.EXPECT().FetchRelayerChanges(gomock.Any(), s.bridgeAddress, block, block).Return(nil, errors.New("error"))
+
+	_, err := s.relayerSetEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.NotNil(err)
+	_, ok := s.relayerSet.Threshold()
+	s.False(ok)
+}
+
+func (s *RelayerSetEventHandlerTestSuite) TestHandleEventsUpdatesRelayerSet() {
+	start := big.NewInt(100)
+	end := big.NewInt(101)
+	addedRelayer := common.HexToAddress("0x1")
+	removedRelayer := common.HexToAddress("0x2")
+	s.relayerSet.SetRelayer(removedRelayer, true)
+	s.mockEventListener.EXPECT().FetchThresholdChanges(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.ThresholdChanged{
+		{NewThreshold: big.NewInt(2), Block: 100, Index: 1},
+		{NewThreshold: big.NewInt(1000), Block: 100, Index: 2},
+		{NewThreshold: big.NewInt(3), Block: 101, Index: 0},
+	}, nil)
+	s.mockEventListener.EXPECT().FetchRelayerChanges(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.RelayerChanged{
+		{Relayer: addedRelayer, Granted: true, Block: 100},
+		{Relayer: removedRelayer, Granted: false, Block: 101},
+	}, nil)
+
+	msgs, err := s.relayerSetEventHandler.HandleEvents(context.Background(), start, end)
+
+	s.Nil(err)
+	s.Equal(len(msgs), 0)
+	threshold, ok := s.relayerSet.Threshold()
+	s.True(ok)
+	s.Equal(uint8(3), threshold)
+	isRelayer, ok := s.relayerSet.IsRelayer(removedRelayer)
+	s.True(ok)
+	s.False(isRelayer)
+	s.Equal([]common.Address{addedRelayer}, s.relayerSet.Relayers())
+}
+
+func (s *RelayerSetEventHandlerTestSuite) TestHandleEventsAppliesChangesInEmittedOrder() {
+	start := big.NewInt(100)
+	end := big.NewInt(101)
+	relayer := common.HexToAddress("0x1")
+	s.mockEventListener.EXPECT().FetchThresholdChanges(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.ThresholdChanged{
+		{NewThreshold: big.NewInt(3), Block: 101, Index: 0},
+		{NewThreshold: big.NewInt(2), Block: 100, Index: 4},
+	}, nil)
+	s.mockEventListener.EXPECT().FetchRelayerChanges(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.RelayerChanged{
+		{Relayer: relayer, Granted: false, Block: 100, Index: 5},
+		{Relayer: relayer, Granted: true, Block: 100, Index: 2},
+	}, nil)
+
+	_, err := s.relayerSetEventHandler.HandleEvents(context.Background(), start, end)
+
+	s.Nil(err)
+	threshold, _ := s.relayerSet.Threshold()
+	s.Equal(uint8(3), threshold)
+	isRelayer, ok := s.relayerSet.IsRelayer(relayer)
+	s.True(ok)
+	s.False(isRelayer)
+}
+
+func (s *RelayerSetEventHandlerTestSuite) TestHandleEventsIgnoresChangesOlderThanApplied() {
+	relayer := common.HexToAddress("0x1")
+	block := big.NewInt(110)
+	s.mockEventListener.EXPECT().FetchThresholdChanges(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.ThresholdChanged{
+		{NewThreshold: big.NewInt(3), Block: 110, Index: 1},
+	}, nil)
+	s.mockEventListener.EXPECT().FetchRelayerChanges(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.RelayerChanged{
+		{Relayer: relayer, Granted: true, Block: 110, Index: 2},
+	}, nil)
+	_, err := s.relayerSetEventHandler.HandleEvents(context.Background(), block, block)
+	s.Nil(err)
+
+	start := big.NewInt(100)
+	end := big.NewInt(110)
+	s.mockEventListener.EXPECT().FetchThresholdChanges(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.ThresholdChanged{
+		{NewThreshold: big.NewInt(2), Block: 100, Index: 0},
+		{NewThreshold: big.NewInt(3), Block: 110, Index: 1},
+	}, nil)
+	s.mockEventListener.EXPECT().FetchRelayerChanges(gomock.Any(), s.bridgeAddress, start, end).Return([]*events.RelayerChanged{
+		{Relayer: relayer, Granted: false, Block: 105, Index: 0},
+		{Relayer: relayer, Granted: true, Block: 110, Index: 2},
+	}, nil)
+
+	_, err = s.relayerSetEventHandler.HandleEvents(context.Background(), start, end)
+
+	s.Nil(err)
+	threshold, _ := s.relayerSet.Threshold()
+	s.Equal(uint8(3), threshold)
+	isRelayer, _ := s.relayerSet.IsRelayer(relayer)
+	s.True(isRelayer)
+}
+
+func (s *RelayerSetEventHandlerTestSuite) TestHandleEventsNotifiesObserverIfRelayerRemoved() {
+	block := big.NewInt(100)
+	s.relayerSet.SetRelayer(s.relayer, true)
+	s.mockEventListener.EXPECT().FetchThresholdChanges(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.ThresholdChanged{}, nil)
+	s.mockEventListener.EXPECT().FetchRelayerChanges(gomock.Any(), s.bridgeAddress, block, block).Return([]*events.RelayerChanged{
+		{Relayer: common.HexToAddress("0x1"), Granted: false, Block: 100, Index: 1},
+		{Relayer: s.relayer, Granted: false, Block: 100, Index: 2},
+	}, nil)
+	s.mockObserver.EXPECT().RelayerRemoved(uint8(2), s.relayer).Times(1)
+
+	_, err := s.relayerSetEventHandler.HandleEvents(context.Background(), block, block)
+
+	s.Nil(err)
+	isRelayer, _ := s.relayerSet.IsRelayer(s.relayer)
+	s.False(isRelayer)
+}
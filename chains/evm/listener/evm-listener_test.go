@@ -0,0 +1,271 @@
+package listener_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/listener"
+	mock_listener "github.com/VaivalGithub/chainsafe-core/chains/evm/listener/mock"
+	"github.com/VaivalGithub/chainsafe-core/config/chain"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_blockstore "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type EVMListenerTestSuite struct {
+	suite.Suite
+	evmListener       *listener.EVMListener
+	mockChainClient   *mock_listener.MockChainClient
+	mockFirstHandler  *mock_listener.MockEventHandler
+	mockSecondHandler *mock_listener.MockEventHandler
+	mockKeyValue      *mock_blockstore.MockKeyValueReaderWriter
+}
+
+func TestRunEVMListenerTestSuite(t *testing.T) {
+	suite.Run(t, new(EVMListenerTestSuite))
+}
+
+func (s *EVMListenerTestSuite) SetupSuite()    {}
+func (s *EVMListenerTestSuite) TearDownSuite() {}
+func (s *EVMListenerTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockChainClient = mock_listener.NewMockChainClient(gomockController)
+	s.mockFirstHandler = mock_listener.NewMockEventHandler(gomockController)
+	s.mockSecondHandler = mock_listener.NewMockEventHandler(gomockController)
+	s.mockKeyValue = mock_blockstore.NewMockKeyValueReaderWriter(gomockController)
+	domainID := uint8(1)
+	s.evmListener = listener.NewEVMListener(
+		s.mockChainClient,
+		[]listener.EventHandler{s.mockFirstHandler, s.mockSecondHandler},
+		store.NewBlockStore(s.mockKeyValue),
+		&chain.EVMConfig{
+			GeneralChainConfig: chain.GeneralChainConfig{Id: &domainID},
+			BlockConfirmations: big.NewInt(10),
+			BlockRetryInterval: time.Millisecond,
+			BlockInterval:      big.NewInt(500),
+		},
+	)
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(1010), nil).AnyTimes()
+}
+func (s *EVMListenerTestSuite) TearDownTest() {}
+
+// expectStoredBlock expects block to be stored and calls done once it is
+func (s *EVMListenerTestSuite) expectStoredBlock(block int64, done func()) *gomock.Call {
+	return s.mockKeyValue.EXPECT().SetByKey([]byte("chain:1:block"), big.NewInt(block).Bytes()).DoAndReturn(func(key, value []byte) error {
+		done()
+		return nil
+	})
+}
+
+func (s *EVMListenerTestSuite) TestSendsMessagesOfBlockRangeInBlockOrder() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := &message.Message{DepositNonce: 1}
+	second := &message.Message{DepositNonce: 2}
+	third := &message.Message{DepositNonce: 3}
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(500)).Return(map[uint64][]*message.Message{
+		5: {third},
+	}, nil)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(500)).Return(map[uint64][]*message.Message{
+		3: {first, second},
+	}, nil)
+	s.expectStoredBlock(500, func() {})
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(501), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(501), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil)
+	s.expectStoredBlock(1000, cancel)
+	msgChan := make(chan *message.Message, 3)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(1), msgChan, make(chan error))
+
+	s.Equal(first, <-msgChan)
+	s.Equal(second, <-msgChan)
+	s.Equal(third, <-msgChan)
+}
+
+func (s *EVMListenerTestSuite) TestHalvesBlockRangeRejectedByProvider() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(500)).Return(nil, errors.New("query returned more than 10000 results")),
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(250)).Return(map[uint64][]*message.Message{}, nil),
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(251), big.NewInt(750)).Return(map[uint64][]*message.Message{}, nil),
+	)
+	gomock.InOrder(
+		s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(250)).Return(map[uint64][]*message.Message{}, nil),
+		s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(251), big.NewInt(750)).Return(map[uint64][]*message.Message{}, nil),
+	)
+	s.expectStoredBlock(250, func() {})
+	s.expectStoredBlock(750, cancel)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(1), make(chan *message.Message), make(chan error))
+}
+
+func (s *EVMListenerTestSuite) TestRetriesSameBlockRangeOnTimeout() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(nil, errors.New("i/o timeout")),
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil),
+	)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil)
+	s.expectStoredBlock(1000, cancel)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(801), make(chan *message.Message), make(chan error))
+}
+
+func (s *EVMListenerTestSuite) TestRetriesBlockRangeIfHandlerFails() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(nil, errors.New("connection refused")),
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil),
+	)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil)
+	s.expectStoredBlock(1000, cancel)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(801), make(chan *message.Message), make(chan error))
+}
+
+// newListenerWithStrategy creates listener that confirms blocks by the strategy
+func (s *EVMListenerTestSuite) newListenerWithStrategy(strategy chain.ConfirmationStrategy) *listener.EVMListener {
+	domainID := uint8(1)
+	return listener.NewEVMListener(
+		s.mockChainClient,
+		[]listener.EventHandler{s.mockFirstHandler, s.mockSecondHandler},
+		store.NewBlockStore(s.mockKeyValue),
+		&chain.EVMConfig{
+			GeneralChainConfig:   chain.GeneralChainConfig{Id: &domainID},
+			BlockConfirmations:   big.NewInt(10),
+			BlockRetryInterval:   time.Millisecond,
+			BlockInterval:        big.NewInt(500),
+			ConfirmationStrategy: strategy,
+			ConfirmationDelay:    time.Minute,
+		},
+	)
+}
+
+func (s *EVMListenerTestSuite) TestWaitsForFinalizedBlock() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	evmListener := s.newListenerWithStrategy(chain.FinalizedConfirmation)
+	gomock.InOrder(
+		s.mockChainClient.EXPECT().FinalizedBlock(gomock.Any()).Return(big.NewInt(900), nil),
+		s.mockChainClient.EXPECT().FinalizedBlock(gomock.Any()).Return(big.NewInt(1005), nil),
+	)
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1001), big.NewInt(1005)).Return(map[uint64][]*message.Message{}, nil)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1001), big.NewInt(1005)).Return(map[uint64][]*message.Message{}, nil)
+	s.expectStoredBlock(1005, cancel)
+
+	evmListener.ListenToEvents(ctx, big.NewInt(1001), make(chan *message.Message), make(chan error))
+}
+
+func (s *EVMListenerTestSuite) TestConfirmsBlocksMinedBeforeDelay() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	evmListener := s.newListenerWithStrategy(chain.DelayConfirmation)
+	s.mockChainClient.EXPECT().LatestBlockBefore(gomock.Any(), big.NewInt(1001), big.NewInt(1010), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, from *big.Int, to *big.Int, timestamp time.Time) (*big.Int, error) {
+			s.WithinDuration(time.Now().Add(-time.Minute), timestamp, time.Second)
+			return big.NewInt(1008), nil
+		})
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1001), big.NewInt(1008)).Return(map[uint64][]*message.Message{}, nil)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1001), big.NewInt(1008)).Return(map[uint64][]*message.Message{}, nil)
+	s.expectStoredBlock(1008, cancel)
+
+	evmListener.ListenToEvents(ctx, big.NewInt(1001), make(chan *message.Message), make(chan error))
+}
+
+func (s *EVMListenerTestSuite) TestReplaySendsMessagesWithoutStoringBlocks() {
+	first := &message.Message{DepositNonce: 1}
+	second := &message.Message{DepositNonce: 2}
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(100), big.NewInt(599)).Return(map[uint64][]*message.Message{
+		150: {first},
+	}, nil)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(100), big.NewInt(599)).Return(map[uint64][]*message.Message{}, nil)
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(600), big.NewInt(700)).Return(map[uint64][]*message.Message{}, nil)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(600), big.NewInt(700)).Return(map[uint64][]*message.Message{
+		700: {second},
+	}, nil)
+	msgChan := make(chan *message.Message, 2)
+
+	err := s.evmListener.Replay(context.Background(), big.NewInt(100), big.NewInt(700), msgChan)
+
+	s.Nil(err)
+	s.Equal(first, <-msgChan)
+	s.Equal(second, <-msgChan)
+}
+
+func (s *EVMListenerTestSuite) TestReplayHalvesBlockRangeRejectedByProvider() {
+	gomock.InOrder(
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(300)).Return(nil, errors.New("query returned more than 10000 results")),
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(250)).Return(map[uint64][]*message.Message{}, nil),
+		s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(251), big.NewInt(300)).Return(map[uint64][]*message.Message{}, nil),
+	)
+	gomock.InOrder(
+		s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(250)).Return(map[uint64][]*message.Message{}, nil),
+		s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(251), big.NewInt(300)).Return(map[uint64][]*message.Message{}, nil),
+	)
+
+	err := s.evmListener.Replay(context.Background(), big.NewInt(1), big.NewInt(300), make(chan *message.Message))
+
+	s.Nil(err)
+}
+
+func (s *EVMListenerTestSuite) TestReplayFailsIfHandlerFails() {
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(300)).Return(nil, errors.New("connection refused"))
+
+	err := s.evmListener.Replay(context.Background(), big.NewInt(1), big.NewInt(300), make(chan *message.Message))
+
+	s.NotNil(err)
+}
+
+func (s *EVMListenerTestSuite) TestReplayStopsAtConfirmedBlock() {
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil)
+	s.mockSecondHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(801), big.NewInt(1000)).Return(map[uint64][]*message.Message{}, nil)
+
+	err := s.evmListener.Replay(context.Background(), big.NewInt(801), big.NewInt(1200), make(chan *message.Message))
+
+	s.Nil(err)
+}
+
+func (s *EVMListenerTestSuite) TestReplayRejectsUnconfirmedRange() {
+	err := s.evmListener.Replay(context.Background(), big.NewInt(1001), big.NewInt(1200), make(chan *message.Message))
+
+	s.NotNil(err)
+}
+
+func (s *EVMListenerTestSuite) TestReplaySkipsRelayerSetEventHandler() {
+	domainID := uint8(1)
+	relayerSetListener := mock_listener.NewMockRelayerSetListener(gomock.NewController(s.T()))
+	evmListener := listener.NewEVMListener(
+		s.mockChainClient,
+		[]listener.EventHandler{
+			s.mockFirstHandler,
+			listener.NewRelayerSetEventHandler(relayerSetListener, listener.NewRelayerSet(), common.Address{}, domainID, common.Address{}),
+		},
+		store.NewBlockStore(s.mockKeyValue),
+		&chain.EVMConfig{
+			GeneralChainConfig: chain.GeneralChainConfig{Id: &domainID},
+			BlockConfirmations: big.NewInt(10),
+			BlockRetryInterval: time.Millisecond,
+			BlockInterval:      big.NewInt(500),
+		},
+	)
+	s.mockFirstHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(1), big.NewInt(300)).Return(map[uint64][]*message.Message{}, nil)
+
+	err := evmListener.Replay(context.Background(), big.NewInt(1), big.NewInt(300), make(chan *message.Message))
+
+	s.Nil(err)
+}
+
+func (s *EVMListenerTestSuite) TestReplayRejectsInvertedRange() {
+	err := s.evmListener.Replay(context.Background(), big.NewInt(300), big.NewInt(1), make(chan *message.Message))
+
+	s.NotNil(err)
+}
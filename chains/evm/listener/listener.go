@@ -5,22 +5,39 @@ package listener
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/VaivalGithub/chainsafe-core/config/chain"
 	"github.com/VaivalGithub/chainsafe-core/relayer/message"
 	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/rs/zerolog/log"
 )
 
 type EventHandler interface {
-	HandleEvent(block *big.Int, msgChan chan *message.Message) error
+	// HandleEvents resolves messages from events emitted from startBlock to endBlock
+	// inclusive and returns them grouped by the block they were emitted in
+	HandleEvents(ctx context.Context, startBlock *big.Int, endBlock *big.Int) (map[uint64][]*message.Message, error)
+}
+
+// ReplaySkipper is implemented by event handlers that track current bridge state
+// and should not be executed again over already handled blocks on replay
+type ReplaySkipper interface {
+	SkipsReplay() bool
 }
 
 type ChainClient interface {
 	LatestBlock() (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error)
+	FinalizedBlock(ctx context.Context) (*big.Int, error)
+	SafeBlock(ctx context.Context) (*big.Int, error)
+	LatestBlockBefore(ctx context.Context, from *big.Int, to *big.Int, timestamp time.Time) (*big.Int, error)
 }
 
 type EVMListener struct {
@@ -29,8 +46,17 @@ type EVMListener struct {
 
 	domainID           uint8
 	blockstore         *store.BlockStore
+	hashStore          HashStore
+	maxReorgDepth      uint64
+	subscriber         ChainSubscriber
+	addresses          []common.Address
+	subscriptionMargin uint64
 	blockRetryInterval time.Duration
 	blockConfirmations *big.Int
+	blockInterval      *big.Int
+
+	confirmationStrategy chain.ConfirmationStrategy
+	confirmationDelay    time.Duration
 }
 
 // NewEVMListener creates an EVMListener that listens to deposit events on chain
@@ -43,18 +69,64 @@ func NewEVMListener(client ChainClient, eventHandlers []EventHandler, blockstore
 		domainID:           *config.GeneralChainConfig.Id,
 		blockRetryInterval: config.BlockRetryInterval,
 		blockConfirmations: config.BlockConfirmations,
+		blockInterval:      config.BlockInterval,
+		maxReorgDepth:      config.MaxReorgDepth,
+		subscriptionMargin: config.SubscriptionMargin,
+
+		confirmationStrategy: config.ConfirmationStrategy,
+		confirmationDelay:    config.ConfirmationDelay,
+	}
+}
+
+// SetObserver passes observer to event handlers that report message lifecycle transitions
+func (l *EVMListener) SetObserver(o message.Observer) {
+	for _, handler := range l.eventHandlers {
+		if observable, ok := handler.(message.Observable); ok {
+			observable.SetObserver(o)
+		}
 	}
 }
 
-// ListenToEvents goes block by block of a network and executes event handlers that are
-// configured for the listener.
+// ListenToEvents goes through block ranges of a network and executes event handlers that are
+// configured for the listener. Ranges are halved while the provider rejects them for
+// being too large and grow back up to the configured block interval once it accepts them.
+// Messages of a range are sent in block order and the range end is stored once they are sent.
+// If a hash store is set, the listener rewinds to the fork point of a detected chain reorganisation
+// and retracts deposits that were removed from the canonical chain.
+// If a subscriber is set, the listener waits for new heads instead of polling for them.
 func (l *EVMListener) ListenToEvents(ctx context.Context, block *big.Int, msgChan chan *message.Message, errChn chan<- error) {
+	if block != nil {
+		block = new(big.Int).Set(block)
+	}
+	blockInterval := l.maxBlockInterval()
+	rangeSize := new(big.Int).Set(blockInterval)
+	var sub *subscription
+	var subscribed time.Time
+	defer func() {
+		if sub != nil {
+			sub.Close()
+		}
+	}()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		default:
-			head, err := l.client.LatestBlock()
+			if sub != nil && sub.Err() != nil {
+				log.Warn().Err(sub.Err()).Uint8("DomainID", l.domainID).Msg("Chain subscription failed, falling back to polling")
+				sub.Close()
+				sub = nil
+			}
+			if sub == nil && l.subscriber != nil && time.Since(subscribed) >= l.blockRetryInterval {
+				subscribed = time.Now()
+				var err error
+				sub, err = l.subscribe(ctx)
+				if err != nil {
+					log.Warn().Err(err).Uint8("DomainID", l.domainID).Msg("Unable to subscribe to chain, polling for new blocks")
+				}
+			}
+
+			head, err := l.latestBlock(sub)
 			if err != nil {
 				log.Error().Err(err).Msg("Unable to get latest block")
 				time.Sleep(l.blockRetryInterval)
@@ -63,26 +135,260 @@ func (l *EVMListener) ListenToEvents(ctx context.Context, block *big.Int, msgCha
 			if block == nil {
 				block = head
 			}
-			// Sleep if the difference is less than needed block confirmations; (latest - current) < BlockDelay
-			if big.NewInt(0).Sub(head, block).Cmp(l.blockConfirmations) == -1 {
+			confirmed, err := l.confirmedBlock(ctx, head, block)
+			if err != nil {
+				log.Error().Err(err).Uint8("DomainID", l.domainID).Msgf("Unable to get %s confirmed block", l.confirmationStrategy)
 				time.Sleep(l.blockRetryInterval)
 				continue
 			}
-			log.Debug().Msgf("Queried block in listner %s", block.String())
-			for _, handler := range l.eventHandlers {
-				err := handler.HandleEvent(block, msgChan)
+			// Wait if the block is not confirmed yet
+			if confirmed.Cmp(block) == -1 {
+				l.waitForBlocks(ctx, sub)
+				continue
+			}
+			endBlock := new(big.Int).Add(block, rangeSize)
+			endBlock.Sub(endBlock, big.NewInt(1))
+			if endBlock.Cmp(confirmed) == 1 {
+				endBlock = confirmed
+			}
+
+			var headers map[uint64]*ethTypes.Header
+			if l.detectsReorgs() {
+				fork, removed, err := l.detectReorg(ctx, block)
 				if err != nil {
-					log.Error().Err(err).Str("DomainID", string(l.domainID)).Msgf("Unable to handle events")
+					log.Error().Err(err).Uint8("DomainID", l.domainID).Msgf("Unable to check block %s for chain reorganisation", block)
+					time.Sleep(l.blockRetryInterval)
 					continue
 				}
+				if fork != nil {
+					log.Warn().Uint8("DomainID", l.domainID).Msgf("Chain reorganisation detected, rewinding from block %s to block %s", block, fork)
+					err = l.rewind(ctx, fork, block, removed, msgChan)
+					if err != nil {
+						if ctx.Err() != nil {
+							return
+						}
+						log.Error().Err(err).Uint8("DomainID", l.domainID).Msgf("Unable to rewind to block %s", fork)
+						time.Sleep(l.blockRetryInterval)
+						continue
+					}
+					block = new(big.Int).Add(fork, big.NewInt(1))
+					continue
+				}
+
+				headers, err = l.fetchHeaders(ctx, head, block, endBlock)
+				if err != nil {
+					log.Error().Err(err).Uint8("DomainID", l.domainID).Msgf("Unable to fetch headers of blocks %s-%s", block, endBlock)
+					time.Sleep(l.blockRetryInterval)
+					continue
+				}
+			}
+
+			msgs := make(map[uint64][]*message.Message)
+			if sub == nil || !sub.Covers(block, endBlock) {
+				log.Debug().Msgf("Queried blocks %s-%s in listener", block, endBlock)
+				msgs, err = l.fetchEvents(ctx, block, endBlock, false)
+			}
+			if err != nil {
+				if isRangeTooLarge(err) && rangeSize.Cmp(big.NewInt(1)) == 1 {
+					rangeSize.Rsh(rangeSize, 1)
+					log.Warn().Err(err).Uint8("DomainID", l.domainID).Msgf("Block range too large, reducing it to %s blocks", rangeSize)
+					continue
+				}
+				log.Error().Err(err).Uint8("DomainID", l.domainID).Msgf("Unable to handle events of blocks %s-%s", block, endBlock)
+				time.Sleep(l.blockRetryInterval)
+				continue
+			}
+			if l.detectsReorgs() {
+				canonical, err := l.isCanonical(ctx, headers, endBlock)
+				if err != nil || !canonical {
+					log.Warn().Err(err).Uint8("DomainID", l.domainID).Msgf("Blocks %s-%s changed while fetching events, retrying", block, endBlock)
+					time.Sleep(l.blockRetryInterval)
+					continue
+				}
+			}
+			if !l.sendMessages(ctx, msgs, msgChan) {
+				return
+			}
+			if l.detectsReorgs() {
+				l.storeProcessedBlocks(headers, msgs)
 			}
 
 			//Write to block store. Not a critical operation, no need to retry
-			err = l.blockstore.StoreBlock(block, l.domainID)
+			err = l.blockstore.StoreBlock(endBlock, l.domainID)
 			if err != nil {
-				log.Error().Str("block", block.String()).Err(err).Msg("Failed to write latest block to blockstore")
+				log.Error().Str("block", endBlock.String()).Err(err).Msg("Failed to write latest block to blockstore")
+			}
+			if sub != nil {
+				sub.Prune(endBlock)
+			}
+			block = new(big.Int).Add(endBlock, big.NewInt(1))
+
+			if rangeSize.Cmp(blockInterval) == -1 {
+				rangeSize.Lsh(rangeSize, 1)
+				if rangeSize.Cmp(blockInterval) == 1 {
+					rangeSize.Set(blockInterval)
+				}
+			}
+		}
+	}
+}
+
+// Replay executes event handlers over the from-to block range again and sends resolved messages
+// in block order. Unlike ListenToEvents, it does not store processed blocks so the blockstore
+// cursor is not moved and handlers that skip replay are not executed. The range is limited to confirmed blocks and it is split by the block
+// interval and halved while the provider rejects it for being too large.
+func (l *EVMListener) Replay(ctx context.Context, from *big.Int, to *big.Int, msgChan chan *message.Message) error {
+	if from.Cmp(to) == 1 {
+		return fmt.Errorf("replay start block %s is after end block %s", from, to)
+	}
+	head, err := l.client.LatestBlock()
+	if err != nil {
+		return fmt.Errorf("unable to get latest block: %w", err)
+	}
+	confirmed, err := l.confirmedBlock(ctx, head, from)
+	if err != nil {
+		return fmt.Errorf("unable to get confirmed block: %w", err)
+	}
+	if from.Cmp(confirmed) == 1 {
+		return fmt.Errorf("replay start block %s is not confirmed yet, latest confirmed block is %s", from, confirmed)
+	}
+	if to.Cmp(confirmed) == 1 {
+		log.Warn().Uint8("DomainID", l.domainID).Msgf("Replay end block %s is not confirmed yet, replaying blocks up to %s", to, confirmed)
+		to = confirmed
+	}
+
+	blockInterval := l.maxBlockInterval()
+	rangeSize := new(big.Int).Set(blockInterval)
+	block := new(big.Int).Set(from)
+	for block.Cmp(to) != 1 {
+		endBlock := new(big.Int).Add(block, rangeSize)
+		endBlock.Sub(endBlock, big.NewInt(1))
+		if endBlock.Cmp(to) == 1 {
+			endBlock.Set(to)
+		}
+
+		log.Debug().Msgf("Replaying blocks %s-%s in listener", block, endBlock)
+		msgs, err := l.fetchEvents(ctx, block, endBlock, true)
+		if err != nil {
+			if isRangeTooLarge(err) && rangeSize.Cmp(big.NewInt(1)) == 1 {
+				rangeSize.Rsh(rangeSize, 1)
+				continue
 			}
-			block.Add(block, big.NewInt(1))
+			return fmt.Errorf("unable to replay blocks %s-%s: %w", block, endBlock, err)
+		}
+		if !l.sendMessages(ctx, msgs, msgChan) {
+			return ctx.Err()
+		}
+		block = new(big.Int).Add(endBlock, big.NewInt(1))
+	}
+	return nil
+}
+
+// confirmedBlock returns the latest block confirmed by the configured confirmation strategy.
+// Blocks are confirmed by the number of blocks mined after them if no strategy is configured.
+func (l *EVMListener) confirmedBlock(ctx context.Context, head *big.Int, block *big.Int) (*big.Int, error) {
+	var confirmed *big.Int
+	var err error
+	switch l.confirmationStrategy {
+	case chain.FinalizedConfirmation:
+		confirmed, err = l.client.FinalizedBlock(ctx)
+	case chain.SafeConfirmation:
+		confirmed, err = l.client.SafeBlock(ctx)
+	case chain.DelayConfirmation:
+		if block.Cmp(head) == 1 {
+			return new(big.Int).Set(head), nil
+		}
+		confirmed, err = l.client.LatestBlockBefore(ctx, block, head, time.Now().Add(-l.confirmationDelay))
+	default:
+		confirmed = new(big.Int).Sub(head, l.blockConfirmations)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// tagged blocks can be ahead of the head received over the subscription
+	if confirmed.Cmp(head) == 1 {
+		return new(big.Int).Set(head), nil
+	}
+	return confirmed, nil
+}
+
+// latestBlock returns the latest head received over the subscription or polls it if there is none
+func (l *EVMListener) latestBlock(sub *subscription) (*big.Int, error) {
+	if sub != nil {
+		return sub.Head(), nil
+	}
+	return l.client.LatestBlock()
+}
+
+// waitForBlocks waits for a new head if subscribed or sleeps for the block retry interval otherwise
+func (l *EVMListener) waitForBlocks(ctx context.Context, sub *subscription) {
+	if sub != nil {
+		sub.Wait(ctx)
+		return
+	}
+	time.Sleep(l.blockRetryInterval)
+}
+
+// maxBlockInterval returns configured block interval or a single block if it is not set
+func (l *EVMListener) maxBlockInterval() *big.Int {
+	if l.blockInterval == nil || l.blockInterval.Sign() < 1 {
+		return big.NewInt(1)
+	}
+	return new(big.Int).Set(l.blockInterval)
+}
+
+// fetchEvents calls all event handlers for the block range and merges their messages by block.
+// Handlers that skip replay are not called if the range is replayed.
+func (l *EVMListener) fetchEvents(ctx context.Context, startBlock *big.Int, endBlock *big.Int, replay bool) (map[uint64][]*message.Message, error) {
+	msgs := make(map[uint64][]*message.Message)
+	for _, handler := range l.eventHandlers {
+		if skipper, ok := handler.(ReplaySkipper); ok && replay && skipper.SkipsReplay() {
+			continue
+		}
+		handlerMsgs, err := handler.HandleEvents(ctx, startBlock, endBlock)
+		if err != nil {
+			return nil, err
+		}
+		for block, blockMsgs := range handlerMsgs {
+			msgs[block] = append(msgs[block], blockMsgs...)
+		}
+	}
+	return msgs, nil
+}
+
+// sendMessages sends messages in block order returning false if ctx is cancelled before they are sent
+func (l *EVMListener) sendMessages(ctx context.Context, msgs map[uint64][]*message.Message, msgChan chan *message.Message) bool {
+	blocks := make([]uint64, 0, len(msgs))
+	for block := range msgs {
+		blocks = append(blocks, block)
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+
+	for _, block := range blocks {
+		for _, m := range msgs[block] {
+			select {
+			case <-ctx.Done():
+				return false
+			case msgChan <- m:
+			}
+		}
+	}
+	return true
+}
+
+// isRangeTooLarge checks if the provider rejected event logs query because of its block range or result size.
+// Only provider messages specific to these limits are matched so that other errors retry the same range.
+func isRangeTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, limitErr := range []string{
+		"query returned more than",
+		"block range",
+		"too many results",
+		"limit exceeded",
+	} {
+		if strings.Contains(msg, limitErr) {
+			return true
 		}
 	}
+	return false
 }
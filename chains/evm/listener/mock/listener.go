@@ -0,0 +1,327 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/listener/event-handler.go
+
+// Package mock_listener is a generated GoMock package.
+package mock_listener
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	events "github.com/VaivalGithub/chainsafe-core/chains/evm/calls/events"
+	message "github.com/VaivalGithub/chainsafe-core/relayer/message"
+	store "github.com/VaivalGithub/chainsafe-core/store"
+	types "github.com/VaivalGithub/chainsafe-core/types"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEventListener is a mock of EventListener interface.
+type MockEventListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventListenerMockRecorder
+}
+
+// MockEventListenerMockRecorder is the mock recorder for MockEventListener.
+type MockEventListenerMockRecorder struct {
+	mock *MockEventListener
+}
+
+// NewMockEventListener creates a new mock instance.
+func NewMockEventListener(ctrl *gomock.Controller) *MockEventListener {
+	mock := &MockEventListener{ctrl: ctrl}
+	mock.recorder = &MockEventListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventListener) EXPECT() *MockEventListenerMockRecorder {
+	return m.recorder
+}
+
+// FetchDeposits mocks base method.
+func (m *MockEventListener) FetchDeposits(ctx context.Context, address common.Address, startBlock, endBlock *big.Int) ([]*events.Deposit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchDeposits", ctx, address, startBlock, endBlock)
+	ret0, _ := ret[0].([]*events.Deposit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchDeposits indicates an expected call of FetchDeposits.
+func (mr *MockEventListenerMockRecorder) FetchDeposits(ctx, address, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchDeposits", reflect.TypeOf((*MockEventListener)(nil).FetchDeposits), ctx, address, startBlock, endBlock)
+}
+
+// MockRegisterTokenListener is a mock of RegisterTokenListener interface.
+type MockRegisterTokenListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegisterTokenListenerMockRecorder
+}
+
+// MockRegisterTokenListenerMockRecorder is the mock recorder for MockRegisterTokenListener.
+type MockRegisterTokenListenerMockRecorder struct {
+	mock *MockRegisterTokenListener
+}
+
+// NewMockRegisterTokenListener creates a new mock instance.
+func NewMockRegisterTokenListener(ctrl *gomock.Controller) *MockRegisterTokenListener {
+	mock := &MockRegisterTokenListener{ctrl: ctrl}
+	mock.recorder = &MockRegisterTokenListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegisterTokenListener) EXPECT() *MockRegisterTokenListenerMockRecorder {
+	return m.recorder
+}
+
+// FetchRegisterTokens mocks base method.
+func (m *MockRegisterTokenListener) FetchRegisterTokens(ctx context.Context, address common.Address, startBlock, endBlock *big.Int) ([]*events.RegisterToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRegisterTokens", ctx, address, startBlock, endBlock)
+	ret0, _ := ret[0].([]*events.RegisterToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRegisterTokens indicates an expected call of FetchRegisterTokens.
+func (mr *MockRegisterTokenListenerMockRecorder) FetchRegisterTokens(ctx, address, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegisterTokens", reflect.TypeOf((*MockRegisterTokenListener)(nil).FetchRegisterTokens), ctx, address, startBlock, endBlock)
+}
+
+// MockProposalEventListener is a mock of ProposalEventListener interface.
+type MockProposalEventListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalEventListenerMockRecorder
+}
+
+// MockProposalEventListenerMockRecorder is the mock recorder for MockProposalEventListener.
+type MockProposalEventListenerMockRecorder struct {
+	mock *MockProposalEventListener
+}
+
+// NewMockProposalEventListener creates a new mock instance.
+func NewMockProposalEventListener(ctrl *gomock.Controller) *MockProposalEventListener {
+	mock := &MockProposalEventListener{ctrl: ctrl}
+	mock.recorder = &MockProposalEventListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalEventListener) EXPECT() *MockProposalEventListenerMockRecorder {
+	return m.recorder
+}
+
+// FetchProposalEvents mocks base method.
+func (m *MockProposalEventListener) FetchProposalEvents(ctx context.Context, address common.Address, startBlock, endBlock *big.Int) ([]*events.ProposalEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProposalEvents", ctx, address, startBlock, endBlock)
+	ret0, _ := ret[0].([]*events.ProposalEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProposalEvents indicates an expected call of FetchProposalEvents.
+func (mr *MockProposalEventListenerMockRecorder) FetchProposalEvents(ctx, address, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProposalEvents", reflect.TypeOf((*MockProposalEventListener)(nil).FetchProposalEvents), ctx, address, startBlock, endBlock)
+}
+
+// FetchProposalVotes mocks base method.
+func (m *MockProposalEventListener) FetchProposalVotes(ctx context.Context, address common.Address, startBlock, endBlock *big.Int) ([]*events.ProposalEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchProposalVotes", ctx, address, startBlock, endBlock)
+	ret0, _ := ret[0].([]*events.ProposalEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchProposalVotes indicates an expected call of FetchProposalVotes.
+func (mr *MockProposalEventListenerMockRecorder) FetchProposalVotes(ctx, address, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchProposalVotes", reflect.TypeOf((*MockProposalEventListener)(nil).FetchProposalVotes), ctx, address, startBlock, endBlock)
+}
+
+// MockRelayerSetListener is a mock of RelayerSetListener interface.
+type MockRelayerSetListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelayerSetListenerMockRecorder
+}
+
+// MockRelayerSetListenerMockRecorder is the mock recorder for MockRelayerSetListener.
+type MockRelayerSetListenerMockRecorder struct {
+	mock *MockRelayerSetListener
+}
+
+// NewMockRelayerSetListener creates a new mock instance.
+func NewMockRelayerSetListener(ctrl *gomock.Controller) *MockRelayerSetListener {
+	mock := &MockRelayerSetListener{ctrl: ctrl}
+	mock.recorder = &MockRelayerSetListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelayerSetListener) EXPECT() *MockRelayerSetListenerMockRecorder {
+	return m.recorder
+}
+
+// FetchRelayerChanges mocks base method.
+func (m *MockRelayerSetListener) FetchRelayerChanges(ctx context.Context, address common.Address, startBlock, endBlock *big.Int) ([]*events.RelayerChanged, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRelayerChanges", ctx, address, startBlock, endBlock)
+	ret0, _ := ret[0].([]*events.RelayerChanged)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRelayerChanges indicates an expected call of FetchRelayerChanges.
+func (mr *MockRelayerSetListenerMockRecorder) FetchRelayerChanges(ctx, address, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRelayerChanges", reflect.TypeOf((*MockRelayerSetListener)(nil).FetchRelayerChanges), ctx, address, startBlock, endBlock)
+}
+
+// FetchThresholdChanges mocks base method.
+func (m *MockRelayerSetListener) FetchThresholdChanges(ctx context.Context, address common.Address, startBlock, endBlock *big.Int) ([]*events.ThresholdChanged, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchThresholdChanges", ctx, address, startBlock, endBlock)
+	ret0, _ := ret[0].([]*events.ThresholdChanged)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchThresholdChanges indicates an expected call of FetchThresholdChanges.
+func (mr *MockRelayerSetListenerMockRecorder) FetchThresholdChanges(ctx, address, startBlock, endBlock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchThresholdChanges", reflect.TypeOf((*MockRelayerSetListener)(nil).FetchThresholdChanges), ctx, address, startBlock, endBlock)
+}
+
+// MockProposalStore is a mock of ProposalStore interface.
+type MockProposalStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockProposalStoreMockRecorder
+}
+
+// MockProposalStoreMockRecorder is the mock recorder for MockProposalStore.
+type MockProposalStoreMockRecorder struct {
+	mock *MockProposalStore
+}
+
+// NewMockProposalStore creates a new mock instance.
+func NewMockProposalStore(ctrl *gomock.Controller) *MockProposalStore {
+	mock := &MockProposalStore{ctrl: ctrl}
+	mock.recorder = &MockProposalStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProposalStore) EXPECT() *MockProposalStoreMockRecorder {
+	return m.recorder
+}
+
+// GetProposal mocks base method.
+func (m *MockProposalStore) GetProposal(domainID, source uint8, depositNonce uint64) (*store.ProposalState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProposal", domainID, source, depositNonce)
+	ret0, _ := ret[0].(*store.ProposalState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProposal indicates an expected call of GetProposal.
+func (mr *MockProposalStoreMockRecorder) GetProposal(domainID, source, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProposal", reflect.TypeOf((*MockProposalStore)(nil).GetProposal), domainID, source, depositNonce)
+}
+
+// StoreProposal mocks base method.
+func (m *MockProposalStore) StoreProposal(domainID uint8, p *store.ProposalState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreProposal", domainID, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreProposal indicates an expected call of StoreProposal.
+func (mr *MockProposalStoreMockRecorder) StoreProposal(domainID, p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreProposal", reflect.TypeOf((*MockProposalStore)(nil).StoreProposal), domainID, p)
+}
+
+// MockMessageStatusStore is a mock of MessageStatusStore interface.
+type MockMessageStatusStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageStatusStoreMockRecorder
+}
+
+// MockMessageStatusStoreMockRecorder is the mock recorder for MockMessageStatusStore.
+type MockMessageStatusStoreMockRecorder struct {
+	mock *MockMessageStatusStore
+}
+
+// NewMockMessageStatusStore creates a new mock instance.
+func NewMockMessageStatusStore(ctrl *gomock.Controller) *MockMessageStatusStore {
+	mock := &MockMessageStatusStore{ctrl: ctrl}
+	mock.recorder = &MockMessageStatusStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageStatusStore) EXPECT() *MockMessageStatusStoreMockRecorder {
+	return m.recorder
+}
+
+// StoreMessageStatus mocks base method.
+func (m_2 *MockMessageStatusStore) StoreMessageStatus(m *message.Message, status message.MessageStatus) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "StoreMessageStatus", m, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMessageStatus indicates an expected call of StoreMessageStatus.
+func (mr *MockMessageStatusStoreMockRecorder) StoreMessageStatus(m, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMessageStatus", reflect.TypeOf((*MockMessageStatusStore)(nil).StoreMessageStatus), m, status)
+}
+
+// MockDepositHandler is a mock of DepositHandler interface.
+type MockDepositHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockDepositHandlerMockRecorder
+}
+
+// MockDepositHandlerMockRecorder is the mock recorder for MockDepositHandler.
+type MockDepositHandlerMockRecorder struct {
+	mock *MockDepositHandler
+}
+
+// NewMockDepositHandler creates a new mock instance.
+func NewMockDepositHandler(ctrl *gomock.Controller) *MockDepositHandler {
+	mock := &MockDepositHandler{ctrl: ctrl}
+	mock.recorder = &MockDepositHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDepositHandler) EXPECT() *MockDepositHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleDeposit mocks base method.
+func (m *MockDepositHandler) HandleDeposit(sourceID, destID uint8, nonce uint64, resourceID types.ResourceID, calldata, handlerResponse []byte) (*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleDeposit", sourceID, destID, nonce, resourceID, calldata, handlerResponse)
+	ret0, _ := ret[0].(*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleDeposit indicates an expected call of HandleDeposit.
+func (mr *MockDepositHandlerMockRecorder) HandleDeposit(sourceID, destID, nonce, resourceID, calldata, handlerResponse interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleDeposit", reflect.TypeOf((*MockDepositHandler)(nil).HandleDeposit), sourceID, destID, nonce, resourceID, calldata, handlerResponse)
+}
@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/listener/reorg.go
+
+// Package mock_listener is a generated GoMock package.
+package mock_listener
+
+import (
+	reflect "reflect"
+
+	store "github.com/VaivalGithub/chainsafe-core/store"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHashStore is a mock of HashStore interface.
+type MockHashStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockHashStoreMockRecorder
+}
+
+// MockHashStoreMockRecorder is the mock recorder for MockHashStore.
+type MockHashStoreMockRecorder struct {
+	mock *MockHashStore
+}
+
+// NewMockHashStore creates a new mock instance.
+func NewMockHashStore(ctrl *gomock.Controller) *MockHashStore {
+	mock := &MockHashStore{ctrl: ctrl}
+	mock.recorder = &MockHashStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHashStore) EXPECT() *MockHashStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteProcessedBlock mocks base method.
+func (m *MockHashStore) DeleteProcessedBlock(domainID uint8, block uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessedBlock", domainID, block)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProcessedBlock indicates an expected call of DeleteProcessedBlock.
+func (mr *MockHashStoreMockRecorder) DeleteProcessedBlock(domainID, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessedBlock", reflect.TypeOf((*MockHashStore)(nil).DeleteProcessedBlock), domainID, block)
+}
+
+// GetProcessedBlock mocks base method.
+func (m *MockHashStore) GetProcessedBlock(domainID uint8, block uint64) (*store.ProcessedBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProcessedBlock", domainID, block)
+	ret0, _ := ret[0].(*store.ProcessedBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProcessedBlock indicates an expected call of GetProcessedBlock.
+func (mr *MockHashStoreMockRecorder) GetProcessedBlock(domainID, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcessedBlock", reflect.TypeOf((*MockHashStore)(nil).GetProcessedBlock), domainID, block)
+}
+
+// StoreProcessedBlock mocks base method.
+func (m *MockHashStore) StoreProcessedBlock(domainID uint8, block uint64, processed *store.ProcessedBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreProcessedBlock", domainID, block, processed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreProcessedBlock indicates an expected call of StoreProcessedBlock.
+func (mr *MockHashStoreMockRecorder) StoreProcessedBlock(domainID, block, processed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreProcessedBlock", reflect.TypeOf((*MockHashStore)(nil).StoreProcessedBlock), domainID, block, processed)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./chains/evm/listener/subscription.go
+
+// Package mock_listener is a generated GoMock package.
+package mock_listener
+
+import (
+	context "context"
+	reflect "reflect"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	types "github.com/ethereum/go-ethereum/core/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChainSubscriber is a mock of ChainSubscriber interface.
+type MockChainSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainSubscriberMockRecorder
+}
+
+// MockChainSubscriberMockRecorder is the mock recorder for MockChainSubscriber.
+type MockChainSubscriberMockRecorder struct {
+	mock *MockChainSubscriber
+}
+
+// NewMockChainSubscriber creates a new mock instance.
+func NewMockChainSubscriber(ctrl *gomock.Controller) *MockChainSubscriber {
+	mock := &MockChainSubscriber{ctrl: ctrl}
+	mock.recorder = &MockChainSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainSubscriber) EXPECT() *MockChainSubscriberMockRecorder {
+	return m.recorder
+}
+
+// SubscribeFilterLogs mocks base method.
+func (m *MockChainSubscriber) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeFilterLogs", ctx, q, ch)
+	ret0, _ := ret[0].(ethereum.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeFilterLogs indicates an expected call of SubscribeFilterLogs.
+func (mr *MockChainSubscriberMockRecorder) SubscribeFilterLogs(ctx, q, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeFilterLogs", reflect.TypeOf((*MockChainSubscriber)(nil).SubscribeFilterLogs), ctx, q, ch)
+}
+
+// SubscribeNewHead mocks base method.
+func (m *MockChainSubscriber) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeNewHead", ctx, ch)
+	ret0, _ := ret[0].(ethereum.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeNewHead indicates an expected call of SubscribeNewHead.
+func (mr *MockChainSubscriberMockRecorder) SubscribeNewHead(ctx, ch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeNewHead", reflect.TypeOf((*MockChainSubscriber)(nil).SubscribeNewHead), ctx, ch)
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RelayerSet caches relayer threshold and relayer role membership of a bridge.
+// It is updated from bridge events by the RelayerSetEventHandler and can be seeded
+// with values fetched from the bridge contract for relayers without known events.
+// Position of the last applied event is kept per value so older events are ignored.
+type RelayerSet struct {
+	threshold         uint8
+	thresholdKnown    bool
+	thresholdPosition *eventPosition
+	relayers          map[common.Address]bool
+	relayerPositions  map[common.Address]eventPosition
+	lock              sync.RWMutex
+}
+
+// eventPosition is the block and log index of an event
+type eventPosition struct {
+	block uint64
+	index uint
+}
+
+func (p eventPosition) after(other eventPosition) bool {
+	if p.block != other.block {
+		return p.block > other.block
+	}
+	return p.index > other.index
+}
+
+func NewRelayerSet() *RelayerSet {
+	return &RelayerSet{
+		relayers:         make(map[common.Address]bool),
+		relayerPositions: make(map[common.Address]eventPosition),
+	}
+}
+
+// Threshold returns cached relayer threshold and false if it is not known yet
+func (rs *RelayerSet) Threshold() (uint8, bool) {
+	rs.lock.RLock()
+	defer rs.lock.RUnlock()
+
+	return rs.threshold, rs.thresholdKnown
+}
+
+// SetThreshold caches current relayer threshold
+func (rs *RelayerSet) SetThreshold(threshold uint8) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.threshold = threshold
+	rs.thresholdKnown = true
+}
+
+// ApplyThresholdChange caches relayer threshold changed by the event at the block and log index.
+// It returns false and ignores the change if a later or the same event was already applied.
+func (rs *RelayerSet) ApplyThresholdChange(threshold uint8, block uint64, index uint) bool {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	position := eventPosition{block: block, index: index}
+	if rs.thresholdPosition != nil && !position.after(*rs.thresholdPosition) {
+		return false
+	}
+	rs.threshold = threshold
+	rs.thresholdKnown = true
+	rs.thresholdPosition = &position
+	return true
+}
+
+// IsRelayer returns whether the address holds the relayer role and false
+// as the second value if its membership is not known yet
+func (rs *RelayerSet) IsRelayer(relayer common.Address) (bool, bool) {
+	rs.lock.RLock()
+	defer rs.lock.RUnlock()
+
+	isRelayer, ok := rs.relayers[relayer]
+	return isRelayer, ok
+}
+
+// SetRelayer caches relayer role membership of the address
+func (rs *RelayerSet) SetRelayer(relayer common.Address, isRelayer bool) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.relayers[relayer] = isRelayer
+}
+
+// ApplyRelayerChange caches relayer role membership changed by the event at the block and log index.
+// It returns false and ignores the change if a later or the same event was already applied to the address.
+func (rs *RelayerSet) ApplyRelayerChange(relayer common.Address, isRelayer bool, block uint64, index uint) bool {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	position := eventPosition{block: block, index: index}
+	last, ok := rs.relayerPositions[relayer]
+	if ok && !position.after(last) {
+		return false
+	}
+	rs.relayers[relayer] = isRelayer
+	rs.relayerPositions[relayer] = position
+	return true
+}
+
+// Relayers returns addresses currently known to hold the relayer role
+func (rs *RelayerSet) Relayers() []common.Address {
+	rs.lock.RLock()
+	defer rs.lock.RUnlock()
+
+	relayers := make([]common.Address, 0, len(rs.relayers))
+	for relayer, isRelayer := range rs.relayers {
+		if isRelayer {
+			relayers = append(relayers, relayer)
+		}
+	}
+	return relayers
+}
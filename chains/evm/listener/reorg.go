@@ -0,0 +1,188 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rs/zerolog/log"
+)
+
+type HashStore interface {
+	StoreProcessedBlock(domainID uint8, block uint64, processed *store.ProcessedBlock) error
+	GetProcessedBlock(domainID uint8, block uint64) (*store.ProcessedBlock, error)
+	DeleteProcessedBlock(domainID uint8, block uint64) error
+}
+
+// SetHashStore enables detecting chain reorganisations by keeping hashes
+// of processed blocks up to the maximum reorg depth behind the chain head
+func (l *EVMListener) SetHashStore(hashStore HashStore) {
+	l.hashStore = hashStore
+}
+
+func (l *EVMListener) detectsReorgs() bool {
+	return l.hashStore != nil && l.maxReorgDepth > 0
+}
+
+// detectReorg checks that the last processed block before block is still canonical. If it is not,
+// stored blocks are walked back to the fork point which is returned along with deposits resolved
+// from removed blocks. Reorganisations deeper than stored blocks are rewound to the oldest of them.
+func (l *EVMListener) detectReorg(ctx context.Context, block *big.Int) (*big.Int, []store.DepositID, error) {
+	if block.Sign() < 1 {
+		return nil, nil, nil
+	}
+
+	removed := make([]store.DepositID, 0)
+	last := block.Uint64() - 1
+	for b := last; ; b-- {
+		processed, err := l.hashStore.GetProcessedBlock(l.domainID, b)
+		if err != nil {
+			return nil, nil, err
+		}
+		if processed == nil {
+			if b == last {
+				return nil, nil, nil
+			}
+			return new(big.Int).SetUint64(b), removed, nil
+		}
+
+		header, err := l.client.HeaderByNumber(ctx, new(big.Int).SetUint64(b))
+		if err != nil {
+			return nil, nil, err
+		}
+		if header.Hash() == processed.Hash {
+			if b == last {
+				return nil, nil, nil
+			}
+			return new(big.Int).SetUint64(b), removed, nil
+		}
+
+		removed = append(removed, processed.Deposits...)
+		if b == 0 {
+			return big.NewInt(0), removed, nil
+		}
+	}
+}
+
+// rewind retracts deposits of removed blocks that are not found again on the canonical chain
+// or were replaced by a deposit with different content and moves the blockstore back to the fork point.
+// Deposits that are still on the canonical chain are emitted again once the listener polls blocks after the fork point.
+func (l *EVMListener) rewind(ctx context.Context, fork *big.Int, block *big.Int, removed []store.DepositID, msgChan chan *message.Message) error {
+	startBlock := new(big.Int).Add(fork, big.NewInt(1))
+	endBlock := new(big.Int).Sub(block, big.NewInt(1))
+	canonical, err := l.fetchEvents(ctx, startBlock, endBlock, false)
+	if err != nil {
+		return err
+	}
+	// canonical deposits are found by their identifiers without data hashes so that
+	// deposits made with the same nonce but different content can be told apart
+	found := make(map[store.DepositID]common.Hash)
+	for _, msgs := range canonical {
+		for _, m := range msgs {
+			id := store.NewDepositID(m)
+			hash := id.DataHash
+			id.DataHash = common.Hash{}
+			found[id] = hash
+		}
+	}
+
+	for _, d := range removed {
+		id := d
+		id.DataHash = common.Hash{}
+		hash, ok := found[id]
+		if ok && (d.DataHash == (common.Hash{}) || d.DataHash == hash) {
+			continue
+		}
+		if ok {
+			log.Warn().Uint8("DomainID", l.domainID).Msgf("Deposit %d to %d changed by chain reorganisation", d.DepositNonce, d.Destination)
+		}
+		retraction := message.NewRetractionMessage(l.domainID, d.Destination, d.DepositNonce)
+		if d.TokenRegistration {
+			retraction = message.NewTokenRegistrationRetractionMessage(l.domainID, d.Destination, d.DepositNonce)
+		}
+		log.Warn().Uint8("DomainID", l.domainID).Msgf("Deposit %d to %d removed by chain reorganisation", d.DepositNonce, d.Destination)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msgChan <- retraction:
+		}
+	}
+
+	for b := startBlock.Uint64(); b <= endBlock.Uint64(); b++ {
+		err = l.hashStore.DeleteProcessedBlock(l.domainID, b)
+		if err != nil {
+			return err
+		}
+	}
+	return l.blockstore.StoreBlock(fork, l.domainID)
+}
+
+// fetchHeaders fetches headers of range blocks that are within the maximum reorg depth from the chain head
+func (l *EVMListener) fetchHeaders(ctx context.Context, head *big.Int, startBlock *big.Int, endBlock *big.Int) (map[uint64]*ethTypes.Header, error) {
+	headers := make(map[uint64]*ethTypes.Header)
+	from := new(big.Int).Sub(head, new(big.Int).SetUint64(l.maxReorgDepth))
+	if from.Cmp(startBlock) == -1 {
+		from.Set(startBlock)
+	}
+
+	var parent *ethTypes.Header
+	for b := from.Uint64(); b <= endBlock.Uint64(); b++ {
+		header, err := l.client.HeaderByNumber(ctx, new(big.Int).SetUint64(b))
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil && header.ParentHash != parent.Hash() {
+			return nil, fmt.Errorf("chain reorganised while fetching block %d", b)
+		}
+		headers[b] = header
+		parent = header
+	}
+	return headers, nil
+}
+
+// isCanonical checks that the last fetched header of the range is still canonical
+// so that events fetched after headers belong to the same chain
+func (l *EVMListener) isCanonical(ctx context.Context, headers map[uint64]*ethTypes.Header, endBlock *big.Int) (bool, error) {
+	last, ok := headers[endBlock.Uint64()]
+	if !ok {
+		return true, nil
+	}
+	header, err := l.client.HeaderByNumber(ctx, endBlock)
+	if err != nil {
+		return false, err
+	}
+	return header.Hash() == last.Hash(), nil
+}
+
+// storeProcessedBlocks stores hashes of fetched headers along with deposits resolved from their blocks
+// and removes blocks that fell behind the maximum reorg depth
+func (l *EVMListener) storeProcessedBlocks(headers map[uint64]*ethTypes.Header, msgs map[uint64][]*message.Message) {
+	for b, header := range headers {
+		processed := &store.ProcessedBlock{
+			Hash:     header.Hash(),
+			Deposits: make([]store.DepositID, 0, len(msgs[b])),
+		}
+		for _, m := range msgs[b] {
+			processed.Deposits = append(processed.Deposits, store.NewDepositID(m))
+		}
+		err := l.hashStore.StoreProcessedBlock(l.domainID, b, processed)
+		if err != nil {
+			log.Error().Err(err).Uint64("block", b).Msg("Failed to store processed block hash")
+		}
+
+		if b < l.maxReorgDepth {
+			continue
+		}
+		err = l.hashStore.DeleteProcessedBlock(l.domainID, b-l.maxReorgDepth)
+		if err != nil {
+			log.Error().Err(err).Uint64("block", b-l.maxReorgDepth).Msg("Failed to remove processed block hash")
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package listener_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/listener"
+	mock_listener "github.com/VaivalGithub/chainsafe-core/chains/evm/listener/mock"
+	"github.com/VaivalGithub/chainsafe-core/config/chain"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_blockstore "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReorgTestSuite struct {
+	suite.Suite
+	evmListener     *listener.EVMListener
+	mockChainClient *mock_listener.MockChainClient
+	mockHandler     *mock_listener.MockEventHandler
+	mockHashStore   *mock_listener.MockHashStore
+	mockKeyValue    *mock_blockstore.MockKeyValueReaderWriter
+	headers         map[uint64]*ethTypes.Header
+}
+
+func TestRunReorgTestSuite(t *testing.T) {
+	suite.Run(t, new(ReorgTestSuite))
+}
+
+func (s *ReorgTestSuite) SetupSuite()    {}
+func (s *ReorgTestSuite) TearDownSuite() {}
+func (s *ReorgTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockChainClient = mock_listener.NewMockChainClient(gomockController)
+	s.mockHandler = mock_listener.NewMockEventHandler(gomockController)
+	s.mockHashStore = mock_listener.NewMockHashStore(gomockController)
+	s.mockKeyValue = mock_blockstore.NewMockKeyValueReaderWriter(gomockController)
+	domainID := uint8(1)
+	s.evmListener = listener.NewEVMListener(
+		s.mockChainClient,
+		[]listener.EventHandler{s.mockHandler},
+		store.NewBlockStore(s.mockKeyValue),
+		&chain.EVMConfig{
+			GeneralChainConfig: chain.GeneralChainConfig{Id: &domainID},
+			BlockConfirmations: big.NewInt(10),
+			BlockRetryInterval: time.Millisecond,
+			BlockInterval:      big.NewInt(500),
+			MaxReorgDepth:      64,
+		},
+	)
+	s.evmListener.SetHashStore(s.mockHashStore)
+
+	s.headers = make(map[uint64]*ethTypes.Header)
+	parent := common.Hash{}
+	for b := uint64(90); b <= 110; b++ {
+		header := &ethTypes.Header{Number: new(big.Int).SetUint64(b), ParentHash: parent}
+		s.headers[b] = header
+		parent = header.Hash()
+	}
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(110), nil).AnyTimes()
+	s.mockChainClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+		return s.headers[number.Uint64()], nil
+	}).AnyTimes()
+}
+func (s *ReorgTestSuite) TearDownTest() {}
+
+// expectReorgOfBlock99 expects block 99 to be stored with a hash of a block that is no longer canonical
+func (s *ReorgTestSuite) expectReorgOfBlock99(deposits []store.DepositID) {
+	s.mockHashStore.EXPECT().GetProcessedBlock(uint8(1), uint64(99)).Return(&store.ProcessedBlock{
+		Hash:     common.HexToHash("0x99"),
+		Deposits: deposits,
+	}, nil)
+	s.mockHashStore.EXPECT().GetProcessedBlock(uint8(1), uint64(98)).Return(&store.ProcessedBlock{
+		Hash: s.headers[98].Hash(),
+	}, nil).Times(2)
+	s.mockHashStore.EXPECT().DeleteProcessedBlock(uint8(1), uint64(99)).Return(nil)
+	s.mockKeyValue.EXPECT().SetByKey([]byte("chain:1:block"), big.NewInt(98).Bytes()).Return(nil)
+}
+
+// expectProcessedRange expects blocks 99-100 to be processed with deposit to be found in block 99
+func (s *ReorgTestSuite) expectProcessedRange(deposit *message.Message, done func()) {
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(99), big.NewInt(100)).Return(map[uint64][]*message.Message{
+		99: {deposit},
+	}, nil)
+	s.mockHashStore.EXPECT().StoreProcessedBlock(uint8(1), uint64(99), &store.ProcessedBlock{
+		Hash:     s.headers[99].Hash(),
+		Deposits: []store.DepositID{store.NewDepositID(deposit)},
+	}).Return(nil)
+	s.mockHashStore.EXPECT().StoreProcessedBlock(uint8(1), uint64(100), &store.ProcessedBlock{
+		Hash:     s.headers[100].Hash(),
+		Deposits: []store.DepositID{},
+	}).Return(nil)
+	s.mockHashStore.EXPECT().DeleteProcessedBlock(uint8(1), uint64(35)).Return(nil)
+	s.mockHashStore.EXPECT().DeleteProcessedBlock(uint8(1), uint64(36)).Return(nil)
+	s.mockKeyValue.EXPECT().SetByKey([]byte("chain:1:block"), big.NewInt(100).Bytes()).DoAndReturn(func(key, value []byte) error {
+		done()
+		return nil
+	})
+}
+
+func (s *ReorgTestSuite) TestRetractsDepositsRemovedByReorg() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deposit := &message.Message{Source: 1, Destination: 2, DepositNonce: 6}
+	s.expectReorgOfBlock99([]store.DepositID{{Destination: 2, DepositNonce: 5}})
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(99), big.NewInt(99)).Return(map[uint64][]*message.Message{
+		99: {deposit},
+	}, nil)
+	s.expectProcessedRange(deposit, cancel)
+	msgChan := make(chan *message.Message, 2)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(100), msgChan, make(chan error))
+
+	s.Equal(message.NewRetractionMessage(1, 2, 5), <-msgChan)
+	s.Equal(deposit, <-msgChan)
+}
+
+func (s *ReorgTestSuite) TestRetractsTokenRegistrationWithNonceOfCanonicalDeposit() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deposit := &message.Message{Source: 1, Destination: 2, DepositNonce: 5}
+	s.expectReorgOfBlock99([]store.DepositID{{Destination: 2, DepositNonce: 5, TokenRegistration: true}})
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(99), big.NewInt(99)).Return(map[uint64][]*message.Message{
+		99: {deposit},
+	}, nil)
+	s.expectProcessedRange(deposit, cancel)
+	msgChan := make(chan *message.Message, 2)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(100), msgChan, make(chan error))
+
+	s.Equal(message.NewTokenRegistrationRetractionMessage(1, 2, 5), <-msgChan)
+	s.Equal(deposit, <-msgChan)
+}
+
+func (s *ReorgTestSuite) TestRetractsAndReemitsDepositChangedByReorg() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	removed := &message.Message{Source: 1, Destination: 2, DepositNonce: 5, Payload: []interface{}{[]byte{1}, []byte{2}}}
+	deposit := &message.Message{Source: 1, Destination: 2, DepositNonce: 5, Payload: []interface{}{[]byte{3}, []byte{2}}}
+	s.expectReorgOfBlock99([]store.DepositID{store.NewDepositID(removed)})
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(99), big.NewInt(99)).Return(map[uint64][]*message.Message{
+		99: {deposit},
+	}, nil)
+	s.expectProcessedRange(deposit, cancel)
+	msgChan := make(chan *message.Message, 2)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(100), msgChan, make(chan error))
+
+	s.Equal(message.NewRetractionMessage(1, 2, 5), <-msgChan)
+	s.Equal(deposit, <-msgChan)
+}
+
+func (s *ReorgTestSuite) TestReemitsDepositsFoundAgainAfterReorg() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deposit := &message.Message{Source: 1, Destination: 2, DepositNonce: 5}
+	s.expectReorgOfBlock99([]store.DepositID{store.NewDepositID(deposit)})
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(99), big.NewInt(99)).Return(map[uint64][]*message.Message{
+		99: {deposit},
+	}, nil)
+	s.expectProcessedRange(deposit, cancel)
+	msgChan := make(chan *message.Message, 2)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(100), msgChan, make(chan error))
+
+	s.Equal(deposit, <-msgChan)
+	s.Len(msgChan, 0)
+}
+
+func (s *ReorgTestSuite) TestReemitsDepositStoredWithoutDataHash() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deposit := &message.Message{Source: 1, Destination: 2, DepositNonce: 5}
+	s.expectReorgOfBlock99([]store.DepositID{{Destination: 2, DepositNonce: 5}})
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(99), big.NewInt(99)).Return(map[uint64][]*message.Message{
+		99: {deposit},
+	}, nil)
+	s.expectProcessedRange(deposit, cancel)
+	msgChan := make(chan *message.Message, 2)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(100), msgChan, make(chan error))
+
+	s.Equal(deposit, <-msgChan)
+	s.Len(msgChan, 0)
+}
+
+func (s *ReorgTestSuite) TestStoresProcessedBlocksWithoutReorg() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deposit := &message.Message{Source: 1, Destination: 2, DepositNonce: 5}
+	s.mockHashStore.EXPECT().GetProcessedBlock(uint8(1), uint64(98)).Return(&store.ProcessedBlock{
+		Hash: s.headers[98].Hash(),
+	}, nil)
+	s.expectProcessedRange(deposit, cancel)
+	msgChan := make(chan *message.Message, 1)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(99), msgChan, make(chan error))
+
+	s.Equal(deposit, <-msgChan)
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package listener
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// subscriptionBuffer is how many heads and logs are buffered before the subscription consumes them
+const subscriptionBuffer = 128
+
+type ChainSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *ethTypes.Header) (ethereum.Subscription, error)
+	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethTypes.Log) (ethereum.Subscription, error)
+}
+
+// SetSubscriber enables subscription mode in which the listener receives new heads and logs of
+// addresses over subscriptions instead of polling for new blocks. Block ranges without
+// subscribed logs are not queried once they are the configured subscription margin behind the
+// latest received head, giving late logs time to arrive. If subscriptions fail or miss heads
+// the listener falls back to polling and subscribes again after the block retry interval.
+func (l *EVMListener) SetSubscriber(subscriber ChainSubscriber, addresses []common.Address) {
+	l.subscriber = subscriber
+	l.addresses = addresses
+}
+
+// subscription tracks the chain head and blocks with logs received over subscriptions.
+// Logs are buffered by block until the block is confirmed and processed by the listener.
+type subscription struct {
+	lock      sync.RWMutex
+	head      *big.Int
+	since     uint64
+	margin    uint64
+	logBlocks map[uint64]bool
+	err       error
+
+	updated chan struct{}
+	done    chan struct{}
+	cancel  context.CancelFunc
+}
+
+// subscribe subscribes to new heads and logs of listener addresses
+func (l *EVMListener) subscribe(ctx context.Context) (*subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	heads := make(chan *ethTypes.Header, subscriptionBuffer)
+	headSub, err := l.subscriber.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	logs := make(chan ethTypes.Log, subscriptionBuffer)
+	logSub, err := l.subscriber.SubscribeFilterLogs(ctx, ethereum.FilterQuery{Addresses: l.addresses}, logs)
+	if err != nil {
+		headSub.Unsubscribe()
+		cancel()
+		return nil, err
+	}
+	// logs are only guaranteed to be received for blocks mined after subscribing
+	head, err := l.client.LatestBlock()
+	if err != nil {
+		headSub.Unsubscribe()
+		logSub.Unsubscribe()
+		cancel()
+		return nil, err
+	}
+
+	s := &subscription{
+		head:      head,
+		since:     head.Uint64() + 1,
+		margin:    l.subscriptionMargin,
+		logBlocks: make(map[uint64]bool),
+		updated:   make(chan struct{}, 1),
+		done:      make(chan struct{}),
+		cancel:    cancel,
+	}
+	go s.run(ctx, headSub, heads, logSub, logs)
+	return s, nil
+}
+
+func (s *subscription) run(ctx context.Context, headSub ethereum.Subscription, heads chan *ethTypes.Header, logSub ethereum.Subscription, logs chan ethTypes.Log) {
+	defer close(s.done)
+	defer logSub.Unsubscribe()
+	defer headSub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			s.fail(ctx.Err())
+			return
+		case err := <-headSub.Err():
+			s.fail(subscriptionErr("head", err))
+			return
+		case err := <-logSub.Err():
+			s.fail(subscriptionErr("log", err))
+			return
+		case header := <-heads:
+			err := s.addHead(header)
+			if err != nil {
+				s.fail(err)
+				return
+			}
+		case eventLog := <-logs:
+			s.addLog(eventLog)
+		}
+	}
+}
+
+func (s *subscription) addHead(header *ethTypes.Header) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	next := new(big.Int).Add(s.head, big.NewInt(1))
+	if header.Number.Cmp(next) == 1 {
+		return fmt.Errorf("missed heads %s-%s", next, new(big.Int).Sub(header.Number, big.NewInt(1)))
+	}
+	s.head = new(big.Int).Set(header.Number)
+	s.notify()
+	return nil
+}
+
+// addLog buffers block of the log. Blocks of removed logs are kept as well
+// so that the listener queries them again.
+func (s *subscription) addLog(eventLog ethTypes.Log) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.logBlocks[eventLog.BlockNumber] = true
+}
+
+func (s *subscription) fail(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.err = err
+}
+
+func (s *subscription) notify() {
+	select {
+	case s.updated <- struct{}{}:
+	default:
+	}
+}
+
+// Err returns the error subscriptions failed with or nil if they are active
+func (s *subscription) Err() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.err
+}
+
+// Head returns the latest received chain head
+func (s *subscription) Head() *big.Int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return new(big.Int).Set(s.head)
+}
+
+// Covers checks if no logs were received for the block range, the range is entirely
+// mined after subscribing and ends at least the margin behind the latest head, in which
+// case it does not have to be queried. Logs can arrive after the head of their block so
+// ranges close to the head are always queried.
+func (s *subscription) Covers(startBlock *big.Int, endBlock *big.Int) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if startBlock.Uint64() < s.since {
+		return false
+	}
+	if endBlock.Uint64()+s.margin > s.head.Uint64() {
+		return false
+	}
+	for block := range s.logBlocks {
+		if block >= startBlock.Uint64() && block <= endBlock.Uint64() {
+			return false
+		}
+	}
+	return true
+}
+
+// Prune removes buffered blocks up to the processed block
+func (s *subscription) Prune(block *big.Int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for b := range s.logBlocks {
+		if b <= block.Uint64() {
+			delete(s.logBlocks, b)
+		}
+	}
+}
+
+// Wait waits until a new head is received or subscriptions fail
+func (s *subscription) Wait(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-s.updated:
+	case <-s.done:
+	}
+}
+
+// Close unsubscribes and waits for subscriptions to be closed
+func (s *subscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+func subscriptionErr(name string, err error) error {
+	if err == nil {
+		err = errors.New("subscription closed")
+	}
+	return fmt.Errorf("%s subscription failed: %w", name, err)
+}
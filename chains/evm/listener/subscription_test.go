@@ -0,0 +1,199 @@
+package listener_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/evm/listener"
+	mock_listener "github.com/VaivalGithub/chainsafe-core/chains/evm/listener/mock"
+	"github.com/VaivalGithub/chainsafe-core/config/chain"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_blockstore "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type testSubscription struct {
+	errChan chan error
+}
+
+func newTestSubscription() *testSubscription {
+	return &testSubscription{errChan: make(chan error, 1)}
+}
+
+func (s *testSubscription) Unsubscribe()      {}
+func (s *testSubscription) Err() <-chan error { return s.errChan }
+
+type SubscriptionTestSuite struct {
+	suite.Suite
+	evmListener        *listener.EVMListener
+	mockChainClient    *mock_listener.MockChainClient
+	mockHandler        *mock_listener.MockEventHandler
+	mockSubscriber     *mock_listener.MockChainSubscriber
+	mockKeyValue       *mock_blockstore.MockKeyValueReaderWriter
+	bridgeAddress      common.Address
+	headSubscription   *testSubscription
+	logSubscription    *testSubscription
+	headChan           chan<- *ethTypes.Header
+	logChan            chan<- ethTypes.Log
+	subscriptionsReady chan struct{}
+}
+
+func TestRunSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(SubscriptionTestSuite))
+}
+
+func (s *SubscriptionTestSuite) SetupSuite()    {}
+func (s *SubscriptionTestSuite) TearDownSuite() {}
+func (s *SubscriptionTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockChainClient = mock_listener.NewMockChainClient(gomockController)
+	s.mockHandler = mock_listener.NewMockEventHandler(gomockController)
+	s.mockSubscriber = mock_listener.NewMockChainSubscriber(gomockController)
+	s.mockKeyValue = mock_blockstore.NewMockKeyValueReaderWriter(gomockController)
+	s.bridgeAddress = common.HexToAddress("0x1")
+	domainID := uint8(1)
+	s.evmListener = listener.NewEVMListener(
+		s.mockChainClient,
+		[]listener.EventHandler{s.mockHandler},
+		store.NewBlockStore(s.mockKeyValue),
+		&chain.EVMConfig{
+			GeneralChainConfig: chain.GeneralChainConfig{Id: &domainID},
+			BlockConfirmations: big.NewInt(10),
+			BlockRetryInterval: time.Millisecond,
+			BlockInterval:      big.NewInt(500),
+		},
+	)
+	s.evmListener.SetSubscriber(s.mockSubscriber, []common.Address{s.bridgeAddress})
+	s.headSubscription = newTestSubscription()
+	s.logSubscription = newTestSubscription()
+	s.subscriptionsReady = make(chan struct{})
+}
+func (s *SubscriptionTestSuite) TearDownTest() {}
+
+// expectSubscriptions expects listener to subscribe once at block 100 and fills heads
+// channel with provided heads before subscriptions are consumed
+func (s *SubscriptionTestSuite) expectSubscriptions(heads ...int64) {
+	s.mockSubscriber.EXPECT().SubscribeNewHead(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, ch chan<- *ethTypes.Header) (ethereum.Subscription, error) {
+		for _, head := range heads {
+			ch <- &ethTypes.Header{Number: big.NewInt(head)}
+		}
+		s.headChan = ch
+		return s.headSubscription, nil
+	})
+	s.mockSubscriber.EXPECT().SubscribeFilterLogs(gomock.Any(), ethereum.FilterQuery{Addresses: []common.Address{s.bridgeAddress}}, gomock.Any()).DoAndReturn(func(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethTypes.Log) (ethereum.Subscription, error) {
+		s.logChan = ch
+		close(s.subscriptionsReady)
+		return s.logSubscription, nil
+	})
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+}
+
+// expectStoredBlock expects block to be stored and calls done once it is
+func (s *SubscriptionTestSuite) expectStoredBlock(block int64, done func()) {
+	s.mockKeyValue.EXPECT().SetByKey([]byte("chain:1:block"), big.NewInt(block).Bytes()).DoAndReturn(func(key, value []byte) error {
+		done()
+		return nil
+	})
+}
+
+// expectPolling expects listener to fail subscribing again and poll head 111 instead
+func (s *SubscriptionTestSuite) expectPolling() {
+	s.mockSubscriber.EXPECT().SubscribeNewHead(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")).AnyTimes()
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(111), nil).AnyTimes()
+}
+
+func (s *SubscriptionTestSuite) TestSkipsBlocksWithoutSubscribedLogs() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.expectSubscriptions(101, 102, 103, 104, 105, 106, 107, 108, 109, 110, 111)
+	s.expectStoredBlock(101, cancel)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(101), make(chan *message.Message), make(chan error))
+}
+
+func (s *SubscriptionTestSuite) TestQueriesBlocksWithSubscribedLogs() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.expectSubscriptions(101, 102, 103, 104, 105, 106, 107, 108, 109, 110)
+	deposit := &message.Message{DepositNonce: 1}
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(101), big.NewInt(101)).Return(map[uint64][]*message.Message{
+		101: {deposit},
+	}, nil)
+	s.expectStoredBlock(101, cancel)
+	go func() {
+		<-s.subscriptionsReady
+		s.logChan <- ethTypes.Log{Address: s.bridgeAddress, BlockNumber: 101}
+		// log is consumed before the head that confirms its block as subscriptions are consumed sequentially
+		for len(s.logChan) > 0 {
+			time.Sleep(time.Millisecond)
+		}
+		s.headChan <- &ethTypes.Header{Number: big.NewInt(111)}
+	}()
+	msgChan := make(chan *message.Message, 1)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(101), msgChan, make(chan error))
+
+	s.Equal(deposit, <-msgChan)
+}
+
+func (s *SubscriptionTestSuite) TestQueriesBlocksWithinSubscriptionMargin() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	domainID := uint8(1)
+	s.evmListener = listener.NewEVMListener(
+		s.mockChainClient,
+		[]listener.EventHandler{s.mockHandler},
+		store.NewBlockStore(s.mockKeyValue),
+		&chain.EVMConfig{
+			GeneralChainConfig: chain.GeneralChainConfig{Id: &domainID},
+			BlockConfirmations: big.NewInt(1),
+			BlockRetryInterval: time.Millisecond,
+			BlockInterval:      big.NewInt(500),
+			SubscriptionMargin: 5,
+		},
+	)
+	s.evmListener.SetSubscriber(s.mockSubscriber, []common.Address{s.bridgeAddress})
+	// head 102 confirms block 101 before its log arrives over the subscription
+	s.expectSubscriptions(101, 102)
+	deposit := &message.Message{DepositNonce: 1}
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(101), big.NewInt(101)).Return(map[uint64][]*message.Message{
+		101: {deposit},
+	}, nil)
+	s.expectStoredBlock(101, cancel)
+	msgChan := make(chan *message.Message, 1)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(101), msgChan, make(chan error))
+
+	s.Equal(deposit, <-msgChan)
+}
+
+func (s *SubscriptionTestSuite) TestFallsBackToPollingWhenSubscriptionFails() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.expectSubscriptions()
+	s.headSubscription.errChan <- errors.New("connection closed")
+	s.expectPolling()
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(101), big.NewInt(101)).Return(map[uint64][]*message.Message{}, nil)
+	s.expectStoredBlock(101, cancel)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(101), make(chan *message.Message), make(chan error))
+}
+
+func (s *SubscriptionTestSuite) TestFallsBackToPollingOnMissedHeads() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.expectSubscriptions(101, 105)
+	s.expectPolling()
+	s.mockHandler.EXPECT().HandleEvents(gomock.Any(), big.NewInt(101), big.NewInt(101)).Return(map[uint64][]*message.Message{}, nil)
+	s.expectStoredBlock(101, cancel)
+
+	s.evmListener.ListenToEvents(ctx, big.NewInt(101), make(chan *message.Message), make(chan error))
+}
@@ -0,0 +1,274 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package memory
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Block is a block of the in-memory chain along with deposits made in it
+type Block struct {
+	Number     uint64
+	Hash       common.Hash
+	ParentHash common.Hash
+	Deposits   []*message.Message
+}
+
+// Chain is an in-memory RelayedChain used to simulate relaying without running nodes.
+// Deposits are mined into blocks that are polled once confirmed, writes are recorded
+// and can be delayed or failed and blocks can be reorganised out of the chain.
+type Chain struct {
+	domainID      uint8
+	blocks        []*Block
+	pending       []*message.Message
+	polled        uint64
+	forks         uint64
+	confirmations uint64
+	writes        []*message.Message
+	writeLatency  time.Duration
+	writeErrors   []error
+	// changed is closed and replaced whenever blocks or writes change
+	changed chan struct{}
+	lock    sync.Mutex
+}
+
+// NewChain creates in-memory chain with genesis block without deposits
+func NewChain(domainID uint8) *Chain {
+	c := &Chain{
+		domainID: domainID,
+		changed:  make(chan struct{}),
+	}
+	c.blocks = []*Block{c.newBlock(0, common.Hash{}, nil)}
+	c.polled = 1
+	return c
+}
+
+func (c *Chain) DomainID() uint8 {
+	return c.domainID
+}
+
+// Deposit adds deposit to the block that is mined next
+func (c *Chain) Deposit(m *message.Message) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.pending = append(c.pending, m)
+}
+
+// Mine mines block with pending deposits and returns it
+func (c *Chain) Mine() *Block {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	head := c.blocks[len(c.blocks)-1]
+	block := c.newBlock(head.Number+1, head.Hash, c.pending)
+	c.blocks = append(c.blocks, block)
+	c.pending = nil
+	c.notify()
+	return block
+}
+
+// Reorg removes depth blocks from the chain head and returns their deposits.
+// Blocks mined afterwards have different hashes and removed blocks that were
+// already polled are polled again once the chain grows back.
+func (c *Chain) Reorg(depth int) []*message.Message {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// genesis block is never removed
+	if depth > len(c.blocks)-1 {
+		depth = len(c.blocks) - 1
+	}
+	removed := c.blocks[len(c.blocks)-depth:]
+	c.blocks = c.blocks[:len(c.blocks)-depth]
+	c.forks++
+	if next := uint64(len(c.blocks)); c.polled > next {
+		c.polled = next
+	}
+
+	deposits := make([]*message.Message, 0)
+	for _, block := range removed {
+		deposits = append(deposits, block.Deposits...)
+	}
+	c.notify()
+	return deposits
+}
+
+// SetConfirmations sets how many blocks have to be mined on top of a block before it is polled
+func (c *Chain) SetConfirmations(confirmations uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.confirmations = confirmations
+	c.notify()
+}
+
+// Block returns block of the canonical chain by its number
+func (c *Chain) Block(number uint64) (*Block, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if number >= uint64(len(c.blocks)) {
+		return nil, false
+	}
+	return c.blocks[number], true
+}
+
+// LatestBlock returns number of the chain head
+func (c *Chain) LatestBlock() (*big.Int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return new(big.Int).SetUint64(c.blocks[len(c.blocks)-1].Number), nil
+}
+
+// PollEvents sends deposits of confirmed blocks to msgChan in the order they were made
+// until ctx is cancelled
+func (c *Chain) PollEvents(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+	for {
+		c.lock.Lock()
+		changed := c.changed
+		block, ok := c.nextConfirmedBlock()
+		c.lock.Unlock()
+
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				continue
+			}
+		}
+
+		for _, m := range block.Deposits {
+			select {
+			case <-ctx.Done():
+				return
+			case msgChan <- m:
+			}
+		}
+
+		c.lock.Lock()
+		// block could have been reorganised out while its deposits were sent
+		if c.polled == block.Number && block.Number < uint64(len(c.blocks)) && c.blocks[block.Number] == block {
+			c.polled++
+		}
+		c.lock.Unlock()
+	}
+}
+
+// nextConfirmedBlock returns the next block to poll if it is confirmed.
+// It has to be called with the lock held.
+func (c *Chain) nextConfirmedBlock() (*Block, bool) {
+	head := uint64(len(c.blocks) - 1)
+	if c.polled+c.confirmations > head {
+		return nil, false
+	}
+	return c.blocks[c.polled], true
+}
+
+// Write records the message after the write latency unless a write failure is queued
+func (c *Chain) Write(ctx context.Context, m *message.Message) error {
+	c.lock.Lock()
+	latency := c.writeLatency
+	var err error
+	if len(c.writeErrors) > 0 {
+		err = c.writeErrors[0]
+		c.writeErrors = c.writeErrors[1:]
+	}
+	c.lock.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(latency):
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.writes = append(c.writes, m)
+	c.notify()
+	return nil
+}
+
+// SetWriteLatency sets how long each write takes
+func (c *Chain) SetWriteLatency(latency time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.writeLatency = latency
+}
+
+// FailWrites fails the next writes with provided errors in order
+func (c *Chain) FailWrites(errs ...error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.writeErrors = append(c.writeErrors, errs...)
+}
+
+// Writes returns messages written to the chain in the order they were written
+func (c *Chain) Writes() []*message.Message {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	writes := make([]*message.Message, len(c.writes))
+	copy(writes, c.writes)
+	return writes
+}
+
+// WaitForWrites blocks until at least n messages are written to the chain and returns them
+func (c *Chain) WaitForWrites(ctx context.Context, n int) ([]*message.Message, error) {
+	for {
+		c.lock.Lock()
+		changed := c.changed
+		if len(c.writes) >= n {
+			writes := make([]*message.Message, len(c.writes))
+			copy(writes, c.writes)
+			c.lock.Unlock()
+			return writes, nil
+		}
+		c.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// notify wakes up everyone waiting for the chain to change. It has to be called with the lock held.
+func (c *Chain) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// newBlock creates block whose hash depends on its parent, number and the number
+// of reorgs so that blocks mined after a reorg differ from removed ones
+func (c *Chain) newBlock(number uint64, parentHash common.Hash, deposits []*message.Message) *Block {
+	header := make([]byte, 16)
+	binary.BigEndian.PutUint64(header[:8], number)
+	binary.BigEndian.PutUint64(header[8:], c.forks)
+	return &Block{
+		Number:     number,
+		Hash:       crypto.Keccak256Hash([]byte{c.domainID}, parentHash.Bytes(), header),
+		ParentHash: parentHash,
+		Deposits:   deposits,
+	}
+}
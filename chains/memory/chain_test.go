@@ -0,0 +1,112 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/memory"
+	"github.com/VaivalGithub/chainsafe-core/relayer"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/stretchr/testify/suite"
+)
+
+var _ relayer.RelayedChain = &memory.Chain{}
+
+type ChainTestSuite struct {
+	suite.Suite
+	chain *memory.Chain
+}
+
+func TestRunChainTestSuite(t *testing.T) {
+	suite.Run(t, new(ChainTestSuite))
+}
+
+func (s *ChainTestSuite) SetupSuite()    {}
+func (s *ChainTestSuite) TearDownSuite() {}
+func (s *ChainTestSuite) SetupTest() {
+	s.chain = memory.NewChain(1)
+}
+func (s *ChainTestSuite) TearDownTest() {}
+
+func (s *ChainTestSuite) poll(ctx context.Context) chan *message.Message {
+	msgChan := make(chan *message.Message)
+	go s.chain.PollEvents(ctx, make(chan error), msgChan)
+	return msgChan
+}
+
+func (s *ChainTestSuite) TestPollsMinedDeposits() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	msgChan := s.poll(ctx)
+
+	s.chain.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 1})
+	s.chain.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 2})
+	block := s.chain.Mine()
+
+	s.Equal(block.Number, uint64(1))
+	s.Equal((<-msgChan).DepositNonce, uint64(1))
+	s.Equal((<-msgChan).DepositNonce, uint64(2))
+}
+
+func (s *ChainTestSuite) TestPollsBlockOnceConfirmed() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.chain.SetConfirmations(1)
+	msgChan := s.poll(ctx)
+
+	s.chain.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 1})
+	s.chain.Mine()
+	select {
+	case <-msgChan:
+		s.Fail("unconfirmed deposit polled")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s.chain.Mine()
+	s.Equal((<-msgChan).DepositNonce, uint64(1))
+}
+
+func (s *ChainTestSuite) TestReorgRemovesBlocksAndPollsReplacements() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	msgChan := s.poll(ctx)
+	s.chain.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 1})
+	removedBlock := s.chain.Mine()
+	<-msgChan
+
+	deposits := s.chain.Reorg(1)
+	s.Equal(len(deposits), 1)
+	latest, _ := s.chain.LatestBlock()
+	s.Equal(latest, big.NewInt(0))
+
+	s.chain.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 2})
+	block := s.chain.Mine()
+	s.Equal(block.Number, removedBlock.Number)
+	s.NotEqual(block.Hash, removedBlock.Hash)
+	s.Equal((<-msgChan).DepositNonce, uint64(2))
+}
+
+func (s *ChainTestSuite) TestWriteFailsWithQueuedErrors() {
+	s.chain.FailWrites(errors.New("error"))
+
+	err := s.chain.Write(context.Background(), &message.Message{DepositNonce: 1})
+	s.NotNil(err)
+
+	err = s.chain.Write(context.Background(), &message.Message{DepositNonce: 1})
+	s.Nil(err)
+	s.Equal(len(s.chain.Writes()), 1)
+}
+
+func (s *ChainTestSuite) TestCancelledWriteIsNotRecorded() {
+	s.chain.SetWriteLatency(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.chain.Write(ctx, &message.Message{DepositNonce: 1})
+
+	s.Equal(err, context.Canceled)
+	s.Equal(len(s.chain.Writes()), 0)
+}
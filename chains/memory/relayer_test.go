@@ -0,0 +1,133 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/chains/memory"
+	"github.com/VaivalGithub/chainsafe-core/lvldb"
+	"github.com/VaivalGithub/chainsafe-core/opentelemetry"
+	"github.com/VaivalGithub/chainsafe-core/relayer"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelayerScenarioTestSuite struct {
+	suite.Suite
+	db           *lvldb.LVLDB
+	messageStore *store.MessageStore
+	source       *memory.Chain
+	destination  *memory.Chain
+}
+
+func TestRunRelayerScenarioTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayerScenarioTestSuite))
+}
+
+func (s *RelayerScenarioTestSuite) SetupSuite()    {}
+func (s *RelayerScenarioTestSuite) TearDownSuite() {}
+func (s *RelayerScenarioTestSuite) SetupTest() {
+	db, err := lvldb.NewLvlDB(s.T().TempDir())
+	s.Nil(err)
+	s.db = db
+	s.messageStore = store.NewMessageStore(db)
+	s.source = memory.NewChain(1)
+	s.destination = memory.NewChain(2)
+}
+func (s *RelayerScenarioTestSuite) TearDownTest() {
+	_ = s.db.Close()
+}
+
+// start starts relayer of the source and destination chains and returns function stopping it
+func (s *RelayerScenarioTestSuite) start(messageProcessors ...message.MessageProcessor) func() {
+	r := relayer.NewRelayer(
+		[]relayer.RelayedChain{s.source, s.destination},
+		&opentelemetry.ConsoleTelemetry{},
+		s.messageStore,
+		messageProcessors...,
+	)
+	r.RegisterRetryPolicy(2, relayer.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond})
+	// writes still running on stop are cancelled and left in the outbox
+	r.SetShutdownTimeout(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Start(ctx, make(chan error, 1))
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (s *RelayerScenarioTestSuite) waitForWrites(n int) []*message.Message {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	writes, err := s.destination.WaitForWrites(ctx, n)
+	s.Nil(err)
+	return writes
+}
+
+func (s *RelayerScenarioTestSuite) TestDepositIsWrittenToDestination() {
+	stop := s.start()
+	defer stop()
+
+	s.source.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 1})
+	s.source.Mine()
+
+	writes := s.waitForWrites(1)
+	s.Equal(writes[0].DepositNonce, uint64(1))
+}
+
+func (s *RelayerScenarioTestSuite) TestFailedWriteIsRetried() {
+	s.destination.FailWrites(errors.New("error"), errors.New("error"))
+	stop := s.start()
+	defer stop()
+
+	s.source.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 1})
+	s.source.Mine()
+
+	writes := s.waitForWrites(1)
+	s.Equal(len(writes), 1)
+}
+
+func (s *RelayerScenarioTestSuite) TestRejectedDepositIsNotWritten() {
+	stop := s.start(func(m *message.Message) error {
+		if m.DepositNonce == 1 {
+			return errors.New("rejected")
+		}
+		return nil
+	})
+	defer stop()
+
+	s.source.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 1})
+	s.source.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 2})
+	s.source.Mine()
+
+	writes := s.waitForWrites(1)
+	s.Equal(writes[0].DepositNonce, uint64(2))
+}
+
+func (s *RelayerScenarioTestSuite) TestUnwrittenDepositIsWrittenAfterRestart() {
+	s.destination.SetWriteLatency(time.Hour)
+	stop := s.start()
+	s.source.Deposit(&message.Message{Source: 1, Destination: 2, DepositNonce: 1})
+	s.source.Mine()
+	s.Eventually(func() bool {
+		msgs, _ := s.messageStore.GetMessages()
+		return len(msgs) == 1
+	}, 5*time.Second, time.Millisecond)
+	stop()
+
+	s.destination.SetWriteLatency(0)
+	stop = s.start()
+	defer stop()
+
+	writes := s.waitForWrites(1)
+	s.Equal(writes[0].DepositNonce, uint64(1))
+}
@@ -19,6 +19,15 @@ type GeneralChainConfig struct {
 	LatestBlock    bool
 	EgsApi         string `mapstructure:"egsApi"`
 	From           string `mapstructure:"from"`
+	// Retry policy for writing messages to this chain
+	RetryMaxAttempts int     `mapstructure:"retryMaxAttempts" default:"5"`
+	RetryBackoff     uint64  `mapstructure:"retryBackoff" default:"5"`
+	RetryMaxBackoff  uint64  `mapstructure:"retryMaxBackoff" default:"300"`
+	RetryJitter      float64 `mapstructure:"retryJitter" default:"0.2"`
+	// Worker pool for writing messages to this chain
+	Workers           int  `mapstructure:"workers" default:"4"`
+	QueueDepth        int  `mapstructure:"queueDepth" default:"100"`
+	UnorderedDelivery bool `mapstructure:"unorderedDelivery"`
 }
 
 func (c *GeneralChainConfig) Validate() error {
@@ -32,6 +41,19 @@ func (c *GeneralChainConfig) Validate() error {
 	if c.Name == "" {
 		return fmt.Errorf("required field chain.Name empty for chain %v", *c.Id)
 	}
+	// zero values of retry and worker pool fields are replaced with their defaults
+	if c.RetryMaxAttempts < 0 {
+		return fmt.Errorf("retryMaxAttempts has to be >=0, 0 uses the default")
+	}
+	if c.RetryJitter < 0 || c.RetryJitter > 1 {
+		return fmt.Errorf("retryJitter has to be between 0 and 1")
+	}
+	if c.Workers < 0 {
+		return fmt.Errorf("workers has to be >=0, 0 uses the default")
+	}
+	if c.QueueDepth < 0 {
+		return fmt.Errorf("queueDepth has to be >=0")
+	}
 	return nil
 }
 
@@ -4,11 +4,25 @@ import (
 	"fmt"
 	"github.com/creasty/defaults"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
 
+type ConfirmationStrategy string
+
+const (
+	// DepthConfirmation confirms blocks that are the configured number of blocks behind the chain head
+	DepthConfirmation ConfirmationStrategy = "depth"
+	// FinalizedConfirmation confirms blocks up to the block tagged as finalized
+	FinalizedConfirmation ConfirmationStrategy = "finalized"
+	// SafeConfirmation confirms blocks up to the block tagged as safe
+	SafeConfirmation ConfirmationStrategy = "safe"
+	// DelayConfirmation confirms blocks that were mined at least the configured delay ago
+	DelayConfirmation ConfirmationStrategy = "delay"
+)
+
 type EVMConfig struct {
 	GeneralChainConfig GeneralChainConfig
 	Bridge             string
@@ -21,6 +35,21 @@ type EVMConfig struct {
 	StartBlock         *big.Int
 	BlockConfirmations *big.Int
 	BlockRetryInterval time.Duration
+	// BlockInterval is the largest block range of a single event logs query
+	BlockInterval *big.Int
+	// MaxReorgDepth is how many blocks behind the chain head the listener keeps hashes of
+	// to detect chain reorganisations
+	MaxReorgDepth uint64
+	// Subscribe enables receiving new heads and bridge logs over a websocket subscription
+	// instead of polling for new blocks
+	Subscribe bool
+	// SubscriptionMargin is how many blocks behind the latest received head a block range has to
+	// end for the listener to skip querying it when no logs were received for it over the subscription
+	SubscriptionMargin uint64
+	// ConfirmationStrategy determines which blocks the listener considers confirmed
+	ConfirmationStrategy ConfirmationStrategy
+	// ConfirmationDelay is how long ago blocks have to be mined to be confirmed by the delay strategy
+	ConfirmationDelay time.Duration
 }
 
 type RawEVMConfig struct {
@@ -35,6 +64,13 @@ type RawEVMConfig struct {
 	StartBlock         int64   `mapstructure:"startBlock"`
 	BlockConfirmations int64   `mapstructure:"blockConfirmations" default:"10"`
 	BlockRetryInterval uint64  `mapstructure:"blockRetryInterval" default:"5"`
+	BlockInterval      int64   `mapstructure:"blockInterval" default:"1000"`
+	MaxReorgDepth      uint64  `mapstructure:"maxReorgDepth" default:"64"`
+	Subscribe          bool    `mapstructure:"subscribe"`
+	SubscriptionMargin uint64  `mapstructure:"subscriptionMargin" default:"5"`
+	// ConfirmationStrategy is one of depth, finalized, safe or delay
+	ConfirmationStrategy string `mapstructure:"confirmationStrategy" default:"depth"`
+	ConfirmationDelay    uint64 `mapstructure:"confirmationDelay"`
 }
 
 func (c *RawEVMConfig) Validate() error {
@@ -47,6 +83,21 @@ func (c *RawEVMConfig) Validate() error {
 	if c.BlockConfirmations != 0 && c.BlockConfirmations < 1 {
 		return fmt.Errorf("blockConfirmations has to be >=1")
 	}
+	if c.BlockInterval != 0 && c.BlockInterval < 1 {
+		return fmt.Errorf("blockInterval has to be >=1")
+	}
+	switch ConfirmationStrategy(c.ConfirmationStrategy) {
+	case DepthConfirmation, FinalizedConfirmation, SafeConfirmation:
+	case DelayConfirmation:
+		if c.ConfirmationDelay < 1 {
+			return fmt.Errorf("confirmationDelay has to be >=1 for delay confirmation strategy")
+		}
+	default:
+		return fmt.Errorf("unsupported confirmationStrategy %s", c.ConfirmationStrategy)
+	}
+	if c.Subscribe && !strings.HasPrefix(c.Endpoint, "ws") {
+		return fmt.Errorf("subscribe requires a websocket endpoint for chain %v", *c.Id)
+	}
 	return nil
 }
 
@@ -71,17 +122,23 @@ func NewEVMConfig(chainConfig map[string]interface{}) (*EVMConfig, error) {
 
 	c.GeneralChainConfig.ParseFlags()
 	config := &EVMConfig{
-		GeneralChainConfig: c.GeneralChainConfig,
-		Erc20Handler:       c.Erc20Handler,
-		Erc721Handler:      c.Erc721Handler,
-		GenericHandler:     c.GenericHandler,
-		Bridge:             c.Bridge,
-		BlockRetryInterval: time.Duration(c.BlockRetryInterval) * time.Second,
-		GasLimit:           big.NewInt(c.GasLimit),
-		MaxGasPrice:        big.NewInt(c.MaxGasPrice),
-		GasMultiplier:      big.NewFloat(c.GasMultiplier),
-		StartBlock:         big.NewInt(c.StartBlock),
-		BlockConfirmations: big.NewInt(c.BlockConfirmations),
+		GeneralChainConfig:   c.GeneralChainConfig,
+		Erc20Handler:         c.Erc20Handler,
+		Erc721Handler:        c.Erc721Handler,
+		GenericHandler:       c.GenericHandler,
+		Bridge:               c.Bridge,
+		BlockRetryInterval:   time.Duration(c.BlockRetryInterval) * time.Second,
+		GasLimit:             big.NewInt(c.GasLimit),
+		MaxGasPrice:          big.NewInt(c.MaxGasPrice),
+		GasMultiplier:        big.NewFloat(c.GasMultiplier),
+		StartBlock:           big.NewInt(c.StartBlock),
+		BlockConfirmations:   big.NewInt(c.BlockConfirmations),
+		BlockInterval:        big.NewInt(c.BlockInterval),
+		MaxReorgDepth:        c.MaxReorgDepth,
+		Subscribe:            c.Subscribe,
+		SubscriptionMargin:   c.SubscriptionMargin,
+		ConfirmationStrategy: ConfirmationStrategy(c.ConfirmationStrategy),
+		ConfirmationDelay:    time.Duration(c.ConfirmationDelay) * time.Second,
 	}
 
 	return config, nil
@@ -61,6 +61,76 @@ func (s *NewEVMConfigTestSuite) Test_InvalidBlockConfirmation() {
 	s.Equal(err.Error(), "blockConfirmations has to be >=1")
 }
 
+func (s *NewEVMConfigTestSuite) Test_InvalidBlockInterval() {
+	_, err := chain.NewEVMConfig(map[string]interface{}{
+		"id":            1,
+		"endpoint":      "ws://domain.com",
+		"name":          "evm1",
+		"from":          "address",
+		"bridge":        "bridgeAddress",
+		"blockInterval": -1,
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "blockInterval has to be >=1")
+}
+
+func (s *NewEVMConfigTestSuite) Test_SubscribeWithoutWebsocketEndpoint() {
+	_, err := chain.NewEVMConfig(map[string]interface{}{
+		"id":        1,
+		"endpoint":  "http://domain.com",
+		"name":      "evm1",
+		"from":      "address",
+		"bridge":    "bridgeAddress",
+		"subscribe": true,
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "subscribe requires a websocket endpoint for chain 1")
+}
+
+func (s *NewEVMConfigTestSuite) Test_UnsupportedConfirmationStrategy() {
+	_, err := chain.NewEVMConfig(map[string]interface{}{
+		"id":                   1,
+		"endpoint":             "ws://domain.com",
+		"name":                 "evm1",
+		"from":                 "address",
+		"bridge":               "bridgeAddress",
+		"confirmationStrategy": "latest",
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "unsupported confirmationStrategy latest")
+}
+
+func (s *NewEVMConfigTestSuite) Test_DelayConfirmationWithoutDelay() {
+	_, err := chain.NewEVMConfig(map[string]interface{}{
+		"id":                   1,
+		"endpoint":             "ws://domain.com",
+		"name":                 "evm1",
+		"from":                 "address",
+		"bridge":               "bridgeAddress",
+		"confirmationStrategy": "delay",
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "confirmationDelay has to be >=1 for delay confirmation strategy")
+}
+
+func (s *NewEVMConfigTestSuite) Test_InvalidRetryJitter() {
+	_, err := chain.NewEVMConfig(map[string]interface{}{
+		"id":          1,
+		"endpoint":    "ws://domain.com",
+		"name":        "evm1",
+		"from":        "address",
+		"bridge":      "bridgeAddress",
+		"retryJitter": 2,
+	})
+
+	s.NotNil(err)
+	s.Equal(err.Error(), "retryJitter has to be between 0 and 1")
+}
+
 func (s *NewEVMConfigTestSuite) Test_ValidConfig() {
 	rawConfig := map[string]interface{}{
 		"id":       1,
@@ -77,36 +147,53 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfig() {
 	s.Nil(err)
 	s.Equal(*actualConfig, chain.EVMConfig{
 		GeneralChainConfig: chain.GeneralChainConfig{
-			Name:     "evm1",
-			Endpoint: "ws://domain.com",
-			Id:       id,
+			Name:             "evm1",
+			Endpoint:         "ws://domain.com",
+			Id:               id,
+			From:             "address",
+			RetryMaxAttempts: 5,
+			RetryBackoff:     5,
+			RetryMaxBackoff:  300,
+			RetryJitter:      0.2,
+			Workers:          4,
+			QueueDepth:       100,
 		},
-		Bridge:             "bridgeAddress",
-		Erc20Handler:       "",
-		Erc721Handler:      "",
-		GenericHandler:     "",
-		GasLimit:           big.NewInt(2000000),
-		MaxGasPrice:        big.NewInt(20000000000),
-		GasMultiplier:      big.NewFloat(1),
-		StartBlock:         big.NewInt(0),
-		BlockConfirmations: big.NewInt(10),
-		BlockRetryInterval: time.Duration(5) * time.Second,
+		Bridge:               "bridgeAddress",
+		Erc20Handler:         "",
+		Erc721Handler:        "",
+		GenericHandler:       "",
+		GasLimit:             big.NewInt(2000000),
+		MaxGasPrice:          big.NewInt(20000000000),
+		GasMultiplier:        big.NewFloat(1),
+		StartBlock:           big.NewInt(0),
+		BlockConfirmations:   big.NewInt(10),
+		BlockRetryInterval:   time.Duration(5) * time.Second,
+		BlockInterval:        big.NewInt(1000),
+		MaxReorgDepth:        64,
+		SubscriptionMargin:   5,
+		ConfirmationStrategy: chain.DepthConfirmation,
 	})
 }
 
 func (s *NewEVMConfigTestSuite) Test_ValidConfigWithCustomTxParams() {
 	rawConfig := map[string]interface{}{
-		"id":                 1,
-		"endpoint":           "ws://domain.com",
-		"name":               "evm1",
-		"from":               "address",
-		"bridge":             "bridgeAddress",
-		"maxGasPrice":        1000,
-		"gasMultiplier":      1000,
-		"gasLimit":           1000,
-		"startBlock":         1000,
-		"blockConfirmations": 10,
-		"blockRetryInterval": 10,
+		"id":                   1,
+		"endpoint":             "ws://domain.com",
+		"name":                 "evm1",
+		"from":                 "address",
+		"bridge":               "bridgeAddress",
+		"maxGasPrice":          1000,
+		"gasMultiplier":        1000,
+		"gasLimit":             1000,
+		"startBlock":           1000,
+		"blockConfirmations":   10,
+		"blockRetryInterval":   10,
+		"blockInterval":        50,
+		"maxReorgDepth":        200,
+		"subscribe":            true,
+		"subscriptionMargin":   20,
+		"confirmationStrategy": "delay",
+		"confirmationDelay":    60,
 	}
 
 	actualConfig, err := chain.NewEVMConfig(rawConfig)
@@ -116,19 +203,32 @@ func (s *NewEVMConfigTestSuite) Test_ValidConfigWithCustomTxParams() {
 	s.Nil(err)
 	s.Equal(*actualConfig, chain.EVMConfig{
 		GeneralChainConfig: chain.GeneralChainConfig{
-			Name:     "evm1",
-			Endpoint: "ws://domain.com",
-			Id:       id,
+			Name:             "evm1",
+			Endpoint:         "ws://domain.com",
+			Id:               id,
+			From:             "address",
+			RetryMaxAttempts: 5,
+			RetryBackoff:     5,
+			RetryMaxBackoff:  300,
+			RetryJitter:      0.2,
+			Workers:          4,
+			QueueDepth:       100,
 		},
-		Bridge:             "bridgeAddress",
-		Erc20Handler:       "",
-		Erc721Handler:      "",
-		GenericHandler:     "",
-		GasLimit:           big.NewInt(1000),
-		MaxGasPrice:        big.NewInt(1000),
-		GasMultiplier:      big.NewFloat(1000),
-		StartBlock:         big.NewInt(1000),
-		BlockConfirmations: big.NewInt(10),
-		BlockRetryInterval: time.Duration(10) * time.Second,
+		Bridge:               "bridgeAddress",
+		Erc20Handler:         "",
+		Erc721Handler:        "",
+		GenericHandler:       "",
+		GasLimit:             big.NewInt(1000),
+		MaxGasPrice:          big.NewInt(1000),
+		GasMultiplier:        big.NewFloat(1000),
+		StartBlock:           big.NewInt(1000),
+		BlockConfirmations:   big.NewInt(10),
+		BlockRetryInterval:   time.Duration(10) * time.Second,
+		BlockInterval:        big.NewInt(50),
+		MaxReorgDepth:        200,
+		Subscribe:            true,
+		SubscriptionMargin:   20,
+		ConfirmationStrategy: chain.DelayConfirmation,
+		ConfirmationDelay:    time.Minute,
 	})
 }
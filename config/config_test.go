@@ -3,11 +3,14 @@ package config_test
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/VaivalGithub/chainsafe-core/config"
 	"github.com/VaivalGithub/chainsafe-core/config/relayer"
+	"github.com/VaivalGithub/chainsafe-core/types"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -82,6 +85,9 @@ func (s *GetConfigTestSuite) Test_ValidConfig() {
 			LogLevel:                  1,
 			LogFile:                   "out.log",
 			OpenTelemetryCollectorURL: "",
+			ShutdownTimeout:           30 * time.Second,
+			TransferLimits:            map[types.ResourceID]relayer.TransferLimit{},
+			MaxBlockLag:               50,
 		},
 		ChainConfigs: []map[string]interface{}{{
 			"type": "evm",
@@ -89,3 +95,228 @@ func (s *GetConfigTestSuite) Test_ValidConfig() {
 		}},
 	})
 }
+
+func (s *GetConfigTestSuite) Test_MessageProcessorsConfig() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			MessageProcessors: []relayer.MessageProcessorConfig{
+				{Name: "disabledRoutes", Params: map[string]interface{}{"routes": []interface{}{}}},
+				{Name: "amountLimits", Params: map[string]interface{}{"limits": map[string]interface{}{"0x01": map[string]interface{}{"min": "10"}}}},
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	actualConfig, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.Nil(err)
+	s.Equal(actualConfig.RelayerConfig.MessageProcessors, []relayer.MessageProcessorConfig{
+		{Name: "disabledRoutes", Params: map[string]interface{}{"routes": []interface{}{}}},
+		{Name: "amountLimits", Params: map[string]interface{}{"limits": map[string]interface{}{"0x01": map[string]interface{}{"min": "10"}}}},
+	})
+}
+
+func (s *GetConfigTestSuite) Test_MessageProcessorWithoutName() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			MessageProcessors: []relayer.MessageProcessorConfig{
+				{Params: map[string]interface{}{"min": "10"}},
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	_, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.NotNil(err)
+	s.Equal(err.Error(), "required field messageProcessors[0].name empty")
+}
+
+func (s *GetConfigTestSuite) Test_TransferLimitsConfig() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			TransferLimits: []relayer.TransferLimitConfig{
+				{ResourceID: "0x0000000000000000000000000000000000000000000000000000000000000001", MaxTransfer: "100", DailyLimit: "1000"},
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	actualConfig, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.Nil(err)
+	s.Equal(actualConfig.RelayerConfig.TransferLimits, map[types.ResourceID]relayer.TransferLimit{
+		{31: 1}: {MaxTransfer: big.NewInt(100), DailyLimit: big.NewInt(1000)},
+	})
+}
+
+func (s *GetConfigTestSuite) Test_InvalidTransferLimitAmount() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			TransferLimits: []relayer.TransferLimitConfig{
+				{ResourceID: "0x0000000000000000000000000000000000000000000000000000000000000001", MaxTransfer: "invalid"},
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	_, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.NotNil(err)
+	s.Equal(err.Error(), "invalid transferLimits amount invalid")
+}
+
+func (s *GetConfigTestSuite) Test_AdminServerWithoutToken() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel:           "info",
+			AdminServerAddress: "127.0.0.1:9002",
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	_, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.NotNil(err)
+	s.Equal(err.Error(), "required field adminToken empty while adminServerAddress is set")
+}
+
+func (s *GetConfigTestSuite) Test_ProfitabilityConfig() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			Profitability: &relayer.ProfitabilityConfig{
+				Fee: "1000",
+				Resources: []relayer.ResourceProfitabilityConfig{
+					{ResourceID: "0x0000000000000000000000000000000000000000000000000000000000000001", Rate: "0.5"},
+				},
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	actualConfig, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.Nil(err)
+	s.False(actualConfig.RelayerConfig.Profitability.Hold)
+	s.Equal(actualConfig.RelayerConfig.Profitability.DefaultRule, &relayer.ProfitabilityRule{Fee: big.NewInt(1000)})
+	s.Equal(actualConfig.RelayerConfig.Profitability.Rules[types.ResourceID{31: 1}].Rate.String(), "0.5")
+}
+
+func (s *GetConfigTestSuite) Test_InvalidProfitabilityAction() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			Profitability: &relayer.ProfitabilityConfig{
+				Action: "defer",
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	_, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.NotNil(err)
+	s.Equal(err.Error(), "invalid profitability action defer, supported actions: \"skip|hold\"")
+}
+
+func (s *GetConfigTestSuite) Test_LeaderElectionConfig() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			LeaderElection: &relayer.LeaderElectionConfig{
+				ID:       "relayer-1",
+				LockFile: "relayer.lock",
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	actualConfig, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.Nil(err)
+	s.Equal(actualConfig.RelayerConfig.LeaderElection, &relayer.LeaderElection{
+		ID:             "relayer-1",
+		LockFile:       "relayer.lock",
+		LeaseTTL:       30 * time.Second,
+		RenewInterval:  5 * time.Second,
+		TakeoverBlocks: 50,
+	})
+}
+
+func (s *GetConfigTestSuite) Test_LeaderElectionRenewIntervalNotLowerThanLeaseTTL() {
+	data := config.RawConfig{
+		RelayerConfig: relayer.RawRelayerConfig{
+			LogLevel: "info",
+			LeaderElection: &relayer.LeaderElectionConfig{
+				LockFile:      "relayer.lock",
+				LeaseTTL:      10,
+				RenewInterval: 10,
+			},
+		},
+		ChainConfigs: []map[string]interface{}{{
+			"type": "evm",
+			"name": "evm1",
+		}},
+	}
+	file, _ := json.Marshal(data)
+	_ = ioutil.WriteFile("test.json", file, 0644)
+
+	_, err := config.GetConfig("test.json")
+
+	_ = os.Remove("test.json")
+	s.NotNil(err)
+	s.Equal(err.Error(), "leaderElection renewInterval 10 has to be positive and lower than leaseTTL 10")
+}
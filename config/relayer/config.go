@@ -2,7 +2,12 @@ package relayer
 
 import (
 	"fmt"
+	"math/big"
+	"os"
+	"time"
 
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
 )
 
@@ -10,15 +15,136 @@ type RelayerConfig struct {
 	OpenTelemetryCollectorURL string
 	LogLevel                  zerolog.Level
 	LogFile                   string
+	ShutdownTimeout           time.Duration
+	MessageProcessors         []MessageProcessorConfig
+	TransferLimits            map[types.ResourceID]TransferLimit
+	// HealthServerAddress is the listen address of the health server, server is disabled if it is empty
+	HealthServerAddress string
+	// MaxBlockLag is the number of blocks a listener can fall behind chain head before the relayer is not ready
+	MaxBlockLag uint64
+	// AdminServerAddress is the listen address of the admin API, API is disabled if it is empty
+	AdminServerAddress string
+	// AdminToken authenticates admin API requests
+	AdminToken string
+	// Profitability is nil if deposits are relayed regardless of their vote cost
+	Profitability *Profitability
+	// LeaderElection is nil if the relayer runs without a standby instance
+	LeaderElection *LeaderElection
+}
+
+// TransferLimit holds parsed transfer volume caps of a resource, nil caps are not checked
+type TransferLimit struct {
+	MaxTransfer *big.Int
+	DailyLimit  *big.Int
+}
+
+// Profitability holds parsed profitability check settings
+type Profitability struct {
+	// Hold unprofitable deposits for manual approval instead of skipping them
+	Hold bool
+	// DefaultRule is used for resources without own rule, deposits of such resources are not checked if it is nil
+	DefaultRule *ProfitabilityRule
+	Rules       map[types.ResourceID]ProfitabilityRule
+}
+
+// ProfitabilityRule holds parsed relayer earnings of a resource deposit, nil earnings are not used
+type ProfitabilityRule struct {
+	Fee  *big.Int
+	Rate *big.Float
+}
+
+// LeaderElection holds parsed active/passive failover settings
+type LeaderElection struct {
+	ID             string
+	LockFile       string
+	LeaseTTL       time.Duration
+	RenewInterval  time.Duration
+	TakeoverBlocks uint64
 }
 
 type RawRelayerConfig struct {
-	OpenTelemetryCollectorURL string `mapstructure:"OpenTelemetryCollectorURL" json:"opentelemetryCollectorURL"`
-	LogLevel                  string `mapstructure:"LogLevel" json:"logLevel" default:"info"`
-	LogFile                   string `mapstructure:"LogFile" json:"logFile" default:"out.log"`
+	OpenTelemetryCollectorURL string                   `mapstructure:"OpenTelemetryCollectorURL" json:"opentelemetryCollectorURL"`
+	LogLevel                  string                   `mapstructure:"LogLevel" json:"logLevel" default:"info"`
+	LogFile                   string                   `mapstructure:"LogFile" json:"logFile" default:"out.log"`
+	ShutdownTimeout           uint64                   `mapstructure:"ShutdownTimeout" json:"shutdownTimeout" default:"30"`
+	MessageProcessors         []MessageProcessorConfig `mapstructure:"MessageProcessors" json:"messageProcessors"`
+	TransferLimits            []TransferLimitConfig    `mapstructure:"TransferLimits" json:"transferLimits"`
+	HealthServerAddress       string                   `mapstructure:"HealthServerAddress" json:"healthServerAddress"`
+	MaxBlockLag               uint64                   `mapstructure:"MaxBlockLag" json:"maxBlockLag" default:"50"`
+	AdminServerAddress        string                   `mapstructure:"AdminServerAddress" json:"adminServerAddress"`
+	AdminToken                string                   `mapstructure:"AdminToken" json:"adminToken"`
+	Profitability             *ProfitabilityConfig     `mapstructure:"Profitability" json:"profitability"`
+	LeaderElection            *LeaderElectionConfig    `mapstructure:"LeaderElection" json:"leaderElection"`
+}
+
+// MessageProcessorConfig selects built-in message processor by name.
+// Processors are applied to each message in the order they are configured.
+type MessageProcessorConfig struct {
+	Name   string                 `mapstructure:"Name" json:"name"`
+	Params map[string]interface{} `mapstructure:"Params" json:"params"`
+}
+
+// TransferLimitConfig defines volume caps of fungible transfers of a resource.
+// Amounts are decimal strings in base units of the token.
+type TransferLimitConfig struct {
+	ResourceID  string `mapstructure:"ResourceID" json:"resourceId"`
+	MaxTransfer string `mapstructure:"MaxTransfer" json:"maxTransfer"`
+	DailyLimit  string `mapstructure:"DailyLimit" json:"dailyLimit"`
+}
+
+// ProfitabilityConfig enables checking that deposits earn the relayer at least their vote cost.
+// Fees are decimal strings in wei of the destination native token.
+type ProfitabilityConfig struct {
+	// Action is taken on unprofitable deposits, either skip or hold
+	Action string `mapstructure:"Action" json:"action" default:"skip"`
+	// Fee is the flat relayer fee of deposits of resources without own config
+	Fee       string                        `mapstructure:"Fee" json:"fee"`
+	Resources []ResourceProfitabilityConfig `mapstructure:"Resources" json:"resources"`
+}
+
+// ResourceProfitabilityConfig defines relayer earnings of deposits of a resource.
+// Rate is a decimal number of native token wei a single base unit of the deposited token is worth.
+type ResourceProfitabilityConfig struct {
+	ResourceID string `mapstructure:"ResourceID" json:"resourceId"`
+	Fee        string `mapstructure:"Fee" json:"fee"`
+	Rate       string `mapstructure:"Rate" json:"rate"`
+}
+
+// LeaderElectionConfig enables failover between relayer instances sharing a key.
+// Only the instance holding the lease polls events and writes messages.
+// The lease is held in the relayer store which has to be shared by the instances
+// and support conditional writes.
+// Durations are in seconds.
+type LeaderElectionConfig struct {
+	// ID identifies the relayer instance, it defaults to the hostname
+	ID string `mapstructure:"ID" json:"id"`
+	// LockFile holds the lease in a lock file instead of the relayer store. The lock file is
+	// local so failover works only between instances on the same host and not on windows.
+	LockFile string `mapstructure:"LockFile" json:"lockFile"`
+	LeaseTTL uint64 `mapstructure:"LeaseTTL" json:"leaseTTL" default:"30"`
+	// RenewInterval is how often the leader renews the lease and the standby tries to acquire it
+	RenewInterval uint64 `mapstructure:"RenewInterval" json:"renewInterval" default:"5"`
+	// TakeoverBlocks is how many blocks behind the chain head the standby keeps its blockstore
+	TakeoverBlocks uint64 `mapstructure:"TakeoverBlocks" json:"takeoverBlocks" default:"50"`
 }
 
 func (c *RawRelayerConfig) Validate() error {
+	if c.AdminServerAddress != "" && c.AdminToken == "" {
+		return fmt.Errorf("required field adminToken empty while adminServerAddress is set")
+	}
+	if c.Profitability != nil && c.Profitability.Action != "skip" && c.Profitability.Action != "hold" {
+		return fmt.Errorf("invalid profitability action %s, supported actions: \"skip|hold\"", c.Profitability.Action)
+	}
+	if c.LeaderElection != nil {
+		if c.LeaderElection.RenewInterval == 0 || c.LeaderElection.RenewInterval >= c.LeaderElection.LeaseTTL {
+			return fmt.Errorf("leaderElection renewInterval %d has to be positive and lower than leaseTTL %d", c.LeaderElection.RenewInterval, c.LeaderElection.LeaseTTL)
+		}
+	}
+	for i, mp := range c.MessageProcessors {
+		if mp.Name == "" {
+			return fmt.Errorf("required field messageProcessors[%d].name empty", i)
+		}
+	}
 	return nil
 }
 
@@ -38,6 +164,122 @@ func NewRelayerConfig(rawConfig RawRelayerConfig) (RelayerConfig, error) {
 
 	config.LogFile = rawConfig.LogFile
 	config.OpenTelemetryCollectorURL = rawConfig.OpenTelemetryCollectorURL
+	config.ShutdownTimeout = time.Duration(rawConfig.ShutdownTimeout) * time.Second
+	config.MessageProcessors = rawConfig.MessageProcessors
+	config.HealthServerAddress = rawConfig.HealthServerAddress
+	config.MaxBlockLag = rawConfig.MaxBlockLag
+	config.AdminServerAddress = rawConfig.AdminServerAddress
+	config.AdminToken = rawConfig.AdminToken
+
+	config.TransferLimits = make(map[types.ResourceID]TransferLimit)
+	for _, limitConfig := range rawConfig.TransferLimits {
+		resourceID, limit, err := parseTransferLimit(limitConfig)
+		if err != nil {
+			return config, err
+		}
+		config.TransferLimits[resourceID] = limit
+	}
+
+	if rawConfig.Profitability != nil {
+		config.Profitability, err = parseProfitability(*rawConfig.Profitability)
+		if err != nil {
+			return config, err
+		}
+	}
+
+	if rawConfig.LeaderElection != nil {
+		config.LeaderElection, err = parseLeaderElection(*rawConfig.LeaderElection)
+		if err != nil {
+			return config, err
+		}
+	}
 
 	return config, nil
 }
+
+func parseLeaderElection(c LeaderElectionConfig) (*LeaderElection, error) {
+	id := c.ID
+	if id == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed resolving leaderElection id: %w", err)
+		}
+		id = hostname
+	}
+	return &LeaderElection{
+		ID:             id,
+		LockFile:       c.LockFile,
+		LeaseTTL:       time.Duration(c.LeaseTTL) * time.Second,
+		RenewInterval:  time.Duration(c.RenewInterval) * time.Second,
+		TakeoverBlocks: c.TakeoverBlocks,
+	}, nil
+}
+
+func parseTransferLimit(c TransferLimitConfig) (types.ResourceID, TransferLimit, error) {
+	var resourceID types.ResourceID
+	b := common.FromHex(c.ResourceID)
+	if len(b) != len(resourceID) {
+		return resourceID, TransferLimit{}, fmt.Errorf("invalid transferLimits resourceId %s", c.ResourceID)
+	}
+	copy(resourceID[:], b)
+
+	maxTransfer, err := parseAmount(c.MaxTransfer)
+	if err != nil {
+		return resourceID, TransferLimit{}, err
+	}
+	dailyLimit, err := parseAmount(c.DailyLimit)
+	if err != nil {
+		return resourceID, TransferLimit{}, err
+	}
+	return resourceID, TransferLimit{MaxTransfer: maxTransfer, DailyLimit: dailyLimit}, nil
+}
+
+func parseProfitability(c ProfitabilityConfig) (*Profitability, error) {
+	profitability := &Profitability{
+		Hold:  c.Action == "hold",
+		Rules: make(map[types.ResourceID]ProfitabilityRule),
+	}
+	fee, err := parseAmount(c.Fee)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profitability fee %s", c.Fee)
+	}
+	if fee != nil {
+		profitability.DefaultRule = &ProfitabilityRule{Fee: fee}
+	}
+
+	for _, resourceConfig := range c.Resources {
+		var resourceID types.ResourceID
+		b := common.FromHex(resourceConfig.ResourceID)
+		if len(b) != len(resourceID) {
+			return nil, fmt.Errorf("invalid profitability resourceId %s", resourceConfig.ResourceID)
+		}
+		copy(resourceID[:], b)
+
+		fee, err := parseAmount(resourceConfig.Fee)
+		if err != nil {
+			return nil, fmt.Errorf("invalid profitability fee %s", resourceConfig.Fee)
+		}
+		rule := ProfitabilityRule{Fee: fee}
+		if resourceConfig.Rate != "" {
+			rate, ok := new(big.Float).SetString(resourceConfig.Rate)
+			if !ok || rate.Sign() < 0 {
+				return nil, fmt.Errorf("invalid profitability rate %s", resourceConfig.Rate)
+			}
+			rule.Rate = rate
+		}
+		profitability.Rules[resourceID] = rule
+	}
+	return profitability, nil
+}
+
+// parseAmount parses decimal amount returning nil if it is empty
+func parseAmount(amount string) (*big.Int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	a, ok := new(big.Int).SetString(amount, 10)
+	if !ok || a.Sign() < 0 {
+		return nil, fmt.Errorf("invalid transferLimits amount %s", amount)
+	}
+	return a, nil
+}
@@ -23,12 +23,12 @@ func (gasPricer *StaticGasPriceDeterminant) GasPrice(priority *uint8) ([]*big.In
 	var gasPrice []*big.Int
 	switch *priority {
 	// medium
-	case 1:
+	case 2:
 		gasPrice = []*big.Int{big.NewInt(80000000000)}
 	// fast
-	case 2:
+	case 3:
 		gasPrice = []*big.Int{big.NewInt(140000000000)}
-	// slow
+	// slow or none
 	default:
 		gasPrice = []*big.Int{big.NewInt(50000000000)}
 	}
@@ -6,13 +6,17 @@ package app
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
 	"syscall"
+	"time"
 
 	secp256k1 "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/VaivalGithub/chainsafe-core/admin"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/contracts/bridge"
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/calls/events"
@@ -23,12 +27,19 @@ import (
 	"github.com/VaivalGithub/chainsafe-core/chains/evm/listener"
 	"github.com/VaivalGithub/chainsafe-core/config"
 	"github.com/VaivalGithub/chainsafe-core/config/chain"
+	relayerConfig "github.com/VaivalGithub/chainsafe-core/config/relayer"
 	"github.com/VaivalGithub/chainsafe-core/e2e/dummy"
 	"github.com/VaivalGithub/chainsafe-core/flags"
+	"github.com/VaivalGithub/chainsafe-core/health"
+	"github.com/VaivalGithub/chainsafe-core/leader"
 	"github.com/VaivalGithub/chainsafe-core/lvldb"
 	"github.com/VaivalGithub/chainsafe-core/opentelemetry"
 	"github.com/VaivalGithub/chainsafe-core/relayer"
+	"github.com/VaivalGithub/chainsafe-core/relayer/limits"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/relayer/profitability"
 	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/VaivalGithub/chainsafe-core/types"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 )
@@ -44,70 +55,136 @@ func Run() error {
 		panic(err)
 	}
 	blockstore := store.NewBlockStore(db)
+	messageStore := store.NewMessageStore(db)
+	hashStore := store.NewHashStore(db)
+	proposalStore := store.NewProposalStore(db)
 
-	chains := []relayer.RelayedChain{}
-	for _, chainConfig := range configuration.ChainConfigs {
-		switch chainConfig["type"] {
-		case "evm":
-			{
-				config, err := chain.NewEVMConfig(chainConfig)
-				if err != nil {
-					panic(err)
-				}
-
-				privateKey, err := secp256k1.HexToECDSA(config.GeneralChainConfig.Key)
-				if err != nil {
-					panic(err)
-				}
-
-				client, err := evmclient.NewEVMClient(config.GeneralChainConfig.Endpoint, privateKey)
-				if err != nil {
-					panic(err)
-				}
+	chainConfigs, err := newChainConfigs(configuration.ChainConfigs)
+	if err != nil {
+		panic(err)
+	}
 
-				dummyGasPricer := dummy.NewStaticGasPriceDeterminant(client, nil)
-				t := signAndSend.NewSignAndSendTransactor(evmtransaction.NewTransaction, dummyGasPricer, client)
-				bridgeContract := bridge.NewBridgeContract(client, common.HexToAddress(config.Bridge), t)
-
-				depositHandler := listener.NewETHDepositHandler(bridgeContract)
-				depositHandler.RegisterDepositHandler(config.Erc20Handler, listener.Erc20DepositHandler)
-				depositHandler.RegisterDepositHandler(config.Erc721Handler, listener.Erc721DepositHandler)
-				depositHandler.RegisterDepositHandler(config.GenericHandler, listener.GenericDepositHandler)
-				eventListener := events.NewListener(client)
-				eventHandlers := make([]listener.EventHandler, 0)
-				eventHandlers = append(eventHandlers, listener.NewDepositEventHandler(eventListener, depositHandler, common.HexToAddress(config.Bridge), *config.GeneralChainConfig.Id))
-				evmListener := listener.NewEVMListener(client, eventHandlers, blockstore, config)
-
-				mh := executor.NewEVMMessageHandler(bridgeContract)
-				mh.RegisterMessageHandler(config.Erc20Handler, executor.ERC20MessageHandler)
-				mh.RegisterMessageHandler(config.Erc721Handler, executor.ERC721MessageHandler)
-				mh.RegisterMessageHandler(config.GenericHandler, executor.GenericMessageHandler)
-
-				var evmVoter *executor.EVMVoter
-				evmVoter, err = executor.NewVoterWithSubscription(mh, client, bridgeContract)
-				if err != nil {
-					log.Error().Msgf("failed creating voter with subscription: %s. Falling back to default voter.", err.Error())
-					evmVoter = executor.NewVoter(mh, client, bridgeContract)
-				}
+	chains := []relayer.RelayedChain{}
+	evmChains := make(map[uint8]*relayedEVMChain)
+	retryPolicies := make(map[uint8]relayer.RetryPolicy)
+	poolConfigs := make(map[uint8]relayer.WorkerPoolConfig)
+	for domainID, config := range chainConfigs {
+		evmChain, err := newEVMChain(config, blockstore, hashStore, proposalStore, messageStore)
+		if err != nil {
+			panic(err)
+		}
 
-				chain := evm.NewEVMChain(evmListener, evmVoter, blockstore, config)
+		chains = append(chains, evmChain)
+		evmChains[domainID] = evmChain
+		retryPolicies[domainID] = newRetryPolicy(config.GeneralChainConfig)
+		poolConfigs[domainID] = newWorkerPoolConfig(config.GeneralChainConfig)
+	}
 
-				chains = append(chains, chain)
-			}
-		default:
-			panic(fmt.Errorf("type '%s' not recognized", chainConfig["type"]))
+	messageProcessors := make([]message.MessageProcessor, len(configuration.RelayerConfig.MessageProcessors))
+	for i, mpConfig := range configuration.RelayerConfig.MessageProcessors {
+		messageProcessors[i], err = message.NewMessageProcessor(mpConfig.Name, mpConfig.Params)
+		if err != nil {
+			panic(err)
+		}
+	}
+	var profitabilityChecker *profitability.Checker
+	if configuration.RelayerConfig.Profitability != nil {
+		profitabilityChecker = newProfitabilityChecker(configuration.RelayerConfig.Profitability)
+		for domainID, evmChain := range evmChains {
+			profitabilityChecker.RegisterEstimator(domainID, evmChain)
 		}
+		messageProcessors = append(messageProcessors, profitabilityChecker.Process)
+	}
+	var limiter *limits.TransferLimiter
+	if len(configuration.RelayerConfig.TransferLimits) > 0 {
+		// limits are checked last so that they count only transfers accepted by other processors
+		limiter = limits.NewTransferLimiter(store.NewLimitStore(db), newTransferLimits(configuration.RelayerConfig.TransferLimits))
+		messageProcessors = append(messageProcessors, limiter.Process)
 	}
 
 	r := relayer.NewRelayer(
 		chains,
 		&opentelemetry.ConsoleTelemetry{},
+		messageStore,
+		messageProcessors...,
 	)
+	if limiter != nil {
+		// transfer volume is counted once the transfer is written to the destination
+		r.RegisterObserver(limiter)
+	}
+	for domainID, policy := range retryPolicies {
+		r.RegisterRetryPolicy(domainID, policy)
+	}
+	for domainID, poolConfig := range poolConfigs {
+		r.RegisterWorkerPool(domainID, poolConfig)
+	}
+	r.SetShutdownTimeout(configuration.RelayerConfig.ShutdownTimeout)
+
+	var elector *leader.Elector
+	if configuration.RelayerConfig.LeaderElection != nil {
+		lease, err := newLease(configuration.RelayerConfig.LeaderElection, db)
+		if err != nil {
+			panic(err)
+		}
+		// relayer stays on standby until it acquires the lease
+		r.Standby()
+		elector = newElector(configuration.RelayerConfig.LeaderElection, lease, r, blockstore)
+		for domainID, evmChain := range evmChains {
+			elector.RegisterChain(domainID, evmChain.client)
+		}
+	}
+
+	checker := health.NewChecker(blockstore, r, configuration.RelayerConfig.MaxBlockLag)
+	adminAPI := admin.NewAPI(r, blockstore, configuration.RelayerConfig.AdminToken)
+	for domainID, evmChain := range evmChains {
+		checker.RegisterChain(domainID, evmChain.client)
+		adminAPI.RegisterChain(domainID, evmChain.depositEventHandler)
+	}
+	if configuration.RelayerConfig.HealthServerAddress != "" {
+		healthServer := &http.Server{
+			Addr:    configuration.RelayerConfig.HealthServerAddress,
+			Handler: checker.Handler(),
+		}
+		go func() {
+			err := healthServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				log.Error().Err(err).Msg("health server failed")
+			}
+		}()
+		defer healthServer.Close()
+	}
+	if configuration.RelayerConfig.AdminServerAddress != "" {
+		adminServer := &http.Server{
+			Addr:    configuration.RelayerConfig.AdminServerAddress,
+			Handler: adminAPI.Handler(),
+		}
+		go func() {
+			err := adminServer.ListenAndServe()
+			if err != nil && err != http.ErrServerClosed {
+				log.Error().Err(err).Msg("admin server failed")
+			}
+		}()
+		defer adminServer.Close()
+	}
 
 	errChn := make(chan error)
 	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go r.Start(ctx, errChn)
+	relayerDone := make(chan struct{})
+	go func() {
+		r.Start(ctx, errChn)
+		close(relayerDone)
+	}()
+	if elector != nil {
+		go elector.Run(ctx)
+	}
+	// relayer finishes in-flight writes before the blockstore is flushed and closed
+	defer func() {
+		cancel()
+		<-relayerDone
+		if err := db.Close(); err != nil {
+			log.Error().Err(err).Msg("failed closing blockstore")
+		}
+	}()
 
 	sysErr := make(chan os.Signal, 1)
 	signal.Notify(sysErr,
@@ -116,12 +193,237 @@ func Run() error {
 		syscall.SIGHUP,
 		syscall.SIGQUIT)
 
-	select {
-	case err := <-errChn:
-		log.Error().Err(err).Msg("failed to listen and serve")
+	for {
+		select {
+		case err := <-errChn:
+			log.Error().Err(err).Msg("failed to listen and serve")
+			return err
+		case sig := <-sysErr:
+			if sig == syscall.SIGHUP {
+				log.Info().Msg("Reloading chain configs")
+				err := reloadChains(r, checker, adminAPI, profitabilityChecker, elector, chainConfigs, blockstore, hashStore, proposalStore, messageStore)
+				if err != nil {
+					log.Error().Err(err).Msg("failed reloading chain configs")
+				}
+				continue
+			}
+			log.Info().Msgf("terminating got ` [%v] signal", sig)
+			return nil
+		}
+	}
+}
+
+// reloadChains reads chain configs again and adds, removes or restarts chains whose config changed.
+// Relayer config changes are applied only on restart.
+func reloadChains(r *relayer.Relayer, checker *health.Checker, adminAPI *admin.API, profitabilityChecker *profitability.Checker, elector *leader.Elector, running map[uint8]*chain.EVMConfig, blockstore *store.BlockStore, hashStore *store.HashStore, proposalStore *store.ProposalStore, messageStore *store.MessageStore) error {
+	configuration, err := config.GetConfig(viper.GetString(flags.ConfigFlagName))
+	if err != nil {
+		return err
+	}
+	chainConfigs, err := newChainConfigs(configuration.ChainConfigs)
+	if err != nil {
 		return err
-	case sig := <-sysErr:
-		log.Info().Msgf("terminating got ` [%v] signal", sig)
-		return nil
 	}
+
+	for domainID, runningConfig := range running {
+		newConfig, ok := chainConfigs[domainID]
+		if ok && reflect.DeepEqual(runningConfig, newConfig) {
+			continue
+		}
+		checker.UnregisterChain(domainID)
+		adminAPI.UnregisterChain(domainID)
+		if profitabilityChecker != nil {
+			profitabilityChecker.UnregisterEstimator(domainID)
+		}
+		if elector != nil {
+			elector.UnregisterChain(domainID)
+		}
+		err = r.RemoveChain(domainID)
+		if err != nil {
+			return err
+		}
+		delete(running, domainID)
+	}
+
+	for domainID, newConfig := range chainConfigs {
+		if _, ok := running[domainID]; ok {
+			continue
+		}
+		evmChain, err := newEVMChain(newConfig, blockstore, hashStore, proposalStore, messageStore)
+		if err != nil {
+			return err
+		}
+
+		r.RegisterRetryPolicy(domainID, newRetryPolicy(newConfig.GeneralChainConfig))
+		r.RegisterWorkerPool(domainID, newWorkerPoolConfig(newConfig.GeneralChainConfig))
+		err = r.AddChain(evmChain)
+		if err != nil {
+			return err
+		}
+		checker.RegisterChain(domainID, evmChain.client)
+		adminAPI.RegisterChain(domainID, evmChain.depositEventHandler)
+		if profitabilityChecker != nil {
+			profitabilityChecker.RegisterEstimator(domainID, evmChain)
+		}
+		if elector != nil {
+			elector.RegisterChain(domainID, evmChain.client)
+		}
+		running[domainID] = newConfig
+	}
+	return nil
+}
+
+// newChainConfigs parses chain configs mapping them by domain ID
+func newChainConfigs(chainConfigs []map[string]interface{}) (map[uint8]*chain.EVMConfig, error) {
+	configs := make(map[uint8]*chain.EVMConfig)
+	for _, chainConfig := range chainConfigs {
+		switch chainConfig["type"] {
+		case "evm":
+			config, err := chain.NewEVMConfig(chainConfig)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := configs[*config.GeneralChainConfig.Id]; ok {
+				return nil, fmt.Errorf("duplicate chain config with domain ID %d", *config.GeneralChainConfig.Id)
+			}
+			configs[*config.GeneralChainConfig.Id] = config
+		default:
+			return nil, fmt.Errorf("type '%s' not recognized", chainConfig["type"])
+		}
+	}
+	return configs, nil
+}
+
+// relayedEVMChain is EVM chain along with its components used by the health checks and the admin API
+type relayedEVMChain struct {
+	*evm.EVMChain
+	client              *evmclient.EVMClient
+	depositEventHandler *listener.DepositEventHandler
+}
+
+func newEVMChain(config *chain.EVMConfig, blockstore *store.BlockStore, hashStore *store.HashStore, proposalStore *store.ProposalStore, messageStore *store.MessageStore) (*relayedEVMChain, error) {
+	privateKey, err := secp256k1.HexToECDSA(config.GeneralChainConfig.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := evmclient.NewEVMClient(config.GeneralChainConfig.Endpoint, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	dummyGasPricer := dummy.NewStaticGasPriceDeterminant(client, nil)
+	t := signAndSend.NewSignAndSendTransactor(evmtransaction.NewTransaction, dummyGasPricer, client)
+	bridgeContract := bridge.NewBridgeContract(client, common.HexToAddress(config.Bridge), t)
+
+	depositHandler := listener.NewETHDepositHandler(bridgeContract)
+	depositHandler.RegisterDepositHandler(config.Erc20Handler, listener.Erc20DepositHandler)
+	depositHandler.RegisterDepositHandler(config.Erc721Handler, listener.Erc721DepositHandler)
+	depositHandler.RegisterDepositHandler(config.GenericHandler, listener.GenericDepositHandler)
+	eventListener := events.NewListener(client)
+	depositEventHandler := listener.NewDepositEventHandler(eventListener, depositHandler, common.HexToAddress(config.Bridge), *config.GeneralChainConfig.Id)
+	eventHandlers := make([]listener.EventHandler, 0)
+	eventHandlers = append(eventHandlers, depositEventHandler)
+	eventHandlers = append(eventHandlers, listener.NewRegisterTokenEventHandler(eventListener, common.HexToAddress(config.Bridge), *config.GeneralChainConfig.Id))
+	proposalEventHandler := listener.NewProposalEventHandler(eventListener, proposalStore, common.HexToAddress(config.Bridge), *config.GeneralChainConfig.Id)
+	proposalEventHandler.SetMessageStatusStore(messageStore)
+	eventHandlers = append(eventHandlers, proposalEventHandler)
+	relayerSet := listener.NewRelayerSet()
+	eventHandlers = append(eventHandlers, listener.NewRelayerSetEventHandler(eventListener, relayerSet, common.HexToAddress(config.Bridge), *config.GeneralChainConfig.Id, client.RelayerAddress()))
+	evmListener := listener.NewEVMListener(client, eventHandlers, blockstore, config)
+	evmListener.SetHashStore(hashStore)
+	if config.Subscribe {
+		evmListener.SetSubscriber(client, []common.Address{common.HexToAddress(config.Bridge)})
+	}
+
+	mh := executor.NewEVMMessageHandler(bridgeContract)
+	mh.RegisterMessageHandler(config.Erc20Handler, executor.ERC20MessageHandler)
+	mh.RegisterMessageHandler(config.Erc721Handler, executor.ERC721MessageHandler)
+	mh.RegisterMessageHandler(config.GenericHandler, executor.GenericMessageHandler)
+
+	var evmVoter *executor.EVMVoter
+	evmVoter, err = executor.NewVoterWithSubscription(mh, client, bridgeContract)
+	if err != nil {
+		log.Error().Msgf("failed creating voter with subscription: %s. Falling back to default voter.", err.Error())
+		evmVoter = executor.NewVoter(mh, client, bridgeContract)
+	}
+	evmVoter.SetProposalStore(*config.GeneralChainConfig.Id, proposalStore)
+	evmVoter.SetRelayerSet(relayerSet)
+
+	evmChain := evm.NewEVMChain(evmListener, evmVoter, blockstore, config)
+	evmChain.SetTokenRegistrar(executor.NewTokenRegistrar(client, bridgeContract))
+	evmChain.SetVoteGasEstimator(client, mh, bridgeContract)
+
+	return &relayedEVMChain{
+		EVMChain:            evmChain,
+		client:              client,
+		depositEventHandler: depositEventHandler,
+	}, nil
+}
+
+func newRetryPolicy(config chain.GeneralChainConfig) relayer.RetryPolicy {
+	return relayer.RetryPolicy{
+		MaxAttempts: config.RetryMaxAttempts,
+		Backoff:     time.Duration(config.RetryBackoff) * time.Second,
+		MaxBackoff:  time.Duration(config.RetryMaxBackoff) * time.Second,
+		Jitter:      config.RetryJitter,
+	}
+}
+
+func newWorkerPoolConfig(config chain.GeneralChainConfig) relayer.WorkerPoolConfig {
+	return relayer.WorkerPoolConfig{
+		Workers:    config.Workers,
+		QueueDepth: config.QueueDepth,
+		Ordered:    !config.UnorderedDelivery,
+	}
+}
+
+func newProfitabilityChecker(config *relayerConfig.Profitability) *profitability.Checker {
+	rules := make(map[types.ResourceID]profitability.Rule)
+	for resourceID, rule := range config.Rules {
+		rules[resourceID] = profitability.Rule{
+			Fee:  rule.Fee,
+			Rate: rule.Rate,
+		}
+	}
+	var defaultRule *profitability.Rule
+	if config.DefaultRule != nil {
+		defaultRule = &profitability.Rule{
+			Fee:  config.DefaultRule.Fee,
+			Rate: config.DefaultRule.Rate,
+		}
+	}
+	return profitability.NewChecker(rules, defaultRule, config.Hold)
+}
+
+// newLease holds the leader lease in the lock file if it is configured or in the relayer store otherwise
+func newLease(config *relayerConfig.LeaderElection, db store.KeyValueReaderWriter) (leader.Lease, error) {
+	if config.LockFile != "" {
+		return leader.NewFileLock(config.LockFile), nil
+	}
+	conditionalStore, ok := db.(store.KeyValueConditionalStore)
+	if !ok {
+		return nil, fmt.Errorf("relayer store does not support conditional writes of the leader lease, set leaderElection.lockFile to hold it in a local lock file")
+	}
+	return leader.NewStoreLease(conditionalStore, "relayer"), nil
+}
+
+func newElector(config *relayerConfig.LeaderElection, lease leader.Lease, r *relayer.Relayer, blockstore *store.BlockStore) *leader.Elector {
+	return leader.NewElector(lease, r, blockstore, leader.Config{
+		ID:             config.ID,
+		LeaseTTL:       config.LeaseTTL,
+		RenewInterval:  config.RenewInterval,
+		TakeoverBlocks: config.TakeoverBlocks,
+	})
+}
+
+func newTransferLimits(config map[types.ResourceID]relayerConfig.TransferLimit) map[types.ResourceID]limits.Limit {
+	transferLimits := make(map[types.ResourceID]limits.Limit)
+	for resourceID, limit := range config {
+		transferLimits[resourceID] = limits.Limit{
+			MaxTransfer: limit.MaxTransfer,
+			DailyLimit:  limit.DailyLimit,
+		}
+	}
+	return transferLimits
 }
@@ -0,0 +1,44 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/lvldb"
+	"github.com/VaivalGithub/chainsafe-core/store"
+)
+
+// ListDeadLetters prints messages that exhausted write retries.
+// Blockstore can't be opened while the relayer is running.
+func ListDeadLetters(blockstorePath string) error {
+	db, err := lvldb.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	msgs, err := store.NewMessageStore(db).GetDeadLetters()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		fmt.Printf("source: %d, destination: %d, nonce: %d, type: %s, resourceID: %x\n", m.Source, m.Destination, m.DepositNonce, m.Type, m.ResourceId)
+	}
+	return nil
+}
+
+// RequeueDeadLetter moves dead-lettered message back to the outbox
+// so it is relayed on the next relayer start.
+func RequeueDeadLetter(blockstorePath string, source, destination uint8, depositNonce uint64) error {
+	db, err := lvldb.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = store.NewMessageStore(db).RequeueDeadLetter(source, destination, depositNonce)
+	return err
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/lvldb"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+)
+
+// ListHeldMessages prints messages waiting for manual approval along with their decoded payload.
+// Blockstore can't be opened while the relayer is running.
+func ListHeldMessages(blockstorePath string) error {
+	db, err := lvldb.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	msgs, err := store.NewMessageStore(db).GetHeldMessages()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		fmt.Printf("source: %d, destination: %d, nonce: %d, type: %s, resourceID: %x, sender: %s, %s\n", m.Source, m.Destination, m.DepositNonce, m.Type, m.ResourceId, m.Sender.Hex(), describePayload(m))
+	}
+	return nil
+}
+
+// ApproveHeldMessage moves held message back to the outbox
+// so it is relayed on the next relayer start.
+func ApproveHeldMessage(blockstorePath string, source, destination uint8, depositNonce uint64) error {
+	db, err := lvldb.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = store.NewMessageStore(db).ApproveHeldMessage(source, destination, depositNonce)
+	return err
+}
+
+// RejectHeldMessage removes held message so that it is never relayed
+func RejectHeldMessage(blockstorePath string, source, destination uint8, depositNonce uint64) error {
+	db, err := lvldb.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = store.NewMessageStore(db).RejectHeldMessage(source, destination, depositNonce)
+	return err
+}
+
+// describePayload decodes message payload created by deposit handlers into readable form
+func describePayload(m *message.Message) string {
+	payload, err := m.TypedPayload()
+	if err != nil {
+		return fmt.Sprintf("payload: %x", m.Payload)
+	}
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Sprintf("payload: %x", m.Payload)
+	}
+	return fmt.Sprintf("payload: %s", encoded)
+}
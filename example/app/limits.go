@@ -0,0 +1,32 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/lvldb"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ResetCircuitBreaker resumes relaying transfers of the resource after its daily limit was exceeded.
+// Transfers held while the circuit breaker was tripped stay held.
+func ResetCircuitBreaker(blockstorePath string, resourceID string) error {
+	var rID types.ResourceID
+	b := common.FromHex(resourceID)
+	if len(b) != len(rID) {
+		return fmt.Errorf("invalid resource ID %s", resourceID)
+	}
+	copy(rID[:], b)
+
+	db, err := lvldb.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return store.NewLimitStore(db).StoreCircuitBreaker(rID, false)
+}
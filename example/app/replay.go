@@ -0,0 +1,90 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/config"
+	"github.com/VaivalGithub/chainsafe-core/lvldb"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+)
+
+// ReplayBlocks fetches messages of the from-to block range of the domain again and stores them
+// in the outbox so they are relayed on the next relayer start. Messages that were already processed
+// are skipped and the blockstore cursor of the domain is not moved.
+// Blockstore can't be opened while the relayer is running.
+func ReplayBlocks(configPath string, blockstorePath string, domainID uint8, from *big.Int, to *big.Int) error {
+	configuration, err := config.GetConfig(configPath)
+	if err != nil {
+		return err
+	}
+	chainConfigs, err := newChainConfigs(configuration.ChainConfigs)
+	if err != nil {
+		return err
+	}
+	chainConfig, ok := chainConfigs[domainID]
+	if !ok {
+		return fmt.Errorf("chain with domain ID %d not configured", domainID)
+	}
+
+	db, err := lvldb.NewLvlDB(blockstorePath)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	messageStore := store.NewMessageStore(db)
+	evmChain, err := newEVMChain(chainConfig, store.NewBlockStore(db), store.NewHashStore(db), store.NewProposalStore(db), messageStore)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	msgChan := make(chan *message.Message)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- evmChain.Replay(ctx, from, to, msgChan)
+	}()
+
+	for {
+		select {
+		case m := <-msgChan:
+			err := storeReplayedMessage(messageStore, m)
+			if err != nil {
+				return err
+			}
+		case err := <-errChan:
+			return err
+		}
+	}
+}
+
+// storeReplayedMessage stores message in the outbox unless it already has a status.
+// Deposits retracted by a chain reorganisation are stored again as the relayer does when they are re-emitted.
+func storeReplayedMessage(messageStore *store.MessageStore, m *message.Message) error {
+	status, err := messageStore.GetMessageStatus(m)
+	if err != nil {
+		return err
+	}
+	if status != message.MessageStatusUnknown && status != message.MessageStatusRetracted {
+		fmt.Printf("skipping source: %d, destination: %d, nonce: %d, status: %s\n", m.Source, m.Destination, m.DepositNonce, message.MessageStatusMap[status])
+		return nil
+	}
+
+	err = messageStore.StoreMessage(m)
+	if err != nil {
+		return err
+	}
+	err = messageStore.StoreMessageStatus(m, message.MessageStatusReceived)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("replayed source: %d, destination: %d, nonce: %d, type: %s, resourceID: %x\n", m.Source, m.Destination, m.DepositNonce, m.Type, m.ResourceId)
+	return nil
+}
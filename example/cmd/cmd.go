@@ -34,7 +34,7 @@ func init() {
 }
 
 func Execute() {
-	rootCMD.AddCommand(runCMD, evmCLI.EvmRootCLI, local.LocalSetupCmd)
+	rootCMD.AddCommand(runCMD, deadLettersCMD, heldCMD, circuitBreakerCMD, replayCMD, evmCLI.EvmRootCLI, local.LocalSetupCmd)
 	if err := rootCMD.Execute(); err != nil {
 		log.Fatal().Err(err).Msg("failed to execute root cmd")
 	}
@@ -0,0 +1,61 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cmd
+
+import (
+	"github.com/VaivalGithub/chainsafe-core/example/app"
+	"github.com/VaivalGithub/chainsafe-core/flags"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deadLettersCMD = &cobra.Command{
+		Use:   "dead-letters",
+		Short: "Manage messages that exhausted write retries",
+		Long:  "Manage messages that exhausted write retries. Relayer has to be stopped as blockstore can be opened only by a single process",
+	}
+	listDeadLettersCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List dead-lettered messages",
+		Long:  "List dead-lettered messages",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockstore, err := cmd.Flags().GetString(flags.BlockstoreFlagName)
+			if err != nil {
+				return err
+			}
+			return app.ListDeadLetters(blockstore)
+		},
+	}
+	requeueDeadLetterCMD = &cobra.Command{
+		Use:   "requeue",
+		Short: "Requeue dead-lettered message",
+		Long:  "Move dead-lettered message back to the outbox so it is relayed on the next relayer start",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockstore, err := cmd.Flags().GetString(flags.BlockstoreFlagName)
+			if err != nil {
+				return err
+			}
+			return app.RequeueDeadLetter(blockstore, source, destination, depositNonce)
+		},
+	}
+)
+
+var (
+	source       uint8
+	destination  uint8
+	depositNonce uint64
+)
+
+func init() {
+	deadLettersCMD.PersistentFlags().String(flags.BlockstoreFlagName, "./lvldbdata", "Specify path for blockstore")
+
+	requeueDeadLetterCMD.Flags().Uint8Var(&source, "source", 0, "Source domain ID of the message")
+	requeueDeadLetterCMD.Flags().Uint8Var(&destination, "destination", 0, "Destination domain ID of the message")
+	requeueDeadLetterCMD.Flags().Uint64Var(&depositNonce, "nonce", 0, "Deposit nonce of the message")
+	_ = requeueDeadLetterCMD.MarkFlagRequired("source")
+	_ = requeueDeadLetterCMD.MarkFlagRequired("destination")
+	_ = requeueDeadLetterCMD.MarkFlagRequired("nonce")
+
+	deadLettersCMD.AddCommand(listDeadLettersCMD, requeueDeadLetterCMD)
+}
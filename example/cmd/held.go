@@ -0,0 +1,69 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cmd
+
+import (
+	"github.com/VaivalGithub/chainsafe-core/example/app"
+	"github.com/VaivalGithub/chainsafe-core/flags"
+	"github.com/spf13/cobra"
+)
+
+var (
+	heldCMD = &cobra.Command{
+		Use:   "held",
+		Short: "Manage messages held for manual approval",
+		Long:  "Manage messages held for manual approval. Relayer has to be stopped as blockstore can be opened only by a single process",
+	}
+	listHeldCMD = &cobra.Command{
+		Use:   "list",
+		Short: "List held messages",
+		Long:  "List held messages with their decoded payload",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockstore, err := cmd.Flags().GetString(flags.BlockstoreFlagName)
+			if err != nil {
+				return err
+			}
+			return app.ListHeldMessages(blockstore)
+		},
+	}
+	approveHeldCMD = &cobra.Command{
+		Use:   "approve",
+		Short: "Approve held message",
+		Long:  "Move held message back to the outbox so it is relayed on the next relayer start",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockstore, err := cmd.Flags().GetString(flags.BlockstoreFlagName)
+			if err != nil {
+				return err
+			}
+			return app.ApproveHeldMessage(blockstore, source, destination, depositNonce)
+		},
+	}
+	rejectHeldCMD = &cobra.Command{
+		Use:   "reject",
+		Short: "Reject held message",
+		Long:  "Remove held message so that it is never relayed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockstore, err := cmd.Flags().GetString(flags.BlockstoreFlagName)
+			if err != nil {
+				return err
+			}
+			return app.RejectHeldMessage(blockstore, source, destination, depositNonce)
+		},
+	}
+)
+
+func init() {
+	heldCMD.PersistentFlags().String(flags.BlockstoreFlagName, "./lvldbdata", "Specify path for blockstore")
+
+	for _, c := range []*cobra.Command{approveHeldCMD, rejectHeldCMD} {
+		c.Flags().Uint8Var(&source, "source", 0, "Source domain ID of the message")
+		c.Flags().Uint8Var(&destination, "destination", 0, "Destination domain ID of the message")
+		c.Flags().Uint64Var(&depositNonce, "nonce", 0, "Deposit nonce of the message")
+		_ = c.MarkFlagRequired("source")
+		_ = c.MarkFlagRequired("destination")
+		_ = c.MarkFlagRequired("nonce")
+	}
+
+	heldCMD.AddCommand(listHeldCMD, approveHeldCMD, rejectHeldCMD)
+}
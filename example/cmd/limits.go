@@ -0,0 +1,43 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cmd
+
+import (
+	"github.com/VaivalGithub/chainsafe-core/example/app"
+	"github.com/VaivalGithub/chainsafe-core/flags"
+	"github.com/spf13/cobra"
+)
+
+var (
+	circuitBreakerCMD = &cobra.Command{
+		Use:   "circuit-breaker",
+		Short: "Manage transfer limit circuit breakers",
+		Long:  "Manage transfer limit circuit breakers. Relayer has to be stopped as blockstore can be opened only by a single process",
+	}
+	resetCircuitBreakerCMD = &cobra.Command{
+		Use:   "reset",
+		Short: "Reset circuit breaker of a resource",
+		Long:  "Reset tripped circuit breaker of a resource so that its transfers are relayed again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			blockstore, err := cmd.Flags().GetString(flags.BlockstoreFlagName)
+			if err != nil {
+				return err
+			}
+			return app.ResetCircuitBreaker(blockstore, resourceID)
+		},
+	}
+)
+
+var (
+	resourceID string
+)
+
+func init() {
+	circuitBreakerCMD.PersistentFlags().String(flags.BlockstoreFlagName, "./lvldbdata", "Specify path for blockstore")
+
+	resetCircuitBreakerCMD.Flags().StringVar(&resourceID, "resource", "", "Resource ID of the circuit breaker")
+	_ = resetCircuitBreakerCMD.MarkFlagRequired("resource")
+
+	circuitBreakerCMD.AddCommand(resetCircuitBreakerCMD)
+}
@@ -0,0 +1,48 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cmd
+
+import (
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/example/app"
+	"github.com/VaivalGithub/chainsafe-core/flags"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replayCMD = &cobra.Command{
+		Use:   "replay",
+		Short: "Replay historic block range",
+		Long:  "Fetch messages of the block range of the domain again and store them in the outbox so they are relayed on the next relayer start. Blockstore cursor is not moved. Relayer has to be stopped as blockstore can be opened only by a single process",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := cmd.Flags().GetString(flags.ConfigFlagName)
+			if err != nil {
+				return err
+			}
+			blockstore, err := cmd.Flags().GetString(flags.BlockstoreFlagName)
+			if err != nil {
+				return err
+			}
+			return app.ReplayBlocks(config, blockstore, domainID, new(big.Int).SetUint64(fromBlock), new(big.Int).SetUint64(toBlock))
+		},
+	}
+)
+
+var (
+	domainID  uint8
+	fromBlock uint64
+	toBlock   uint64
+)
+
+func init() {
+	replayCMD.Flags().String(flags.ConfigFlagName, ".", "Path to JSON configuration file")
+	replayCMD.Flags().String(flags.BlockstoreFlagName, "./lvldbdata", "Specify path for blockstore")
+	replayCMD.Flags().Uint8Var(&domainID, "domain", 0, "Domain ID of the chain to replay")
+	replayCMD.Flags().Uint64Var(&fromBlock, "from", 0, "First block of the replayed range")
+	replayCMD.Flags().Uint64Var(&toBlock, "to", 0, "Last block of the replayed range")
+	_ = replayCMD.MarkFlagRequired("domain")
+	_ = replayCMD.MarkFlagRequired("from")
+	_ = replayCMD.MarkFlagRequired("to")
+}
@@ -0,0 +1,164 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package health
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ChainClient interface {
+	LatestBlock() (*big.Int, error)
+}
+
+type BlockStore interface {
+	GetLastStoredBlock(domainID uint8) (*big.Int, error)
+}
+
+type RelayerStatus interface {
+	LastWrite(domainID uint8) time.Time
+	QueueDepth(domainID uint8) int
+}
+
+// ChainStatus reports progress of relaying a single chain
+type ChainStatus struct {
+	DomainID        uint8      `json:"domainId"`
+	RPCReachable    bool       `json:"rpcReachable"`
+	Error           string     `json:"error,omitempty"`
+	LatestBlock     *big.Int   `json:"latestBlock,omitempty"`
+	LastStoredBlock *big.Int   `json:"lastStoredBlock,omitempty"`
+	BlocksBehind    uint64     `json:"blocksBehind"`
+	LastVote        *time.Time `json:"lastVote,omitempty"`
+	QueueDepth      int        `json:"queueDepth"`
+	Ready           bool       `json:"ready"`
+}
+
+// Status reports progress of all relayed chains
+type Status struct {
+	Ready  bool          `json:"ready"`
+	Chains []ChainStatus `json:"chains"`
+}
+
+// Checker checks progress of registered chains. Chain is ready if its RPC is reachable
+// and its listener is at most maxBlockLag blocks behind the chain head.
+type Checker struct {
+	blockstore  BlockStore
+	relayer     RelayerStatus
+	maxBlockLag uint64
+	clients     map[uint8]ChainClient
+	lock        sync.RWMutex
+}
+
+func NewChecker(blockstore BlockStore, relayer RelayerStatus, maxBlockLag uint64) *Checker {
+	return &Checker{
+		blockstore:  blockstore,
+		relayer:     relayer,
+		maxBlockLag: maxBlockLag,
+		clients:     make(map[uint8]ChainClient),
+	}
+}
+
+// RegisterChain adds chain to health checks
+func (c *Checker) RegisterChain(domainID uint8, client ChainClient) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.clients[domainID] = client
+}
+
+// UnregisterChain removes chain from health checks
+func (c *Checker) UnregisterChain(domainID uint8) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.clients, domainID)
+}
+
+// Status checks all registered chains ordered by domain ID
+func (c *Checker) Status() Status {
+	c.lock.RLock()
+	clients := make(map[uint8]ChainClient, len(c.clients))
+	for domainID, client := range c.clients {
+		clients[domainID] = client
+	}
+	c.lock.RUnlock()
+
+	status := Status{
+		Ready:  true,
+		Chains: make([]ChainStatus, 0, len(clients)),
+	}
+	for domainID, client := range clients {
+		chainStatus := c.chainStatus(domainID, client)
+		status.Ready = status.Ready && chainStatus.Ready
+		status.Chains = append(status.Chains, chainStatus)
+	}
+	sort.Slice(status.Chains, func(i, j int) bool {
+		return status.Chains[i].DomainID < status.Chains[j].DomainID
+	})
+	return status
+}
+
+func (c *Checker) chainStatus(domainID uint8, client ChainClient) ChainStatus {
+	status := ChainStatus{
+		DomainID:   domainID,
+		QueueDepth: c.relayer.QueueDepth(domainID),
+	}
+	if lastWrite := c.relayer.LastWrite(domainID); !lastWrite.IsZero() {
+		status.LastVote = &lastWrite
+	}
+
+	latestBlock, err := client.LatestBlock()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.RPCReachable = true
+	status.LatestBlock = latestBlock
+
+	lastStoredBlock, err := c.blockstore.GetLastStoredBlock(domainID)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.LastStoredBlock = lastStoredBlock
+
+	if latestBlock.Cmp(lastStoredBlock) > 0 {
+		status.BlocksBehind = new(big.Int).Sub(latestBlock, lastStoredBlock).Uint64()
+	}
+	status.Ready = status.BlocksBehind <= c.maxBlockLag
+	return status
+}
+
+// Handler serves chain status on /healthz and /readyz. Liveness check on /healthz always
+// succeeds while the process is serving, readiness check on /readyz fails if any chain is not ready.
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK, c.Status())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		status := c.Status()
+		code := http.StatusOK
+		if !status.Ready {
+			code = http.StatusServiceUnavailable
+		}
+		writeStatus(w, code, status)
+	})
+	return mux
+}
+
+func writeStatus(w http.ResponseWriter, code int, status Status) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	err := json.NewEncoder(w).Encode(status)
+	if err != nil {
+		log.Error().Err(err).Msg("failed writing health status")
+	}
+}
@@ -0,0 +1,114 @@
+package health_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/health"
+	mock_health "github.com/VaivalGithub/chainsafe-core/health/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckerTestSuite struct {
+	suite.Suite
+	checker           *health.Checker
+	mockChainClient   *mock_health.MockChainClient
+	mockBlockStore    *mock_health.MockBlockStore
+	mockRelayerStatus *mock_health.MockRelayerStatus
+}
+
+func TestRunCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckerTestSuite))
+}
+
+func (s *CheckerTestSuite) SetupSuite()    {}
+func (s *CheckerTestSuite) TearDownSuite() {}
+func (s *CheckerTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockChainClient = mock_health.NewMockChainClient(gomockController)
+	s.mockBlockStore = mock_health.NewMockBlockStore(gomockController)
+	s.mockRelayerStatus = mock_health.NewMockRelayerStatus(gomockController)
+	s.checker = health.NewChecker(s.mockBlockStore, s.mockRelayerStatus, 10)
+	s.checker.RegisterChain(1, s.mockChainClient)
+}
+func (s *CheckerTestSuite) TearDownTest() {}
+
+func (s *CheckerTestSuite) get(path string) (int, health.Status) {
+	recorder := httptest.NewRecorder()
+	s.checker.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var status health.Status
+	err := json.NewDecoder(recorder.Body).Decode(&status)
+	s.Nil(err)
+	return recorder.Code, status
+}
+
+func (s *CheckerTestSuite) TestReadyIfListenerWithinMaxBlockLag() {
+	lastWrite := time.Unix(1000, 0)
+	s.mockRelayerStatus.EXPECT().QueueDepth(uint8(1)).Return(3)
+	s.mockRelayerStatus.EXPECT().LastWrite(uint8(1)).Return(lastWrite)
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(110), nil)
+	s.mockBlockStore.EXPECT().GetLastStoredBlock(uint8(1)).Return(big.NewInt(100), nil)
+
+	code, status := s.get("/readyz")
+
+	s.Equal(http.StatusOK, code)
+	s.True(status.Ready)
+	s.Equal(1, len(status.Chains))
+	s.True(status.Chains[0].RPCReachable)
+	s.Equal(uint64(10), status.Chains[0].BlocksBehind)
+	s.Equal(3, status.Chains[0].QueueDepth)
+	s.True(lastWrite.Equal(*status.Chains[0].LastVote))
+}
+
+func (s *CheckerTestSuite) TestNotReadyIfListenerFallsBehind() {
+	s.mockRelayerStatus.EXPECT().QueueDepth(uint8(1)).Return(0)
+	s.mockRelayerStatus.EXPECT().LastWrite(uint8(1)).Return(time.Time{})
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(111), nil)
+	s.mockBlockStore.EXPECT().GetLastStoredBlock(uint8(1)).Return(big.NewInt(100), nil)
+
+	code, status := s.get("/readyz")
+
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.False(status.Ready)
+	s.Equal(uint64(11), status.Chains[0].BlocksBehind)
+	s.Nil(status.Chains[0].LastVote)
+}
+
+func (s *CheckerTestSuite) TestNotReadyIfRPCUnreachable() {
+	s.mockRelayerStatus.EXPECT().QueueDepth(uint8(1)).Return(0)
+	s.mockRelayerStatus.EXPECT().LastWrite(uint8(1)).Return(time.Time{})
+	s.mockChainClient.EXPECT().LatestBlock().Return(nil, errors.New("error"))
+
+	code, status := s.get("/readyz")
+
+	s.Equal(http.StatusServiceUnavailable, code)
+	s.False(status.Chains[0].RPCReachable)
+	s.Equal("error", status.Chains[0].Error)
+}
+
+func (s *CheckerTestSuite) TestHealthzSucceedsIfChainNotReady() {
+	s.mockRelayerStatus.EXPECT().QueueDepth(uint8(1)).Return(0)
+	s.mockRelayerStatus.EXPECT().LastWrite(uint8(1)).Return(time.Time{})
+	s.mockChainClient.EXPECT().LatestBlock().Return(nil, errors.New("error"))
+
+	code, status := s.get("/healthz")
+
+	s.Equal(http.StatusOK, code)
+	s.False(status.Ready)
+}
+
+func (s *CheckerTestSuite) TestUnregisteredChainIsNotChecked() {
+	s.checker.UnregisterChain(1)
+
+	code, status := s.get("/readyz")
+
+	s.Equal(http.StatusOK, code)
+	s.Equal(0, len(status.Chains))
+}
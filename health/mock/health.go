@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./health/health.go
+
+// Package mock_health is a generated GoMock package.
+package mock_health
+
+import (
+	big "math/big"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChainClient is a mock of ChainClient interface.
+type MockChainClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainClientMockRecorder
+}
+
+// MockChainClientMockRecorder is the mock recorder for MockChainClient.
+type MockChainClientMockRecorder struct {
+	mock *MockChainClient
+}
+
+// NewMockChainClient creates a new mock instance.
+func NewMockChainClient(ctrl *gomock.Controller) *MockChainClient {
+	mock := &MockChainClient{ctrl: ctrl}
+	mock.recorder = &MockChainClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainClient) EXPECT() *MockChainClientMockRecorder {
+	return m.recorder
+}
+
+// LatestBlock mocks base method.
+func (m *MockChainClient) LatestBlock() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestBlock")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestBlock indicates an expected call of LatestBlock.
+func (mr *MockChainClientMockRecorder) LatestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestBlock", reflect.TypeOf((*MockChainClient)(nil).LatestBlock))
+}
+
+// MockBlockStore is a mock of BlockStore interface.
+type MockBlockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStoreMockRecorder
+}
+
+// MockBlockStoreMockRecorder is the mock recorder for MockBlockStore.
+type MockBlockStoreMockRecorder struct {
+	mock *MockBlockStore
+}
+
+// NewMockBlockStore creates a new mock instance.
+func NewMockBlockStore(ctrl *gomock.Controller) *MockBlockStore {
+	mock := &MockBlockStore{ctrl: ctrl}
+	mock.recorder = &MockBlockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStore) EXPECT() *MockBlockStoreMockRecorder {
+	return m.recorder
+}
+
+// GetLastStoredBlock mocks base method.
+func (m *MockBlockStore) GetLastStoredBlock(domainID uint8) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastStoredBlock", domainID)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastStoredBlock indicates an expected call of GetLastStoredBlock.
+func (mr *MockBlockStoreMockRecorder) GetLastStoredBlock(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastStoredBlock", reflect.TypeOf((*MockBlockStore)(nil).GetLastStoredBlock), domainID)
+}
+
+// MockRelayerStatus is a mock of RelayerStatus interface.
+type MockRelayerStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelayerStatusMockRecorder
+}
+
+// MockRelayerStatusMockRecorder is the mock recorder for MockRelayerStatus.
+type MockRelayerStatusMockRecorder struct {
+	mock *MockRelayerStatus
+}
+
+// NewMockRelayerStatus creates a new mock instance.
+func NewMockRelayerStatus(ctrl *gomock.Controller) *MockRelayerStatus {
+	mock := &MockRelayerStatus{ctrl: ctrl}
+	mock.recorder = &MockRelayerStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelayerStatus) EXPECT() *MockRelayerStatusMockRecorder {
+	return m.recorder
+}
+
+// LastWrite mocks base method.
+func (m *MockRelayerStatus) LastWrite(domainID uint8) time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastWrite", domainID)
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastWrite indicates an expected call of LastWrite.
+func (mr *MockRelayerStatusMockRecorder) LastWrite(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastWrite", reflect.TypeOf((*MockRelayerStatus)(nil).LastWrite), domainID)
+}
+
+// QueueDepth mocks base method.
+func (m *MockRelayerStatus) QueueDepth(domainID uint8) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueueDepth", domainID)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// QueueDepth indicates an expected call of QueueDepth.
+func (mr *MockRelayerStatusMockRecorder) QueueDepth(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueueDepth", reflect.TypeOf((*MockRelayerStatus)(nil).QueueDepth), domainID)
+}
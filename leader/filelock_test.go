@@ -0,0 +1,60 @@
+//go:build !windows
+// +build !windows
+
+package leader_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/leader"
+	"github.com/stretchr/testify/suite"
+)
+
+type FileLockTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func TestRunFileLockTestSuite(t *testing.T) {
+	suite.Run(t, new(FileLockTestSuite))
+}
+
+func (s *FileLockTestSuite) SetupSuite()    {}
+func (s *FileLockTestSuite) TearDownSuite() {}
+func (s *FileLockTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "relayer.lock")
+}
+func (s *FileLockTestSuite) TearDownTest() {}
+
+func (s *FileLockTestSuite) TestLockHeldByAnotherInstanceIsNotAcquired() {
+	first := leader.NewFileLock(s.path)
+	second := leader.NewFileLock(s.path)
+
+	acquired, err := first.TryAcquire("a", time.Minute)
+	s.Nil(err)
+	s.True(acquired)
+
+	acquired, err = second.TryAcquire("b", time.Minute)
+	s.Nil(err)
+	s.False(acquired)
+
+	acquired, err = first.TryAcquire("a", time.Minute)
+	s.Nil(err)
+	s.True(acquired)
+}
+
+func (s *FileLockTestSuite) TestReleasedLockIsAcquired() {
+	first := leader.NewFileLock(s.path)
+	second := leader.NewFileLock(s.path)
+	_, _ = first.TryAcquire("a", time.Minute)
+
+	err := first.Release("a")
+	s.Nil(err)
+
+	acquired, err := second.TryAcquire("b", time.Minute)
+	s.Nil(err)
+	s.True(acquired)
+	s.Nil(second.Release("b"))
+}
@@ -0,0 +1,31 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+//go:build windows
+// +build windows
+
+package leader
+
+import (
+	"errors"
+	"time"
+)
+
+// FileLock is not supported on windows, leader election can not be enabled there
+type FileLock struct {
+	path string
+}
+
+func NewFileLock(path string) *FileLock {
+	return &FileLock{
+		path: path,
+	}
+}
+
+func (l *FileLock) TryAcquire(holder string, ttl time.Duration) (bool, error) {
+	return false, errors.New("leader election lock file is not supported on windows")
+}
+
+func (l *FileLock) Release(holder string) error {
+	return nil
+}
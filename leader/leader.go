@@ -0,0 +1,204 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package leader
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Now returns the current time, it is replaced in tests
+var Now = time.Now
+
+type Lease interface {
+	// TryAcquire acquires the lease for holder or extends it if holder already holds it.
+	// It returns false if the lease is held by another holder.
+	TryAcquire(holder string, ttl time.Duration) (bool, error)
+	// Release releases the lease if it is held by holder
+	Release(holder string) error
+}
+
+type Relayer interface {
+	Activate()
+	Standby()
+}
+
+type ChainClient interface {
+	LatestBlock() (*big.Int, error)
+}
+
+type BlockStore interface {
+	StoreBlock(block *big.Int, domainID uint8) error
+	GetLastStoredBlock(domainID uint8) (*big.Int, error)
+}
+
+type Config struct {
+	// ID identifies the relayer instance holding the lease
+	ID string
+	// LeaseTTL is how long the lease is held without being renewed
+	LeaseTTL time.Duration
+	// RenewInterval is how often the leader renews the lease and the standby tries to acquire it
+	RenewInterval time.Duration
+	// TakeoverBlocks is how many blocks behind the chain head the standby keeps its blockstore.
+	// These blocks are polled again on takeover so they should cover blocks produced during LeaseTTL.
+	TakeoverBlocks uint64
+}
+
+// Elector runs only one of the relayer instances sharing a key at a time.
+// The instance holding the lease polls events and writes messages while others
+// stay on standby and take over once the lease expires. StoreLease fails over between
+// instances on different hosts sharing a store, FileLock only between instances on the same host.
+type Elector struct {
+	lease      Lease
+	relayer    Relayer
+	blockstore BlockStore
+	config     Config
+	clients    map[uint8]ChainClient
+	leader     bool
+	renewed    time.Time
+	lock       sync.RWMutex
+}
+
+// NewElector creates elector of the relayer which has to be on standby until it is elected
+func NewElector(lease Lease, relayer Relayer, blockstore BlockStore, config Config) *Elector {
+	return &Elector{
+		lease:      lease,
+		relayer:    relayer,
+		blockstore: blockstore,
+		config:     config,
+		clients:    make(map[uint8]ChainClient),
+	}
+}
+
+// RegisterChain adds chain whose blockstore is kept fresh while on standby
+func (e *Elector) RegisterChain(domainID uint8, client ChainClient) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.clients[domainID] = client
+}
+
+// UnregisterChain removes chain whose blockstore is kept fresh while on standby
+func (e *Elector) UnregisterChain(domainID uint8) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	delete(e.clients, domainID)
+}
+
+// IsLeader checks if the relayer holds the lease
+func (e *Elector) IsLeader() bool {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	return e.leader
+}
+
+// Run campaigns for the lease every renew interval until ctx is cancelled.
+// The lease is released on return so that the standby can take over without waiting for it to expire.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.campaign()
+
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign acquires or renews the lease and activates the relayer or puts it on standby
+// if its leadership changed. Standby blockstore is moved closer to the chain head.
+func (e *Elector) campaign() {
+	now := Now()
+	acquired, err := e.lease.TryAcquire(e.config.ID, e.config.LeaseTTL)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed acquiring leader lease")
+	}
+
+	e.lock.Lock()
+	if err != nil {
+		// leader keeps running until its lease could expire before the next renewal
+		acquired = e.leader && now.Add(e.config.RenewInterval).Before(e.renewed.Add(e.config.LeaseTTL))
+	} else if acquired {
+		e.renewed = now
+	}
+	elected := acquired && !e.leader
+	demoted := !acquired && e.leader
+	e.leader = acquired
+	e.lock.Unlock()
+
+	switch {
+	case elected:
+		log.Info().Msgf("Relayer %s elected leader", e.config.ID)
+		e.relayer.Activate()
+	case demoted:
+		log.Warn().Msgf("Relayer %s lost leader lease", e.config.ID)
+		e.relayer.Standby()
+	}
+
+	if !acquired {
+		e.keepBlockstoreFresh()
+	}
+}
+
+func (e *Elector) resign() {
+	e.lock.Lock()
+	leader := e.leader
+	e.leader = false
+	e.lock.Unlock()
+
+	if !leader {
+		return
+	}
+	err := e.lease.Release(e.config.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed releasing leader lease")
+	}
+}
+
+// keepBlockstoreFresh stores block TakeoverBlocks behind the chain head of every chain
+// so that the standby does not poll blocks already relayed by the leader once it takes over
+func (e *Elector) keepBlockstoreFresh() {
+	e.lock.RLock()
+	clients := make(map[uint8]ChainClient, len(e.clients))
+	for domainID, client := range e.clients {
+		clients[domainID] = client
+	}
+	e.lock.RUnlock()
+
+	for domainID, client := range clients {
+		head, err := client.LatestBlock()
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed fetching latest block of domain %d", domainID)
+			continue
+		}
+		block := new(big.Int).Sub(head, new(big.Int).SetUint64(e.config.TakeoverBlocks))
+		if block.Sign() <= 0 {
+			continue
+		}
+
+		stored, err := e.blockstore.GetLastStoredBlock(domainID)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed fetching last stored block of domain %d", domainID)
+			continue
+		}
+		if stored.Cmp(block) >= 0 {
+			continue
+		}
+		err = e.blockstore.StoreBlock(block, domainID)
+		if err != nil {
+			log.Warn().Err(err).Msgf("Failed storing block %s of domain %d", block, domainID)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/leader"
+	mock_leader "github.com/VaivalGithub/chainsafe-core/leader/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ElectorTestSuite struct {
+	suite.Suite
+	elector         *leader.Elector
+	mockLease       *mock_leader.MockLease
+	mockRelayer     *mock_leader.MockRelayer
+	mockBlockStore  *mock_leader.MockBlockStore
+	mockChainClient *mock_leader.MockChainClient
+}
+
+func TestRunElectorTestSuite(t *testing.T) {
+	suite.Run(t, new(ElectorTestSuite))
+}
+
+func (s *ElectorTestSuite) SetupSuite()    {}
+func (s *ElectorTestSuite) TearDownSuite() {}
+func (s *ElectorTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockLease = mock_leader.NewMockLease(gomockController)
+	s.mockRelayer = mock_leader.NewMockRelayer(gomockController)
+	s.mockBlockStore = mock_leader.NewMockBlockStore(gomockController)
+	s.mockChainClient = mock_leader.NewMockChainClient(gomockController)
+	s.elector = leader.NewElector(s.mockLease, s.mockRelayer, s.mockBlockStore, leader.Config{
+		ID:             "relayer",
+		LeaseTTL:       time.Hour,
+		RenewInterval:  time.Millisecond,
+		TakeoverBlocks: 10,
+	})
+	s.elector.RegisterChain(1, s.mockChainClient)
+}
+func (s *ElectorTestSuite) TearDownTest() {}
+
+func (s *ElectorTestSuite) cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func (s *ElectorTestSuite) TestActivatesRelayerOnceElected() {
+	s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).Return(true, nil)
+	s.mockRelayer.EXPECT().Activate()
+	s.mockLease.EXPECT().Release("relayer").Return(nil)
+
+	s.elector.Run(s.cancelledContext())
+
+	s.False(s.elector.IsLeader())
+}
+
+func (s *ElectorTestSuite) TestStandbyMovesBlockstoreBehindChainHead() {
+	s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).Return(false, nil)
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockBlockStore.EXPECT().GetLastStoredBlock(uint8(1)).Return(big.NewInt(50), nil)
+	s.mockBlockStore.EXPECT().StoreBlock(big.NewInt(90), uint8(1)).Return(nil)
+
+	s.elector.Run(s.cancelledContext())
+}
+
+func (s *ElectorTestSuite) TestStandbyDoesNotMoveBlockstoreBack() {
+	s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).Return(false, nil)
+	s.mockChainClient.EXPECT().LatestBlock().Return(big.NewInt(100), nil)
+	s.mockBlockStore.EXPECT().GetLastStoredBlock(uint8(1)).Return(big.NewInt(95), nil)
+
+	s.elector.Run(s.cancelledContext())
+}
+
+func (s *ElectorTestSuite) TestStandbyIgnoresChainHeadErrors() {
+	s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).Return(false, nil)
+	s.mockChainClient.EXPECT().LatestBlock().Return(nil, errors.New("error"))
+
+	s.elector.Run(s.cancelledContext())
+}
+
+func (s *ElectorTestSuite) TestPutsRelayerOnStandbyOnceLeaseIsLost() {
+	s.elector.UnregisterChain(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).Return(true, nil),
+		s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).DoAndReturn(func(holder string, ttl time.Duration) (bool, error) {
+			cancel()
+			return false, nil
+		}).MinTimes(1),
+	)
+	gomock.InOrder(
+		s.mockRelayer.EXPECT().Activate(),
+		s.mockRelayer.EXPECT().Standby(),
+	)
+
+	s.elector.Run(ctx)
+}
+
+func (s *ElectorTestSuite) TestKeepsLeadershipWhileLeaseCannotBeRenewed() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gomock.InOrder(
+		s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).Return(true, nil),
+		s.mockLease.EXPECT().TryAcquire("relayer", time.Hour).DoAndReturn(func(holder string, ttl time.Duration) (bool, error) {
+			cancel()
+			return false, errors.New("error")
+		}).MinTimes(1),
+	)
+	s.mockRelayer.EXPECT().Activate()
+	s.mockLease.EXPECT().Release("relayer").Return(nil)
+
+	s.elector.Run(ctx)
+}
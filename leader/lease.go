@@ -0,0 +1,88 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package leader
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type leaseRecord struct {
+	Holder string    `json:"holder"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// StoreLease is a Lease held in a store shared by relayer instances on any host.
+// The store has to support conditional writes that are atomic across all instances,
+// the lease is written only if it did not change since it was read so that
+// at most one instance acquires it at a time.
+type StoreLease struct {
+	db  store.KeyValueConditionalStore
+	key []byte
+}
+
+// NewStoreLease creates lease stored under the name in the shared store
+func NewStoreLease(db store.KeyValueConditionalStore, name string) *StoreLease {
+	return &StoreLease{
+		db:  db,
+		key: []byte(fmt.Sprintf("leader:%s", name)),
+	}
+}
+
+func (l *StoreLease) TryAcquire(holder string, ttl time.Duration) (bool, error) {
+	now := Now()
+	current, raw, err := l.get()
+	if err != nil {
+		return false, err
+	}
+	if current != nil && current.Holder != holder && now.Before(current.Expiry) {
+		return false, nil
+	}
+
+	// another instance acquiring the lease at the same time changes it before us
+	return l.compareAndSet(raw, leaseRecord{Holder: holder, Expiry: now.Add(ttl)})
+}
+
+func (l *StoreLease) Release(holder string) error {
+	current, raw, err := l.get()
+	if err != nil {
+		return err
+	}
+	if current == nil || current.Holder != holder {
+		return nil
+	}
+	_, err = l.compareAndSet(raw, leaseRecord{Holder: holder})
+	return err
+}
+
+// get returns the stored lease with its raw value or nil if it was never acquired
+func (l *StoreLease) get() (*leaseRecord, []byte, error) {
+	v, err := l.db.GetByKey(l.key)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) || errors.Is(err, store.ErrNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	record := &leaseRecord{}
+	err = json.Unmarshal(v, record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return record, v, nil
+}
+
+func (l *StoreLease) compareAndSet(old []byte, record leaseRecord) (bool, error) {
+	v, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+	return l.db.CompareAndSetByKey(l.key, old, v)
+}
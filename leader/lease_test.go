@@ -0,0 +1,143 @@
+package leader_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/leader"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_blockstore "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StoreLeaseTestSuite struct {
+	suite.Suite
+	lease       *leader.StoreLease
+	otherLease  *leader.StoreLease
+	values      map[string][]byte
+	beforeWrite func()
+	now         time.Time
+}
+
+func TestRunStoreLeaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StoreLeaseTestSuite))
+}
+
+func (s *StoreLeaseTestSuite) SetupSuite()    {}
+func (s *StoreLeaseTestSuite) TearDownSuite() {}
+func (s *StoreLeaseTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	mockKeyValueStore := mock_blockstore.NewMockKeyValueConditionalStore(gomockController)
+	s.values = make(map[string][]byte)
+	s.beforeWrite = nil
+	mockKeyValueStore.EXPECT().GetByKey(gomock.Any()).DoAndReturn(func(key []byte) ([]byte, error) {
+		v, ok := s.values[string(key)]
+		if !ok {
+			return nil, store.ErrNotFound
+		}
+		return v, nil
+	}).AnyTimes()
+	mockKeyValueStore.EXPECT().CompareAndSetByKey(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(key []byte, old []byte, value []byte) (bool, error) {
+		if s.beforeWrite != nil {
+			beforeWrite := s.beforeWrite
+			s.beforeWrite = nil
+			beforeWrite()
+		}
+		current, ok := s.values[string(key)]
+		if ok != (old != nil) || !bytes.Equal(current, old) {
+			return false, nil
+		}
+		s.values[string(key)] = value
+		return true, nil
+	}).AnyTimes()
+	s.lease = leader.NewStoreLease(mockKeyValueStore, "relayer")
+	s.otherLease = leader.NewStoreLease(mockKeyValueStore, "relayer")
+
+	s.now = time.Unix(1000, 0)
+	leader.Now = func() time.Time {
+		return s.now
+	}
+}
+func (s *StoreLeaseTestSuite) TearDownTest() {
+	leader.Now = time.Now
+}
+
+func (s *StoreLeaseTestSuite) TestAcquiresFreeLease() {
+	acquired, err := s.lease.TryAcquire("a", time.Minute)
+
+	s.Nil(err)
+	s.True(acquired)
+}
+
+func (s *StoreLeaseTestSuite) TestHolderRenewsLease() {
+	_, _ = s.lease.TryAcquire("a", time.Minute)
+	s.now = s.now.Add(30 * time.Second)
+
+	acquired, err := s.lease.TryAcquire("a", time.Minute)
+	s.Nil(err)
+	s.True(acquired)
+
+	s.now = s.now.Add(45 * time.Second)
+	acquired, err = s.otherLease.TryAcquire("b", time.Minute)
+	s.Nil(err)
+	s.False(acquired)
+}
+
+func (s *StoreLeaseTestSuite) TestLeaseHeldByAnotherHolderIsNotAcquired() {
+	_, _ = s.lease.TryAcquire("a", time.Minute)
+
+	acquired, err := s.otherLease.TryAcquire("b", time.Minute)
+
+	s.Nil(err)
+	s.False(acquired)
+}
+
+func (s *StoreLeaseTestSuite) TestExpiredLeaseIsAcquired() {
+	_, _ = s.lease.TryAcquire("a", time.Minute)
+	s.now = s.now.Add(time.Minute)
+
+	acquired, err := s.otherLease.TryAcquire("b", time.Minute)
+
+	s.Nil(err)
+	s.True(acquired)
+}
+
+func (s *StoreLeaseTestSuite) TestLeaseAcquiredConcurrentlyIsHeldByOneHolder() {
+	s.beforeWrite = func() {
+		acquired, err := s.otherLease.TryAcquire("b", time.Minute)
+		s.Nil(err)
+		s.True(acquired)
+	}
+
+	acquired, err := s.lease.TryAcquire("a", time.Minute)
+	s.Nil(err)
+	s.False(acquired)
+
+	acquired, err = s.otherLease.TryAcquire("b", time.Minute)
+	s.Nil(err)
+	s.True(acquired)
+}
+
+func (s *StoreLeaseTestSuite) TestReleasedLeaseIsAcquired() {
+	_, _ = s.lease.TryAcquire("a", time.Minute)
+
+	err := s.lease.Release("a")
+	s.Nil(err)
+
+	acquired, err := s.otherLease.TryAcquire("b", time.Minute)
+	s.Nil(err)
+	s.True(acquired)
+}
+
+func (s *StoreLeaseTestSuite) TestLeaseIsNotReleasedByAnotherHolder() {
+	_, _ = s.lease.TryAcquire("a", time.Minute)
+
+	err := s.otherLease.Release("b")
+	s.Nil(err)
+
+	acquired, err := s.otherLease.TryAcquire("b", time.Minute)
+	s.Nil(err)
+	s.False(acquired)
+}
@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./leader/leader.go
+
+// Package mock_leader is a generated GoMock package.
+package mock_leader
+
+import (
+	big "math/big"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLease is a mock of Lease interface.
+type MockLease struct {
+	ctrl     *gomock.Controller
+	recorder *MockLeaseMockRecorder
+}
+
+// MockLeaseMockRecorder is the mock recorder for MockLease.
+type MockLeaseMockRecorder struct {
+	mock *MockLease
+}
+
+// NewMockLease creates a new mock instance.
+func NewMockLease(ctrl *gomock.Controller) *MockLease {
+	mock := &MockLease{ctrl: ctrl}
+	mock.recorder = &MockLeaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLease) EXPECT() *MockLeaseMockRecorder {
+	return m.recorder
+}
+
+// Release mocks base method.
+func (m *MockLease) Release(holder string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", holder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockLeaseMockRecorder) Release(holder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockLease)(nil).Release), holder)
+}
+
+// TryAcquire mocks base method.
+func (m *MockLease) TryAcquire(holder string, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryAcquire", holder, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TryAcquire indicates an expected call of TryAcquire.
+func (mr *MockLeaseMockRecorder) TryAcquire(holder, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryAcquire", reflect.TypeOf((*MockLease)(nil).TryAcquire), holder, ttl)
+}
+
+// MockRelayer is a mock of Relayer interface.
+type MockRelayer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelayerMockRecorder
+}
+
+// MockRelayerMockRecorder is the mock recorder for MockRelayer.
+type MockRelayerMockRecorder struct {
+	mock *MockRelayer
+}
+
+// NewMockRelayer creates a new mock instance.
+func NewMockRelayer(ctrl *gomock.Controller) *MockRelayer {
+	mock := &MockRelayer{ctrl: ctrl}
+	mock.recorder = &MockRelayerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelayer) EXPECT() *MockRelayerMockRecorder {
+	return m.recorder
+}
+
+// Activate mocks base method.
+func (m *MockRelayer) Activate() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Activate")
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockRelayerMockRecorder) Activate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockRelayer)(nil).Activate))
+}
+
+// Standby mocks base method.
+func (m *MockRelayer) Standby() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Standby")
+}
+
+// Standby indicates an expected call of Standby.
+func (mr *MockRelayerMockRecorder) Standby() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Standby", reflect.TypeOf((*MockRelayer)(nil).Standby))
+}
+
+// MockChainClient is a mock of ChainClient interface.
+type MockChainClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainClientMockRecorder
+}
+
+// MockChainClientMockRecorder is the mock recorder for MockChainClient.
+type MockChainClientMockRecorder struct {
+	mock *MockChainClient
+}
+
+// NewMockChainClient creates a new mock instance.
+func NewMockChainClient(ctrl *gomock.Controller) *MockChainClient {
+	mock := &MockChainClient{ctrl: ctrl}
+	mock.recorder = &MockChainClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChainClient) EXPECT() *MockChainClientMockRecorder {
+	return m.recorder
+}
+
+// LatestBlock mocks base method.
+func (m *MockChainClient) LatestBlock() (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestBlock")
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestBlock indicates an expected call of LatestBlock.
+func (mr *MockChainClientMockRecorder) LatestBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestBlock", reflect.TypeOf((*MockChainClient)(nil).LatestBlock))
+}
+
+// MockBlockStore is a mock of BlockStore interface.
+type MockBlockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockStoreMockRecorder
+}
+
+// MockBlockStoreMockRecorder is the mock recorder for MockBlockStore.
+type MockBlockStoreMockRecorder struct {
+	mock *MockBlockStore
+}
+
+// NewMockBlockStore creates a new mock instance.
+func NewMockBlockStore(ctrl *gomock.Controller) *MockBlockStore {
+	mock := &MockBlockStore{ctrl: ctrl}
+	mock.recorder = &MockBlockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockStore) EXPECT() *MockBlockStoreMockRecorder {
+	return m.recorder
+}
+
+// GetLastStoredBlock mocks base method.
+func (m *MockBlockStore) GetLastStoredBlock(domainID uint8) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastStoredBlock", domainID)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastStoredBlock indicates an expected call of GetLastStoredBlock.
+func (mr *MockBlockStoreMockRecorder) GetLastStoredBlock(domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastStoredBlock", reflect.TypeOf((*MockBlockStore)(nil).GetLastStoredBlock), domainID)
+}
+
+// StoreBlock mocks base method.
+func (m *MockBlockStore) StoreBlock(block *big.Int, domainID uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreBlock", block, domainID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreBlock indicates an expected call of StoreBlock.
+func (mr *MockBlockStoreMockRecorder) StoreBlock(block, domainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreBlock", reflect.TypeOf((*MockBlockStore)(nil).StoreBlock), block, domainID)
+}
@@ -6,6 +6,7 @@ package lvldb
 import (
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 type LVLDB struct {
@@ -28,6 +29,25 @@ func (db *LVLDB) SetByKey(key []byte, value []byte) error {
 	return db.db.Put(key, value, nil)
 }
 
+func (db *LVLDB) DeleteByKey(key []byte) error {
+	return db.db.Delete(key, nil)
+}
+
+// GetByPrefix returns values of all keys with provided prefix in key order
+func (db *LVLDB) GetByPrefix(prefix []byte) ([][]byte, error) {
+	iter := db.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	values := make([][]byte, 0)
+	for iter.Next() {
+		// iterator reuses underlying buffer so value has to be copied
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+		values = append(values, value)
+	}
+	return values, iter.Error()
+}
+
 func (db *LVLDB) Close() error {
 	return db.db.Close()
 }
@@ -0,0 +1,229 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package limits
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/rs/zerolog/log"
+)
+
+// Window is the period over which transfer volume is summed
+const Window = 24 * time.Hour
+
+var Now = time.Now
+
+type LimitStore interface {
+	StoreTransfer(resourceID types.ResourceID, t *store.Transfer) error
+	GetTransfers(resourceID types.ResourceID) ([]*store.Transfer, error)
+	DeleteTransfer(resourceID types.ResourceID, t *store.Transfer) error
+	StoreCircuitBreaker(resourceID types.ResourceID, tripped bool) error
+	IsCircuitBreakerTripped(resourceID types.ResourceID) (bool, error)
+}
+
+// Limit defines transfer volume caps of a single resource
+type Limit struct {
+	// MaxTransfer is the maximum amount of a single transfer. It is not checked if nil.
+	MaxTransfer *big.Int
+	// DailyLimit is the maximum amount transferred within the Window. It is not checked if nil.
+	DailyLimit *big.Int
+}
+
+// depositKey identifies deposit of a transfer
+type depositKey struct {
+	source       uint8
+	destination  uint8
+	depositNonce uint64
+}
+
+// reservation is volume of a transfer processed by the limiter that was not written yet.
+// Volume of held transfers is not part of the daily volume until they are approved and written.
+type reservation struct {
+	resourceID types.ResourceID
+	amount     *big.Int
+	held       bool
+}
+
+// TransferLimiter holds fungible transfers that exceed volume caps of their resource.
+// Exceeding the daily limit trips the resource circuit breaker which holds all further
+// transfers of the resource until it is reset.
+//
+// Accepted transfers reserve their volume until they are written to the destination
+// or released by the relayer. Only written transfers, including held transfers approved
+// by an operator, are counted in the window volume so the limiter has to be registered
+// as a relayer observer.
+type TransferLimiter struct {
+	message.NoopObserver
+	store        LimitStore
+	limits       map[types.ResourceID]Limit
+	reservations map[depositKey]reservation
+	lock         sync.Mutex
+}
+
+func NewTransferLimiter(store LimitStore, limits map[types.ResourceID]Limit) *TransferLimiter {
+	return &TransferLimiter{
+		store:        store,
+		limits:       limits,
+		reservations: make(map[depositKey]reservation),
+	}
+}
+
+// Process is a message.MessageProcessor that returns message.ErrMessageHeld
+// if the transfer exceeds volume caps of its resource.
+// Transfers are held if limit state can't be read or persisted so that caps can't be bypassed.
+func (l *TransferLimiter) Process(m *message.Message) error {
+	if m.Type != message.FungibleTransfer {
+		return nil
+	}
+	limit, ok := l.limits[m.ResourceId]
+	if !ok {
+		return nil
+	}
+
+	if len(m.Payload) == 0 {
+		return fmt.Errorf("payload of the Message is empty")
+	}
+	amountBytes, ok := m.Payload[0].([]byte)
+	if !ok {
+		return fmt.Errorf("could not cast interface to byte slice")
+	}
+	amount := new(big.Int).SetBytes(amountBytes)
+
+	// workers of different destinations can process transfers of the same resource concurrently
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := newDepositKey(m)
+	tripped, err := l.store.IsCircuitBreakerTripped(m.ResourceId)
+	if err != nil {
+		return l.hold(key, m, amount, fmt.Errorf("%w: failed fetching circuit breaker state: %s", message.ErrMessageHeld, err))
+	}
+	if tripped {
+		return l.hold(key, m, amount, fmt.Errorf("%w: circuit breaker of resource %x is tripped", message.ErrMessageHeld, m.ResourceId))
+	}
+
+	if limit.MaxTransfer != nil && amount.Cmp(limit.MaxTransfer) > 0 {
+		return l.hold(key, m, amount, fmt.Errorf("%w: amount %s exceeds max transfer %s of resource %x", message.ErrMessageHeld, amount, limit.MaxTransfer, m.ResourceId))
+	}
+
+	total, counted, err := l.windowVolume(m)
+	if err != nil {
+		return l.hold(key, m, amount, fmt.Errorf("%w: failed calculating transfer volume: %s", message.ErrMessageHeld, err))
+	}
+	// message is processed again after restart or retry and is already part of the volume
+	if r, reserved := l.reservations[key]; counted || (reserved && !r.held) {
+		return nil
+	}
+
+	for _, r := range l.reservations {
+		if r.resourceID == m.ResourceId && !r.held {
+			total.Add(total, r.amount)
+		}
+	}
+	total.Add(total, amount)
+	if limit.DailyLimit != nil && total.Cmp(limit.DailyLimit) > 0 {
+		log.Warn().Msgf("Transfer volume %s exceeds daily limit %s of resource %x, tripping circuit breaker", total, limit.DailyLimit, m.ResourceId)
+		err = l.store.StoreCircuitBreaker(m.ResourceId, true)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed storing circuit breaker of resource %x", m.ResourceId)
+		}
+		return l.hold(key, m, amount, fmt.Errorf("%w: transfer volume %s exceeds daily limit %s of resource %x", message.ErrMessageHeld, total, limit.DailyLimit, m.ResourceId))
+	}
+
+	l.reservations[key] = reservation{resourceID: m.ResourceId, amount: amount}
+	return nil
+}
+
+// hold reserves volume of the held transfer so that it is counted once it is approved
+// by an operator and written, and returns the hold reason
+func (l *TransferLimiter) hold(key depositKey, m *message.Message, amount *big.Int, reason error) error {
+	l.reservations[key] = reservation{resourceID: m.ResourceId, amount: amount, held: true}
+	return reason
+}
+
+// MessageWritten counts volume reserved by the written transfer in the window volume
+func (l *TransferLimiter) MessageWritten(m *message.Message) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := newDepositKey(m)
+	r, ok := l.reservations[key]
+	if !ok {
+		return
+	}
+	delete(l.reservations, key)
+	err := l.store.StoreTransfer(r.resourceID, &store.Transfer{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.DepositNonce,
+		Amount:       r.amount,
+		Timestamp:    Now().Unix(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msgf("failed storing transfer of message %+v", m)
+	}
+}
+
+// MessageFailed releases volume reserved by the transfer that was rejected or dead-lettered
+func (l *TransferLimiter) MessageFailed(m *message.Message, err error) {
+	l.release(m)
+}
+
+// MessageReleased releases volume reserved by the transfer that was left in the outbox,
+// held or retracted
+func (l *TransferLimiter) MessageReleased(m *message.Message) {
+	l.release(m)
+}
+
+func (l *TransferLimiter) release(m *message.Message) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.reservations, newDepositKey(m))
+}
+
+// ResetCircuitBreaker resumes relaying transfers of the resource
+func (l *TransferLimiter) ResetCircuitBreaker(resourceID types.ResourceID) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.store.StoreCircuitBreaker(resourceID, false)
+}
+
+// windowVolume sums amounts of transfers of the message resource within the Window and
+// removes older transfers. It also reports if the message deposit was already counted.
+func (l *TransferLimiter) windowVolume(m *message.Message) (*big.Int, bool, error) {
+	transfers, err := l.store.GetTransfers(m.ResourceId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	total := big.NewInt(0)
+	counted := false
+	windowStart := Now().Add(-Window).Unix()
+	for _, t := range transfers {
+		if t.Timestamp < windowStart {
+			err = l.store.DeleteTransfer(m.ResourceId, t)
+			if err != nil {
+				log.Error().Err(err).Msgf("failed removing expired transfer %+v", t)
+			}
+			continue
+		}
+		if t.Source == m.Source && t.Destination == m.Destination && t.DepositNonce == m.DepositNonce {
+			counted = true
+		}
+		total.Add(total, t.Amount)
+	}
+	return total, counted, nil
+}
+
+func newDepositKey(m *message.Message) depositKey {
+	return depositKey{source: m.Source, destination: m.Destination, depositNonce: m.DepositNonce}
+}
@@ -0,0 +1,206 @@
+package limits_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/limits"
+	mock_limits "github.com/VaivalGithub/chainsafe-core/relayer/limits/mock"
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransferLimiterTestSuite struct {
+	suite.Suite
+	limiter        *limits.TransferLimiter
+	mockLimitStore *mock_limits.MockLimitStore
+	resourceID     types.ResourceID
+	now            time.Time
+}
+
+func TestRunTransferLimiterTestSuite(t *testing.T) {
+	suite.Run(t, new(TransferLimiterTestSuite))
+}
+
+func (s *TransferLimiterTestSuite) SetupSuite()    {}
+func (s *TransferLimiterTestSuite) TearDownSuite() {}
+func (s *TransferLimiterTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockLimitStore = mock_limits.NewMockLimitStore(gomockController)
+	s.resourceID = types.ResourceID{31: 1}
+	s.limiter = limits.NewTransferLimiter(s.mockLimitStore, map[types.ResourceID]limits.Limit{
+		s.resourceID: {MaxTransfer: big.NewInt(100), DailyLimit: big.NewInt(150)},
+	})
+	s.now = time.Unix(1000000, 0)
+	limits.Now = func() time.Time { return s.now }
+}
+func (s *TransferLimiterTestSuite) TearDownTest() {}
+
+func (s *TransferLimiterTestSuite) transfer(amount int64) *message.Message {
+	return &message.Message{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		ResourceId:   s.resourceID,
+		Type:         message.FungibleTransfer,
+		Payload:      []interface{}{big.NewInt(amount).Bytes()},
+	}
+}
+
+func (s *TransferLimiterTestSuite) TestIgnoresResourcesWithoutLimit() {
+	m := s.transfer(1000)
+	m.ResourceId = types.ResourceID{31: 2}
+
+	err := s.limiter.Process(m)
+
+	s.Nil(err)
+}
+
+func (s *TransferLimiterTestSuite) TestHoldsTransferIfCircuitBreakerTripped() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(true, nil)
+
+	err := s.limiter.Process(s.transfer(10))
+
+	s.True(errors.Is(err, message.ErrMessageHeld))
+}
+
+func (s *TransferLimiterTestSuite) TestHoldsTransferIfBreakerStateFetchFails() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, errors.New("error"))
+
+	err := s.limiter.Process(s.transfer(10))
+
+	s.True(errors.Is(err, message.ErrMessageHeld))
+}
+
+func (s *TransferLimiterTestSuite) TestHoldsTransferOverMaxTransfer() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil)
+
+	err := s.limiter.Process(s.transfer(101))
+
+	s.True(errors.Is(err, message.ErrMessageHeld))
+}
+
+func (s *TransferLimiterTestSuite) TestTripsCircuitBreakerOverDailyLimit() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil)
+	s.mockLimitStore.EXPECT().GetTransfers(s.resourceID).Return([]*store.Transfer{
+		{Source: 1, Destination: 2, DepositNonce: 1, Amount: big.NewInt(100), Timestamp: s.now.Unix() - 10},
+	}, nil)
+	s.mockLimitStore.EXPECT().StoreCircuitBreaker(s.resourceID, true).Return(nil)
+
+	err := s.limiter.Process(s.transfer(60))
+
+	s.True(errors.Is(err, message.ErrMessageHeld))
+}
+
+func (s *TransferLimiterTestSuite) TestRecordsWrittenTransferAndRemovesExpired() {
+	expired := &store.Transfer{Source: 1, Destination: 2, DepositNonce: 1, Amount: big.NewInt(100), Timestamp: s.now.Add(-limits.Window).Unix() - 1}
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil)
+	s.mockLimitStore.EXPECT().GetTransfers(s.resourceID).Return([]*store.Transfer{
+		expired,
+		{Source: 1, Destination: 2, DepositNonce: 2, Amount: big.NewInt(100), Timestamp: s.now.Unix() - 10},
+	}, nil)
+	s.mockLimitStore.EXPECT().DeleteTransfer(s.resourceID, expired).Return(nil)
+	s.mockLimitStore.EXPECT().StoreTransfer(s.resourceID, &store.Transfer{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		Amount:       big.NewInt(50),
+		Timestamp:    s.now.Unix(),
+	}).Return(nil)
+
+	err := s.limiter.Process(s.transfer(50))
+	s.limiter.MessageWritten(s.transfer(50))
+
+	s.Nil(err)
+}
+
+func (s *TransferLimiterTestSuite) TestCountsReservedTransfersInDailyLimit() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil).Times(2)
+	s.mockLimitStore.EXPECT().GetTransfers(s.resourceID).Return([]*store.Transfer{}, nil).Times(2)
+	s.mockLimitStore.EXPECT().StoreCircuitBreaker(s.resourceID, true).Return(nil)
+	second := s.transfer(60)
+	second.DepositNonce = 4
+
+	err := s.limiter.Process(s.transfer(100))
+	s.Nil(err)
+	err = s.limiter.Process(second)
+
+	s.True(errors.Is(err, message.ErrMessageHeld))
+}
+
+func (s *TransferLimiterTestSuite) TestFailedTransferIsNotCounted() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil)
+	s.mockLimitStore.EXPECT().GetTransfers(s.resourceID).Return([]*store.Transfer{}, nil)
+
+	err := s.limiter.Process(s.transfer(100))
+	s.limiter.MessageFailed(s.transfer(100), errors.New("error"))
+	s.limiter.MessageWritten(s.transfer(100))
+
+	s.Nil(err)
+}
+
+func (s *TransferLimiterTestSuite) TestReleasedTransferIsNotCounted() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil).Times(2)
+	s.mockLimitStore.EXPECT().GetTransfers(s.resourceID).Return([]*store.Transfer{}, nil).Times(2)
+	second := s.transfer(60)
+	second.DepositNonce = 4
+
+	err := s.limiter.Process(s.transfer(100))
+	s.Nil(err)
+	s.limiter.MessageReleased(s.transfer(100))
+	err = s.limiter.Process(second)
+
+	s.Nil(err)
+}
+
+func (s *TransferLimiterTestSuite) TestCountsApprovedHeldTransferOnceWritten() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil)
+	s.mockLimitStore.EXPECT().StoreTransfer(s.resourceID, &store.Transfer{
+		Source:       1,
+		Destination:  2,
+		DepositNonce: 3,
+		Amount:       big.NewInt(120),
+		Timestamp:    s.now.Unix(),
+	}).Return(nil)
+
+	err := s.limiter.Process(s.transfer(120))
+	s.True(errors.Is(err, message.ErrMessageHeld))
+	s.limiter.MessageWritten(s.transfer(120))
+}
+
+func (s *TransferLimiterTestSuite) TestHeldTransferIsNotCountedInDailyLimit() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil).Times(2)
+	s.mockLimitStore.EXPECT().GetTransfers(s.resourceID).Return([]*store.Transfer{}, nil)
+	second := s.transfer(100)
+	second.DepositNonce = 4
+
+	err := s.limiter.Process(s.transfer(120))
+	s.True(errors.Is(err, message.ErrMessageHeld))
+	err = s.limiter.Process(second)
+
+	s.Nil(err)
+}
+
+func (s *TransferLimiterTestSuite) TestDoesNotCountSameDepositTwice() {
+	s.mockLimitStore.EXPECT().IsCircuitBreakerTripped(s.resourceID).Return(false, nil)
+	s.mockLimitStore.EXPECT().GetTransfers(s.resourceID).Return([]*store.Transfer{
+		{Source: 1, Destination: 2, DepositNonce: 3, Amount: big.NewInt(100), Timestamp: s.now.Unix() - 10},
+	}, nil)
+
+	err := s.limiter.Process(s.transfer(100))
+
+	s.Nil(err)
+}
+
+func (s *TransferLimiterTestSuite) TestResetCircuitBreaker() {
+	s.mockLimitStore.EXPECT().StoreCircuitBreaker(s.resourceID, false).Return(nil)
+
+	err := s.limiter.ResetCircuitBreaker(s.resourceID)
+
+	s.Nil(err)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./relayer/limits/limits.go
+
+// Package mock_limits is a generated GoMock package.
+package mock_limits
+
+import (
+	reflect "reflect"
+
+	store "github.com/VaivalGithub/chainsafe-core/store"
+	types "github.com/VaivalGithub/chainsafe-core/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLimitStore is a mock of LimitStore interface.
+type MockLimitStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitStoreMockRecorder
+}
+
+// MockLimitStoreMockRecorder is the mock recorder for MockLimitStore.
+type MockLimitStoreMockRecorder struct {
+	mock *MockLimitStore
+}
+
+// NewMockLimitStore creates a new mock instance.
+func NewMockLimitStore(ctrl *gomock.Controller) *MockLimitStore {
+	mock := &MockLimitStore{ctrl: ctrl}
+	mock.recorder = &MockLimitStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitStore) EXPECT() *MockLimitStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteTransfer mocks base method.
+func (m *MockLimitStore) DeleteTransfer(resourceID types.ResourceID, t *store.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransfer", resourceID, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransfer indicates an expected call of DeleteTransfer.
+func (mr *MockLimitStoreMockRecorder) DeleteTransfer(resourceID, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockLimitStore)(nil).DeleteTransfer), resourceID, t)
+}
+
+// GetTransfers mocks base method.
+func (m *MockLimitStore) GetTransfers(resourceID types.ResourceID) ([]*store.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfers", resourceID)
+	ret0, _ := ret[0].([]*store.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfers indicates an expected call of GetTransfers.
+func (mr *MockLimitStoreMockRecorder) GetTransfers(resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfers", reflect.TypeOf((*MockLimitStore)(nil).GetTransfers), resourceID)
+}
+
+// IsCircuitBreakerTripped mocks base method.
+func (m *MockLimitStore) IsCircuitBreakerTripped(resourceID types.ResourceID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsCircuitBreakerTripped", resourceID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsCircuitBreakerTripped indicates an expected call of IsCircuitBreakerTripped.
+func (mr *MockLimitStoreMockRecorder) IsCircuitBreakerTripped(resourceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsCircuitBreakerTripped", reflect.TypeOf((*MockLimitStore)(nil).IsCircuitBreakerTripped), resourceID)
+}
+
+// StoreCircuitBreaker mocks base method.
+func (m *MockLimitStore) StoreCircuitBreaker(resourceID types.ResourceID, tripped bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreCircuitBreaker", resourceID, tripped)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreCircuitBreaker indicates an expected call of StoreCircuitBreaker.
+func (mr *MockLimitStoreMockRecorder) StoreCircuitBreaker(resourceID, tripped interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCircuitBreaker", reflect.TypeOf((*MockLimitStore)(nil).StoreCircuitBreaker), resourceID, tripped)
+}
+
+// StoreTransfer mocks base method.
+func (m *MockLimitStore) StoreTransfer(resourceID types.ResourceID, t *store.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreTransfer", resourceID, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreTransfer indicates an expected call of StoreTransfer.
+func (mr *MockLimitStoreMockRecorder) StoreTransfer(resourceID, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTransfer", reflect.TypeOf((*MockLimitStore)(nil).StoreTransfer), resourceID, t)
+}
@@ -20,6 +20,10 @@ const (
 	FungibleTransfer    TransferType = "FungibleTransfer"
 	NonFungibleTransfer TransferType = "NonFungibleTransfer"
 	GenericTransfer     TransferType = "GenericTransfer"
+	// TokenRegistration carries token pair registered on the source bridge that should be mirrored on the destination
+	TokenRegistration TransferType = "TokenRegistration"
+	// Retraction withdraws deposit that was removed from the source chain by a reorganisation
+	Retraction TransferType = "Retraction"
 )
 
 type ProposalStatus struct {
@@ -41,6 +45,24 @@ var (
 	StatusMap = map[uint8]string{ProposalStatusInactive: "inactive", ProposalStatusActive: "active", ProposalStatusPassed: "passed", ProposalStatusExecuted: "executed", ProposalStatusCanceled: "canceled"}
 )
 
+// MessageStatus is the local processing state of a deposit message
+type MessageStatus uint8
+
+const (
+	MessageStatusUnknown MessageStatus = iota
+	MessageStatusReceived
+	MessageStatusVoted
+	MessageStatusExecuted
+	MessageStatusHeld
+	MessageStatusApproved
+	MessageStatusRejected
+	MessageStatusRetracted
+)
+
+var (
+	MessageStatusMap = map[MessageStatus]string{MessageStatusUnknown: "unknown", MessageStatusReceived: "received", MessageStatusVoted: "voted", MessageStatusExecuted: "executed", MessageStatusHeld: "held", MessageStatusApproved: "approved", MessageStatusRejected: "rejected", MessageStatusRetracted: "retracted"}
+)
+
 type Message struct {
 	Source       uint8  // Source where message was initiated
 	Destination  uint8  // Destination chain of message
@@ -49,6 +71,9 @@ type Message struct {
 	Payload      []interface{} // data associated with event sequence
 	Metadata     Metadata      // Arbitrary data that will be most likely be used by the relayer
 	Type         TransferType
+	Sender       common.Address // Address that made the deposit on the source chain
+	// RetractedType is the type of the retracted message, set only on retractions
+	RetractedType TransferType
 }
 type Message2 struct {
 	Source             uint8  // Source where message was initiated
@@ -73,13 +98,13 @@ func NewMessage(
 	metadata Metadata,
 ) *Message {
 	return &Message{
-		source,
-		destination,
-		depositNonce,
-		resourceId,
-		payload,
-		metadata,
-		transferType,
+		Source:       source,
+		Destination:  destination,
+		DepositNonce: depositNonce,
+		ResourceId:   resourceId,
+		Payload:      payload,
+		Metadata:     metadata,
+		Type:         transferType,
 	}
 }
 
@@ -108,3 +133,66 @@ func NewMessage1(
 		DestTokenAddress,
 	}
 }
+
+// NewTokenRegistrationMessage wraps token registration into a message that can be routed by the relayer
+func NewTokenRegistrationMessage(m *Message2) *Message {
+	return NewTypedMessage(m.Source, m.Destination, m.DepositNonce, m.ResourceId, &TokenRegistrationPayload{
+		SourceHandler:       m.Sourcehandler,
+		DestHandler:         m.Desthandler,
+		DestBridgeAddress:   m.DestBridgeAddress,
+		SourceBridgeAddress: m.SourceBrigeAddress,
+		SourceTokenAddress:  m.SourceTokenAddress,
+		DestTokenAddress:    m.DestTokenAddress,
+	}, Metadata{})
+}
+
+// NewRetractionMessage creates message retracting deposit that was removed from the source chain by a reorganisation
+func NewRetractionMessage(source, destination uint8, depositNonce uint64) *Message {
+	return &Message{
+		Source:       source,
+		Destination:  destination,
+		DepositNonce: depositNonce,
+		Type:         Retraction,
+	}
+}
+
+// NewTokenRegistrationRetractionMessage creates message retracting token registration that was removed
+// from the source chain by a reorganisation
+func NewTokenRegistrationRetractionMessage(source, destination uint8, depositNonce uint64) *Message {
+	m := NewRetractionMessage(source, destination, depositNonce)
+	m.RetractedType = TokenRegistration
+	return m
+}
+
+// IsTokenRegistration returns true for token registrations and their retractions. Token registrations
+// share nonces with deposits so they have to be identified separately from deposits with the same nonce.
+func (m *Message) IsTokenRegistration() bool {
+	switch m.Type {
+	case TokenRegistration:
+		return true
+	case Retraction:
+		return m.RetractedType == TokenRegistration
+	default:
+		return false
+	}
+}
+
+// Message2 returns token registration carried by the token registration message
+func (m *Message) Message2() (*Message2, error) {
+	payload, err := m.TokenRegistrationPayload()
+	if err != nil {
+		return nil, err
+	}
+	return NewMessage1(
+		m.Source,
+		m.Destination,
+		m.DepositNonce,
+		m.ResourceId,
+		payload.SourceHandler,
+		payload.DestHandler,
+		payload.DestBridgeAddress,
+		payload.SourceBridgeAddress,
+		payload.SourceTokenAddress,
+		payload.DestTokenAddress,
+	), nil
+}
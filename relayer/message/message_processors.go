@@ -9,6 +9,14 @@ import (
 
 type MessageProcessor func(message *Message) error
 
+// ErrMessageHeld is returned by message processors, wrapped or as is, to hold
+// the message in the pending state instead of relaying it
+var ErrMessageHeld = errors.New("message held")
+
+// ErrNotRetryable is returned by chain writers, wrapped or as is, if writing
+// the message can not succeed by retrying it so it is dead-lettered right away
+var ErrNotRetryable = errors.New("message write not retryable")
+
 // AdjustDecimalsForERC20AmountMessageProcessor is a function, that accepts message and map[domainID uint8]{decimal uint}
 // using this  params processor converts amount for one chain to another for provided decimals with floor rounding
 func AdjustDecimalsForERC20AmountMessageProcessor(args ...interface{}) MessageProcessor {
@@ -0,0 +1,191 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./relayer/message/observer.go
+
+// Package mock_message is a generated GoMock package.
+package mock_message
+
+import (
+	reflect "reflect"
+
+	message "github.com/VaivalGithub/chainsafe-core/relayer/message"
+	common "github.com/ethereum/go-ethereum/common"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockObserver is a mock of Observer interface.
+type MockObserver struct {
+	ctrl     *gomock.Controller
+	recorder *MockObserverMockRecorder
+}
+
+// MockObserverMockRecorder is the mock recorder for MockObserver.
+type MockObserverMockRecorder struct {
+	mock *MockObserver
+}
+
+// NewMockObserver creates a new mock instance.
+func NewMockObserver(ctrl *gomock.Controller) *MockObserver {
+	mock := &MockObserver{ctrl: ctrl}
+	mock.recorder = &MockObserverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObserver) EXPECT() *MockObserverMockRecorder {
+	return m.recorder
+}
+
+// DepositDetected mocks base method.
+func (m_2 *MockObserver) DepositDetected(m *message.Message) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "DepositDetected", m)
+}
+
+// DepositDetected indicates an expected call of DepositDetected.
+func (mr *MockObserverMockRecorder) DepositDetected(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepositDetected", reflect.TypeOf((*MockObserver)(nil).DepositDetected), m)
+}
+
+// MessageFailed mocks base method.
+func (m_2 *MockObserver) MessageFailed(m *message.Message, err error) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "MessageFailed", m, err)
+}
+
+// MessageFailed indicates an expected call of MessageFailed.
+func (mr *MockObserverMockRecorder) MessageFailed(m, err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageFailed", reflect.TypeOf((*MockObserver)(nil).MessageFailed), m, err)
+}
+
+// MessageProcessed mocks base method.
+func (m_2 *MockObserver) MessageProcessed(m *message.Message) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "MessageProcessed", m)
+}
+
+// MessageProcessed indicates an expected call of MessageProcessed.
+func (mr *MockObserverMockRecorder) MessageProcessed(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageProcessed", reflect.TypeOf((*MockObserver)(nil).MessageProcessed), m)
+}
+
+// MessageReleased mocks base method.
+func (m_2 *MockObserver) MessageReleased(m *message.Message) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "MessageReleased", m)
+}
+
+// MessageReleased indicates an expected call of MessageReleased.
+func (mr *MockObserverMockRecorder) MessageReleased(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageReleased", reflect.TypeOf((*MockObserver)(nil).MessageReleased), m)
+}
+
+// MessageWritten mocks base method.
+func (m_2 *MockObserver) MessageWritten(m *message.Message) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "MessageWritten", m)
+}
+
+// MessageWritten indicates an expected call of MessageWritten.
+func (mr *MockObserverMockRecorder) MessageWritten(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageWritten", reflect.TypeOf((*MockObserver)(nil).MessageWritten), m)
+}
+
+// ProposalExecuted mocks base method.
+func (m_2 *MockObserver) ProposalExecuted(m *message.Message) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "ProposalExecuted", m)
+}
+
+// ProposalExecuted indicates an expected call of ProposalExecuted.
+func (mr *MockObserverMockRecorder) ProposalExecuted(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposalExecuted", reflect.TypeOf((*MockObserver)(nil).ProposalExecuted), m)
+}
+
+// ProposalPassed mocks base method.
+func (m_2 *MockObserver) ProposalPassed(m *message.Message) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "ProposalPassed", m)
+}
+
+// ProposalPassed indicates an expected call of ProposalPassed.
+func (mr *MockObserverMockRecorder) ProposalPassed(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProposalPassed", reflect.TypeOf((*MockObserver)(nil).ProposalPassed), m)
+}
+
+// RelayerRemoved mocks base method.
+func (m *MockObserver) RelayerRemoved(domainID uint8, relayer common.Address) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RelayerRemoved", domainID, relayer)
+}
+
+// RelayerRemoved indicates an expected call of RelayerRemoved.
+func (mr *MockObserverMockRecorder) RelayerRemoved(domainID, relayer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayerRemoved", reflect.TypeOf((*MockObserver)(nil).RelayerRemoved), domainID, relayer)
+}
+
+// VoteMined mocks base method.
+func (m_2 *MockObserver) VoteMined(m *message.Message, txHash common.Hash) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "VoteMined", m, txHash)
+}
+
+// VoteMined indicates an expected call of VoteMined.
+func (mr *MockObserverMockRecorder) VoteMined(m, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteMined", reflect.TypeOf((*MockObserver)(nil).VoteMined), m, txHash)
+}
+
+// VoteSubmitted mocks base method.
+func (m_2 *MockObserver) VoteSubmitted(m *message.Message, txHash common.Hash) {
+	m_2.ctrl.T.Helper()
+	m_2.ctrl.Call(m_2, "VoteSubmitted", m, txHash)
+}
+
+// VoteSubmitted indicates an expected call of VoteSubmitted.
+func (mr *MockObserverMockRecorder) VoteSubmitted(m, txHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoteSubmitted", reflect.TypeOf((*MockObserver)(nil).VoteSubmitted), m, txHash)
+}
+
+// MockObservable is a mock of Observable interface.
+type MockObservable struct {
+	ctrl     *gomock.Controller
+	recorder *MockObservableMockRecorder
+}
+
+// MockObservableMockRecorder is the mock recorder for MockObservable.
+type MockObservableMockRecorder struct {
+	mock *MockObservable
+}
+
+// NewMockObservable creates a new mock instance.
+func NewMockObservable(ctrl *gomock.Controller) *MockObservable {
+	mock := &MockObservable{ctrl: ctrl}
+	mock.recorder = &MockObservableMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockObservable) EXPECT() *MockObservableMockRecorder {
+	return m.recorder
+}
+
+// SetObserver mocks base method.
+func (m *MockObservable) SetObserver(o message.Observer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetObserver", o)
+}
+
+// SetObserver indicates an expected call of SetObserver.
+func (mr *MockObservableMockRecorder) SetObserver(o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetObserver", reflect.TypeOf((*MockObservable)(nil).SetObserver), o)
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package message
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Observer is notified about message lifecycle transitions.
+// Methods are called synchronously from the relayer, listeners and writers so they should not block.
+type Observer interface {
+	// DepositDetected is called when deposit is resolved into message by the source chain listener
+	DepositDetected(m *Message)
+	// MessageProcessed is called when message passes all message processors
+	MessageProcessed(m *Message)
+	// MessageWritten is called when message is successfully written to the destination chain
+	MessageWritten(m *Message)
+	// VoteSubmitted is called when vote for the message proposal is sent to the destination chain
+	VoteSubmitted(m *Message, txHash common.Hash)
+	// VoteMined is called when vote transaction is included in a block
+	VoteMined(m *Message, txHash common.Hash)
+	// ProposalPassed is called when message proposal reaches the vote threshold
+	ProposalPassed(m *Message)
+	// ProposalExecuted is called when message proposal is executed on the destination chain
+	ProposalExecuted(m *Message)
+	// MessageFailed is called when message is rejected, its vote fails or it exhausts write retries
+	MessageFailed(m *Message, err error)
+	// MessageReleased is called when routing of the message stops after it was passed to message
+	// processors without it being written or failed, leaving it in the outbox or held, or when it is retracted
+	MessageReleased(m *Message)
+	// RelayerRemoved is called when the relayer role of this relayer is revoked on the domain bridge
+	RelayerRemoved(domainID uint8, relayer common.Address)
+}
+
+// Observable is implemented by components that report message lifecycle transitions
+type Observable interface {
+	SetObserver(o Observer)
+}
+
+// NoopObserver ignores all lifecycle transitions.
+// It can be embedded by observers interested only in some of them.
+type NoopObserver struct{}
+
+func (NoopObserver) DepositDetected(m *Message)                            {}
+func (NoopObserver) MessageProcessed(m *Message)                           {}
+func (NoopObserver) MessageWritten(m *Message)                             {}
+func (NoopObserver) VoteSubmitted(m *Message, txHash common.Hash)          {}
+func (NoopObserver) VoteMined(m *Message, txHash common.Hash)              {}
+func (NoopObserver) ProposalPassed(m *Message)                             {}
+func (NoopObserver) ProposalExecuted(m *Message)                           {}
+func (NoopObserver) MessageFailed(m *Message, err error)                   {}
+func (NoopObserver) MessageReleased(m *Message)                            {}
+func (NoopObserver) RelayerRemoved(domainID uint8, relayer common.Address) {}
+
+// Observers notifies registered observers in the order they were registered.
+// Observers can be registered while it is already used to notify transitions.
+type Observers struct {
+	observers []Observer
+	lock      sync.RWMutex
+}
+
+// Register adds observer notified about all further transitions
+func (o *Observers) Register(observer Observer) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	o.observers = append(o.observers, observer)
+}
+
+// Len returns number of registered observers
+func (o *Observers) Len() int {
+	if o == nil {
+		return 0
+	}
+
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return len(o.observers)
+}
+
+func (o *Observers) notify(f func(observer Observer)) {
+	if o == nil {
+		return
+	}
+
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	for _, observer := range o.observers {
+		f(observer)
+	}
+}
+
+func (o *Observers) DepositDetected(m *Message) {
+	o.notify(func(observer Observer) { observer.DepositDetected(m) })
+}
+
+func (o *Observers) MessageProcessed(m *Message) {
+	o.notify(func(observer Observer) { observer.MessageProcessed(m) })
+}
+
+func (o *Observers) MessageWritten(m *Message) {
+	o.notify(func(observer Observer) { observer.MessageWritten(m) })
+}
+
+func (o *Observers) VoteSubmitted(m *Message, txHash common.Hash) {
+	o.notify(func(observer Observer) { observer.VoteSubmitted(m, txHash) })
+}
+
+func (o *Observers) VoteMined(m *Message, txHash common.Hash) {
+	o.notify(func(observer Observer) { observer.VoteMined(m, txHash) })
+}
+
+func (o *Observers) ProposalPassed(m *Message) {
+	o.notify(func(observer Observer) { observer.ProposalPassed(m) })
+}
+
+func (o *Observers) ProposalExecuted(m *Message) {
+	o.notify(func(observer Observer) { observer.ProposalExecuted(m) })
+}
+
+func (o *Observers) MessageFailed(m *Message, err error) {
+	o.notify(func(observer Observer) { observer.MessageFailed(m, err) })
+}
+
+func (o *Observers) MessageReleased(m *Message) {
+	o.notify(func(observer Observer) { observer.MessageReleased(m) })
+}
+
+func (o *Observers) RelayerRemoved(domainID uint8, relayer common.Address) {
+	o.notify(func(observer Observer) { observer.RelayerRemoved(domainID, relayer) })
+}
@@ -0,0 +1,35 @@
+package message
+
+import (
+	"testing"
+)
+
+type depositCounter struct {
+	NoopObserver
+	deposits int
+}
+
+func (c *depositCounter) DepositDetected(m *Message) {
+	c.deposits++
+}
+
+func TestObserversNotifiesAllRegisteredObservers(t *testing.T) {
+	first := &depositCounter{}
+	second := &depositCounter{}
+	observers := &Observers{}
+	observers.Register(first)
+	observers.Register(second)
+
+	observers.DepositDetected(&Message{})
+	observers.MessageProcessed(&Message{})
+
+	if first.deposits != 1 || second.deposits != 1 {
+		t.Fatalf("expected both observers to be notified once, got %d and %d", first.deposits, second.deposits)
+	}
+}
+
+func TestNilObserversIgnoresTransitions(t *testing.T) {
+	var observers *Observers
+
+	observers.DepositDetected(&Message{})
+}
@@ -0,0 +1,509 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Payload is typed content of a message specific to its transfer type
+type Payload interface {
+	TransferType() TransferType
+	// Values returns positional representation of the payload stored in Message.Payload
+	Values() []interface{}
+}
+
+// FungiblePayload is payload of FungibleTransfer messages
+type FungiblePayload struct {
+	Amount    *big.Int
+	Recipient []byte
+}
+
+// NonFungiblePayload is payload of NonFungibleTransfer messages
+type NonFungiblePayload struct {
+	TokenID   *big.Int
+	Recipient []byte
+	Metadata  []byte
+}
+
+// GenericPayload is payload of GenericTransfer messages
+type GenericPayload struct {
+	Metadata []byte
+}
+
+// TokenRegistrationPayload is payload of TokenRegistration messages
+type TokenRegistrationPayload struct {
+	SourceHandler       common.Address `json:"sourceHandler"`
+	DestHandler         common.Address `json:"destHandler"`
+	DestBridgeAddress   common.Address `json:"destBridgeAddress"`
+	SourceBridgeAddress common.Address `json:"sourceBridgeAddress"`
+	SourceTokenAddress  common.Address `json:"sourceTokenAddress"`
+	DestTokenAddress    common.Address `json:"destTokenAddress"`
+}
+
+func (p *FungiblePayload) TransferType() TransferType {
+	return FungibleTransfer
+}
+
+func (p *FungiblePayload) Values() []interface{} {
+	return []interface{}{intBytes(p.Amount), p.Recipient}
+}
+
+func (p *NonFungiblePayload) TransferType() TransferType {
+	return NonFungibleTransfer
+}
+
+func (p *NonFungiblePayload) Values() []interface{} {
+	return []interface{}{intBytes(p.TokenID), p.Recipient, p.Metadata}
+}
+
+func (p *GenericPayload) TransferType() TransferType {
+	return GenericTransfer
+}
+
+func (p *GenericPayload) Values() []interface{} {
+	return []interface{}{p.Metadata}
+}
+
+func (p *TokenRegistrationPayload) TransferType() TransferType {
+	return TokenRegistration
+}
+
+func (p *TokenRegistrationPayload) Values() []interface{} {
+	return []interface{}{
+		p.SourceHandler.Bytes(),
+		p.DestHandler.Bytes(),
+		p.DestBridgeAddress.Bytes(),
+		p.SourceBridgeAddress.Bytes(),
+		p.SourceTokenAddress.Bytes(),
+		p.DestTokenAddress.Bytes(),
+	}
+}
+
+type fungiblePayloadJSON struct {
+	Amount    string        `json:"amount"`
+	Recipient hexutil.Bytes `json:"recipient"`
+}
+
+// MarshalJSON encodes amount as decimal string and recipient as hex string
+func (p FungiblePayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fungiblePayloadJSON{
+		Amount:    intString(p.Amount),
+		Recipient: p.Recipient,
+	})
+}
+
+func (p *FungiblePayload) UnmarshalJSON(input []byte) error {
+	var dec fungiblePayloadJSON
+	err := json.Unmarshal(input, &dec)
+	if err != nil {
+		return err
+	}
+
+	amount, err := parseInt(dec.Amount)
+	if err != nil {
+		return fmt.Errorf("invalid payload amount: %w", err)
+	}
+	p.Amount = amount
+	p.Recipient = dec.Recipient
+	return nil
+}
+
+type nonFungiblePayloadJSON struct {
+	TokenID   string        `json:"tokenId"`
+	Recipient hexutil.Bytes `json:"recipient"`
+	Metadata  hexutil.Bytes `json:"metadata"`
+}
+
+// MarshalJSON encodes token ID as decimal string and byte fields as hex strings
+func (p NonFungiblePayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nonFungiblePayloadJSON{
+		TokenID:   intString(p.TokenID),
+		Recipient: p.Recipient,
+		Metadata:  p.Metadata,
+	})
+}
+
+func (p *NonFungiblePayload) UnmarshalJSON(input []byte) error {
+	var dec nonFungiblePayloadJSON
+	err := json.Unmarshal(input, &dec)
+	if err != nil {
+		return err
+	}
+
+	tokenID, err := parseInt(dec.TokenID)
+	if err != nil {
+		return fmt.Errorf("invalid payload tokenId: %w", err)
+	}
+	p.TokenID = tokenID
+	p.Recipient = dec.Recipient
+	p.Metadata = dec.Metadata
+	return nil
+}
+
+type genericPayloadJSON struct {
+	Metadata hexutil.Bytes `json:"metadata"`
+}
+
+// MarshalJSON encodes metadata as hex string
+func (p GenericPayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(genericPayloadJSON{Metadata: p.Metadata})
+}
+
+func (p *GenericPayload) UnmarshalJSON(input []byte) error {
+	var dec genericPayloadJSON
+	err := json.Unmarshal(input, &dec)
+	if err != nil {
+		return err
+	}
+	p.Metadata = dec.Metadata
+	return nil
+}
+
+// MarshalBinary encodes payload with RLP
+func (p *FungiblePayload) MarshalBinary() ([]byte, error) {
+	return rlp.EncodeToBytes(&FungiblePayload{Amount: intOrZero(p.Amount), Recipient: p.Recipient})
+}
+
+func (p *FungiblePayload) UnmarshalBinary(data []byte) error {
+	return rlp.DecodeBytes(data, p)
+}
+
+// MarshalBinary encodes payload with RLP
+func (p *NonFungiblePayload) MarshalBinary() ([]byte, error) {
+	return rlp.EncodeToBytes(&NonFungiblePayload{TokenID: intOrZero(p.TokenID), Recipient: p.Recipient, Metadata: p.Metadata})
+}
+
+func (p *NonFungiblePayload) UnmarshalBinary(data []byte) error {
+	return rlp.DecodeBytes(data, p)
+}
+
+// MarshalBinary encodes payload with RLP
+func (p *GenericPayload) MarshalBinary() ([]byte, error) {
+	return rlp.EncodeToBytes(p)
+}
+
+func (p *GenericPayload) UnmarshalBinary(data []byte) error {
+	return rlp.DecodeBytes(data, p)
+}
+
+// MarshalBinary encodes payload with RLP
+func (p *TokenRegistrationPayload) MarshalBinary() ([]byte, error) {
+	return rlp.EncodeToBytes(p)
+}
+
+func (p *TokenRegistrationPayload) UnmarshalBinary(data []byte) error {
+	return rlp.DecodeBytes(data, p)
+}
+
+// NewTypedMessage creates message with positional payload built from the typed payload
+func NewTypedMessage(
+	source uint8,
+	destination uint8,
+	depositNonce uint64,
+	resourceId types.ResourceID,
+	payload Payload,
+	metadata Metadata,
+) *Message {
+	return NewMessage(source, destination, depositNonce, resourceId, payload.TransferType(), payload.Values(), metadata)
+}
+
+// FungiblePayload decodes positional payload of a fungible transfer message
+func (m *Message) FungiblePayload() (*FungiblePayload, error) {
+	if len(m.Payload) != 2 {
+		return nil, errors.New("malformed payload. Len  of payload should be 2")
+	}
+	amount, ok := m.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload amount format")
+	}
+	recipient, ok := m.Payload[1].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload recipient format")
+	}
+	return &FungiblePayload{Amount: new(big.Int).SetBytes(amount), Recipient: recipient}, nil
+}
+
+// NonFungiblePayload decodes positional payload of a non-fungible transfer message
+func (m *Message) NonFungiblePayload() (*NonFungiblePayload, error) {
+	if len(m.Payload) != 3 {
+		return nil, errors.New("malformed payload. Len  of payload should be 3")
+	}
+	tokenID, ok := m.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload tokenID format")
+	}
+	recipient, ok := m.Payload[1].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload recipient format")
+	}
+	metadata, ok := m.Payload[2].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload metadata format")
+	}
+	return &NonFungiblePayload{TokenID: new(big.Int).SetBytes(tokenID), Recipient: recipient, Metadata: metadata}, nil
+}
+
+// GenericPayload decodes positional payload of a generic transfer message
+func (m *Message) GenericPayload() (*GenericPayload, error) {
+	if len(m.Payload) != 1 {
+		return nil, errors.New("malformed payload. Len  of payload should be 1")
+	}
+	metadata, ok := m.Payload[0].([]byte)
+	if !ok {
+		return nil, errors.New("wrong payload metadata format")
+	}
+	return &GenericPayload{Metadata: metadata}, nil
+}
+
+// TokenRegistrationPayload decodes positional payload of a token registration message
+func (m *Message) TokenRegistrationPayload() (*TokenRegistrationPayload, error) {
+	if len(m.Payload) != 6 {
+		return nil, errors.New("malformed payload. Len  of payload should be 6")
+	}
+	addresses := make([]common.Address, len(m.Payload))
+	for i, p := range m.Payload {
+		b, ok := p.([]byte)
+		if !ok || len(b) != common.AddressLength {
+			return nil, fmt.Errorf("wrong payload address %d format", i)
+		}
+		addresses[i] = common.BytesToAddress(b)
+	}
+	return &TokenRegistrationPayload{
+		SourceHandler:       addresses[0],
+		DestHandler:         addresses[1],
+		DestBridgeAddress:   addresses[2],
+		SourceBridgeAddress: addresses[3],
+		SourceTokenAddress:  addresses[4],
+		DestTokenAddress:    addresses[5],
+	}, nil
+}
+
+// TypedPayload decodes positional payload based on the message transfer type
+func (m *Message) TypedPayload() (Payload, error) {
+	switch m.Type {
+	case FungibleTransfer:
+		return m.FungiblePayload()
+	case NonFungibleTransfer:
+		return m.NonFungiblePayload()
+	case GenericTransfer:
+		return m.GenericPayload()
+	case TokenRegistration:
+		return m.TokenRegistrationPayload()
+	default:
+		return nil, fmt.Errorf("unsupported transfer type %s", m.Type)
+	}
+}
+
+// newPayload returns empty typed payload of the transfer type
+func newPayload(t TransferType) (Payload, error) {
+	switch t {
+	case FungibleTransfer:
+		return &FungiblePayload{}, nil
+	case NonFungibleTransfer:
+		return &NonFungiblePayload{}, nil
+	case GenericTransfer:
+		return &GenericPayload{}, nil
+	case TokenRegistration:
+		return &TokenRegistrationPayload{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported transfer type %s", t)
+	}
+}
+
+type metadataJSON struct {
+	Priority uint8         `json:"priority"`
+	Blob     hexutil.Bytes `json:"blob,omitempty"`
+}
+
+type messageJSON struct {
+	Source       uint8            `json:"source"`
+	Destination  uint8            `json:"destination"`
+	DepositNonce uint64           `json:"depositNonce"`
+	ResourceId   types.ResourceID `json:"resourceId"`
+	Type         TransferType     `json:"type"`
+	Payload      json.RawMessage  `json:"payload"`
+	Metadata     metadataJSON     `json:"metadata"`
+	Sender       common.Address   `json:"sender"`
+}
+
+// MarshalJSON encodes message with typed payload of its transfer type.
+// Payload of messages without typed payload, such as retractions, is encoded
+// as a list of hex encoded positional payload elements.
+func (m Message) MarshalJSON() ([]byte, error) {
+	encodedPayload, err := marshalPayloadJSON(&m)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(messageJSON{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId,
+		Type:         m.Type,
+		Payload:      encodedPayload,
+		Metadata:     metadataJSON{Priority: m.Metadata.Priority, Blob: m.Metadata.Blob},
+		Sender:       m.Sender,
+	})
+}
+
+func (m *Message) UnmarshalJSON(input []byte) error {
+	var dec messageJSON
+	err := json.Unmarshal(input, &dec)
+	if err != nil {
+		return err
+	}
+
+	metadata := Metadata{Priority: dec.Metadata.Priority, Blob: dec.Metadata.Blob}
+	if isRawPayloadJSON(dec.Payload) {
+		var raw []hexutil.Bytes
+		err = json.Unmarshal(dec.Payload, &raw)
+		if err != nil {
+			return err
+		}
+
+		var payload []interface{}
+		for _, p := range raw {
+			payload = append(payload, []byte(p))
+		}
+		*m = *NewMessage(dec.Source, dec.Destination, dec.DepositNonce, dec.ResourceId, dec.Type, payload, metadata)
+		m.Sender = dec.Sender
+		return nil
+	}
+
+	payload, err := newPayload(dec.Type)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(dec.Payload, payload)
+	if err != nil {
+		return err
+	}
+
+	*m = *NewTypedMessage(dec.Source, dec.Destination, dec.DepositNonce, dec.ResourceId, payload, metadata)
+	m.Sender = dec.Sender
+	return nil
+}
+
+// marshalPayloadJSON encodes typed payload of the message and falls back to
+// raw positional payload if the message has no typed payload
+func marshalPayloadJSON(m *Message) ([]byte, error) {
+	payload, err := m.TypedPayload()
+	if err == nil {
+		return json.Marshal(payload)
+	}
+
+	raw := make([]hexutil.Bytes, len(m.Payload))
+	for i, p := range m.Payload {
+		b, ok := p.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("unsupported payload element %d of type %T", i, p)
+		}
+		raw[i] = b
+	}
+	return json.Marshal(raw)
+}
+
+// isRawPayloadJSON returns true if payload is encoded as a list of positional payload elements
+func isRawPayloadJSON(payload json.RawMessage) bool {
+	trimmed := bytes.TrimSpace(payload)
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
+type messageRLP struct {
+	Source       uint8
+	Destination  uint8
+	DepositNonce uint64
+	ResourceId   types.ResourceID
+	Type         TransferType
+	Payload      []byte
+	Metadata     Metadata
+	Sender       common.Address
+}
+
+type binaryPayload interface {
+	Payload
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(data []byte) error
+}
+
+// MarshalBinary encodes message with RLP
+func (m *Message) MarshalBinary() ([]byte, error) {
+	payload, err := m.TypedPayload()
+	if err != nil {
+		return nil, err
+	}
+	encodedPayload, err := payload.(binaryPayload).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return rlp.EncodeToBytes(&messageRLP{
+		Source:       m.Source,
+		Destination:  m.Destination,
+		DepositNonce: m.DepositNonce,
+		ResourceId:   m.ResourceId,
+		Type:         m.Type,
+		Payload:      encodedPayload,
+		Metadata:     m.Metadata,
+		Sender:       m.Sender,
+	})
+}
+
+func (m *Message) UnmarshalBinary(data []byte) error {
+	var dec messageRLP
+	err := rlp.DecodeBytes(data, &dec)
+	if err != nil {
+		return err
+	}
+
+	payload, err := newPayload(dec.Type)
+	if err != nil {
+		return err
+	}
+	err = payload.(binaryPayload).UnmarshalBinary(dec.Payload)
+	if err != nil {
+		return err
+	}
+
+	*m = *NewTypedMessage(dec.Source, dec.Destination, dec.DepositNonce, dec.ResourceId, payload, dec.Metadata)
+	m.Sender = dec.Sender
+	return nil
+}
+
+func intBytes(i *big.Int) []byte {
+	if i == nil {
+		return []byte{}
+	}
+	return i.Bytes()
+}
+
+func intString(i *big.Int) string {
+	return intOrZero(i).String()
+}
+
+func intOrZero(i *big.Int) *big.Int {
+	if i == nil {
+		return new(big.Int)
+	}
+	return i
+}
+
+func parseInt(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok || i.Sign() < 0 {
+		return nil, fmt.Errorf("invalid decimal integer %s", s)
+	}
+	return i, nil
+}
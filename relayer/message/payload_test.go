@@ -0,0 +1,184 @@
+package message
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFungiblePayloadFromPositionalPayload(t *testing.T) {
+	m := &Message{Type: FungibleTransfer, Payload: []interface{}{big.NewInt(100).Bytes(), []byte{1, 2}}}
+
+	payload, err := m.FungiblePayload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.Amount.Cmp(big.NewInt(100)) != 0 || !reflect.DeepEqual(payload.Recipient, []byte{1, 2}) {
+		t.Fatal(payload)
+	}
+}
+
+func TestFungiblePayloadMalformedPayload(t *testing.T) {
+	m := &Message{Type: FungibleTransfer, Payload: []interface{}{[]byte{1}}}
+	_, err := m.FungiblePayload()
+	if err == nil || err.Error() != "malformed payload. Len  of payload should be 2" {
+		t.Fatal(err)
+	}
+
+	m = &Message{Type: FungibleTransfer, Payload: []interface{}{"100", []byte{1}}}
+	_, err = m.FungiblePayload()
+	if err == nil || err.Error() != "wrong payload amount format" {
+		t.Fatal(err)
+	}
+}
+
+func TestTypedPayloadUnsupportedTransferType(t *testing.T) {
+	m := &Message{Type: "unknown", Payload: []interface{}{[]byte{1}}}
+	if _, err := m.TypedPayload(); err == nil {
+		t.Fatal("expected error for unsupported transfer type")
+	}
+}
+
+func TestPayloadJSONEncoding(t *testing.T) {
+	b, err := json.Marshal(&FungiblePayload{Amount: big.NewInt(100), Recipient: []byte{0xab}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"amount":"100","recipient":"0xab"}` {
+		t.Fatal(string(b))
+	}
+
+	var decoded FungiblePayload
+	if err := json.Unmarshal([]byte(`{"amount":"-1","recipient":"0xab"}`), &decoded); err == nil {
+		t.Fatal("expected error for negative amount")
+	}
+}
+
+func TestPayloadBinaryRoundTrip(t *testing.T) {
+	payload := &NonFungiblePayload{TokenID: big.NewInt(5), Recipient: []byte{1}, Metadata: []byte{2, 3}}
+
+	b, err := payload.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded NonFungiblePayload
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(payload.Values(), decoded.Values()) {
+		t.Fatal(decoded)
+	}
+}
+
+func testMessages() []*Message {
+	metadata := Metadata{Priority: 1, Blob: []byte{9}}
+	fungible := NewTypedMessage(1, 2, 3, types.ResourceID{31: 1}, &FungiblePayload{Amount: big.NewInt(100), Recipient: []byte{1}}, metadata)
+	fungible.Sender = common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b")
+	return []*Message{
+		fungible,
+		NewTypedMessage(1, 2, 4, types.ResourceID{31: 2}, &NonFungiblePayload{TokenID: big.NewInt(7), Recipient: []byte{1}, Metadata: []byte{2}}, metadata),
+		NewTypedMessage(1, 2, 5, types.ResourceID{31: 3}, &GenericPayload{Metadata: []byte{4, 5}}, metadata),
+	}
+}
+
+func TestMessageJSONRoundTrip(t *testing.T) {
+	for _, m := range testMessages() {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded Message
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(m, &decoded) {
+			t.Fatalf("expected %+v, got %+v", m, decoded)
+		}
+	}
+}
+
+func TestMessageJSONRoundTripWithoutTypedPayload(t *testing.T) {
+	messages := []*Message{
+		NewRetractionMessage(1, 2, 3),
+		NewMessage(1, 2, 4, types.ResourceID{31: 1}, "", []interface{}{[]byte{1}, []byte{2}}, Metadata{}),
+	}
+	for _, m := range messages {
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded Message
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(m, &decoded) {
+			t.Fatalf("expected %+v, got %+v", m, decoded)
+		}
+	}
+}
+
+func TestMessageBinaryRoundTrip(t *testing.T) {
+	for _, m := range testMessages() {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded Message
+		if err := decoded.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(m, &decoded) {
+			t.Fatalf("expected %+v, got %+v", m, decoded)
+		}
+	}
+}
+
+func TestMessageUnmarshalJSONUnsupportedTransferType(t *testing.T) {
+	var m Message
+	if err := json.Unmarshal([]byte(`{"type":"unknown","payload":{}}`), &m); err == nil {
+		t.Fatal("expected error for unsupported transfer type")
+	}
+}
+
+func TestTokenRegistrationMessageRoundTrip(t *testing.T) {
+	m2 := NewMessage1(
+		1, 2, 3, types.ResourceID{31: 1},
+		common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3"),
+		common.HexToAddress("0x4"), common.HexToAddress("0x5"), common.HexToAddress("0x6"),
+	)
+	m := NewTokenRegistrationMessage(m2)
+	if m.Type != TokenRegistration {
+		t.Fatal(m.Type)
+	}
+
+	b, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Message
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+
+	decodedM2, err := decoded.Message2()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m2, decodedM2) {
+		t.Fatalf("expected %+v, got %+v", m2, decodedM2)
+	}
+}
+
+func TestTokenRegistrationPayloadWrongAddressFormat(t *testing.T) {
+	m := &Message{Type: TokenRegistration, Payload: []interface{}{[]byte{1}, []byte{}, []byte{}, []byte{}, []byte{}, []byte{}}}
+	if _, err := m.Message2(); err == nil {
+		t.Fatal("expected error for wrong address format")
+	}
+}
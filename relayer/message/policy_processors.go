@@ -0,0 +1,336 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package message
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/mapstructure"
+)
+
+// MessageProcessorFactory creates message processor from params defined in the relayer config
+type MessageProcessorFactory func(params map[string]interface{}) (MessageProcessor, error)
+
+// MessageProcessorFactories maps processor names usable in the relayer config to their factories
+var MessageProcessorFactories = map[string]MessageProcessorFactory{
+	"resourceAllowlist": newResourceAllowlistMessageProcessor,
+	"resourceDenylist":  newResourceDenylistMessageProcessor,
+	"disabledRoutes":    newDisabledRoutesMessageProcessor,
+	"senderDenylist":    newSenderDenylistMessageProcessor,
+	"amountLimits":      newAmountLimitsMessageProcessor,
+	"holdAboveAmount":   newHoldAboveAmountMessageProcessor,
+	"adjustDecimals":    newAdjustDecimalsMessageProcessor,
+}
+
+// NewMessageProcessor creates built-in message processor registered under the provided name
+func NewMessageProcessor(name string, params map[string]interface{}) (MessageProcessor, error) {
+	factory, ok := MessageProcessorFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown message processor %s", name)
+	}
+
+	mp, err := factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s message processor params: %w", name, err)
+	}
+	return mp, nil
+}
+
+// Route is a (source, destination) domain pair
+type Route struct {
+	Source      uint8
+	Destination uint8
+}
+
+// ResourceAllowlistMessageProcessor rejects messages with resource IDs that are not in the provided list
+func ResourceAllowlistMessageProcessor(resources []types.ResourceID) MessageProcessor {
+	allowed := make(map[types.ResourceID]bool)
+	for _, r := range resources {
+		allowed[r] = true
+	}
+	return func(m *Message) error {
+		if !allowed[m.ResourceId] {
+			return fmt.Errorf("resource %x is not allowed", m.ResourceId)
+		}
+		return nil
+	}
+}
+
+// ResourceDenylistMessageProcessor rejects messages with resource IDs from the provided list
+func ResourceDenylistMessageProcessor(resources []types.ResourceID) MessageProcessor {
+	denied := make(map[types.ResourceID]bool)
+	for _, r := range resources {
+		denied[r] = true
+	}
+	return func(m *Message) error {
+		if denied[m.ResourceId] {
+			return fmt.Errorf("resource %x is denied", m.ResourceId)
+		}
+		return nil
+	}
+}
+
+// DisabledRoutesMessageProcessor rejects messages sent over one of the provided routes
+func DisabledRoutesMessageProcessor(routes []Route) MessageProcessor {
+	disabled := make(map[Route]bool)
+	for _, r := range routes {
+		disabled[r] = true
+	}
+	return func(m *Message) error {
+		if disabled[Route{Source: m.Source, Destination: m.Destination}] {
+			return fmt.Errorf("route from %d to %d is disabled", m.Source, m.Destination)
+		}
+		return nil
+	}
+}
+
+// SenderDenylistMessageProcessor rejects messages deposited by one of the provided addresses
+func SenderDenylistMessageProcessor(senders []common.Address) MessageProcessor {
+	denied := make(map[common.Address]bool)
+	for _, s := range senders {
+		denied[s] = true
+	}
+	return func(m *Message) error {
+		if denied[m.Sender] {
+			return fmt.Errorf("sender %s is denied", m.Sender.Hex())
+		}
+		return nil
+	}
+}
+
+// AmountLimits are minimal and maximal amounts of fungible transfers of a resource
+// in the smallest unit of its token. Limit is not checked if it is nil.
+type AmountLimits struct {
+	Min *big.Int
+	Max *big.Int
+}
+
+// AmountLimitsMessageProcessor rejects fungible transfers with amount lower than min or higher than max
+// limit of their resource. Limits are per resource as tokens have different decimals.
+// Transfers of resources without limits are not checked.
+func AmountLimitsMessageProcessor(limits map[types.ResourceID]AmountLimits) MessageProcessor {
+	return func(m *Message) error {
+		if m.Type != FungibleTransfer {
+			return nil
+		}
+		l, ok := limits[m.ResourceId]
+		if !ok {
+			return nil
+		}
+		amount, err := fungibleAmount(m)
+		if err != nil {
+			return err
+		}
+
+		if l.Min != nil && amount.Cmp(l.Min) < 0 {
+			return fmt.Errorf("amount %s of resource %x is lower than minimum %s", amount, m.ResourceId, l.Min)
+		}
+		if l.Max != nil && amount.Cmp(l.Max) > 0 {
+			return fmt.Errorf("amount %s of resource %x is higher than maximum %s", amount, m.ResourceId, l.Max)
+		}
+		return nil
+	}
+}
+
+// HoldAboveAmountMessageProcessor holds fungible transfers with amount higher than approval threshold
+// of their resource so that they are relayed only after manual approval.
+// Transfers of resources without threshold are not held.
+func HoldAboveAmountMessageProcessor(thresholds map[types.ResourceID]*big.Int) MessageProcessor {
+	return func(m *Message) error {
+		if m.Type != FungibleTransfer {
+			return nil
+		}
+		threshold, ok := thresholds[m.ResourceId]
+		if !ok {
+			return nil
+		}
+		amount, err := fungibleAmount(m)
+		if err != nil {
+			return err
+		}
+
+		if amount.Cmp(threshold) > 0 {
+			return fmt.Errorf("%w: amount %s of resource %x is higher than approval threshold %s", ErrMessageHeld, amount, m.ResourceId, threshold)
+		}
+		return nil
+	}
+}
+
+func newResourceAllowlistMessageProcessor(params map[string]interface{}) (MessageProcessor, error) {
+	resources, err := decodeResources(params)
+	if err != nil {
+		return nil, err
+	}
+	return ResourceAllowlistMessageProcessor(resources), nil
+}
+
+func newResourceDenylistMessageProcessor(params map[string]interface{}) (MessageProcessor, error) {
+	resources, err := decodeResources(params)
+	if err != nil {
+		return nil, err
+	}
+	return ResourceDenylistMessageProcessor(resources), nil
+}
+
+func newDisabledRoutesMessageProcessor(params map[string]interface{}) (MessageProcessor, error) {
+	var p struct {
+		Routes []Route
+	}
+	err := mapstructure.WeakDecode(params, &p)
+	if err != nil {
+		return nil, err
+	}
+	return DisabledRoutesMessageProcessor(p.Routes), nil
+}
+
+func newSenderDenylistMessageProcessor(params map[string]interface{}) (MessageProcessor, error) {
+	var p struct {
+		Senders []string
+	}
+	err := mapstructure.WeakDecode(params, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	senders := make([]common.Address, len(p.Senders))
+	for i, s := range p.Senders {
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid sender address %s", s)
+		}
+		senders[i] = common.HexToAddress(s)
+	}
+	return SenderDenylistMessageProcessor(senders), nil
+}
+
+func newAmountLimitsMessageProcessor(params map[string]interface{}) (MessageProcessor, error) {
+	var p struct {
+		Limits map[string]struct {
+			Min string
+			Max string
+		}
+	}
+	err := mapstructure.WeakDecode(params, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make(map[types.ResourceID]AmountLimits)
+	for r, l := range p.Limits {
+		resource, err := parseResource(r)
+		if err != nil {
+			return nil, err
+		}
+		min, err := parseAmount(l.Min)
+		if err != nil {
+			return nil, err
+		}
+		max, err := parseAmount(l.Max)
+		if err != nil {
+			return nil, err
+		}
+		if min != nil && max != nil && min.Cmp(max) > 0 {
+			return nil, fmt.Errorf("min amount %s of resource %s is higher than max amount %s", min, r, max)
+		}
+		limits[resource] = AmountLimits{Min: min, Max: max}
+	}
+	return AmountLimitsMessageProcessor(limits), nil
+}
+
+func newHoldAboveAmountMessageProcessor(params map[string]interface{}) (MessageProcessor, error) {
+	var p struct {
+		Thresholds map[string]string
+	}
+	err := mapstructure.WeakDecode(params, &p)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Thresholds) == 0 {
+		return nil, fmt.Errorf("thresholds are required")
+	}
+
+	thresholds := make(map[types.ResourceID]*big.Int)
+	for r, t := range p.Thresholds {
+		resource, err := parseResource(r)
+		if err != nil {
+			return nil, err
+		}
+		threshold, err := parseAmount(t)
+		if err != nil {
+			return nil, err
+		}
+		if threshold == nil {
+			return nil, fmt.Errorf("threshold of resource %s is required", r)
+		}
+		thresholds[resource] = threshold
+	}
+	return HoldAboveAmountMessageProcessor(thresholds), nil
+}
+
+func newAdjustDecimalsMessageProcessor(params map[string]interface{}) (MessageProcessor, error) {
+	var p struct {
+		Decimals map[uint8]uint64
+	}
+	err := mapstructure.WeakDecode(params, &p)
+	if err != nil {
+		return nil, err
+	}
+	return AdjustDecimalsForERC20AmountMessageProcessor(p.Decimals), nil
+}
+
+func decodeResources(params map[string]interface{}) ([]types.ResourceID, error) {
+	var p struct {
+		Resources []string
+	}
+	err := mapstructure.WeakDecode(params, &p)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]types.ResourceID, len(p.Resources))
+	for i, r := range p.Resources {
+		resources[i], err = parseResource(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resources, nil
+}
+
+// parseResource parses hex encoded resource ID
+func parseResource(r string) (types.ResourceID, error) {
+	var resource types.ResourceID
+	b := common.FromHex(r)
+	if len(b) != len(resource) {
+		return resource, fmt.Errorf("invalid resource ID %s", r)
+	}
+	copy(resource[:], b)
+	return resource, nil
+}
+
+// fungibleAmount returns transferred amount from the fungible transfer payload
+func fungibleAmount(m *Message) (*big.Int, error) {
+	if len(m.Payload) == 0 {
+		return nil, fmt.Errorf("payload of the Message is empty")
+	}
+	amountBytes, ok := m.Payload[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("could not cast interface to byte slice")
+	}
+	return new(big.Int).SetBytes(amountBytes), nil
+}
+
+// parseAmount parses decimal amount string returning nil if it is empty
+func parseAmount(amount string) (*big.Int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	a, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+	return a, nil
+}
@@ -0,0 +1,192 @@
+package message
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var testResource = "0x0000000000000000000000000000000000000000000000000000000000000001"
+
+func TestNewMessageProcessorUnknownName(t *testing.T) {
+	_, err := NewMessageProcessor("unknown", nil)
+	if err == nil {
+		t.Fatal("expected error for unknown processor")
+	}
+}
+
+func TestNewMessageProcessorInvalidResource(t *testing.T) {
+	_, err := NewMessageProcessor("resourceAllowlist", map[string]interface{}{
+		"resources": []interface{}{"0x01"},
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid resource ID")
+	}
+}
+
+func TestResourceAllowlistMessageProcessor(t *testing.T) {
+	mp, err := NewMessageProcessor("resourceAllowlist", map[string]interface{}{
+		"resources": []interface{}{testResource},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mp(&Message{ResourceId: types.ResourceID{31: 1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp(&Message{ResourceId: types.ResourceID{31: 2}}); err == nil {
+		t.Fatal("expected resource to be rejected")
+	}
+}
+
+func TestResourceDenylistMessageProcessor(t *testing.T) {
+	mp, err := NewMessageProcessor("resourceDenylist", map[string]interface{}{
+		"resources": []interface{}{testResource},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mp(&Message{ResourceId: types.ResourceID{31: 1}}); err == nil {
+		t.Fatal("expected resource to be rejected")
+	}
+	if err := mp(&Message{ResourceId: types.ResourceID{31: 2}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDisabledRoutesMessageProcessor(t *testing.T) {
+	mp, err := NewMessageProcessor("disabledRoutes", map[string]interface{}{
+		"routes": []interface{}{
+			map[string]interface{}{"source": 1, "destination": 2},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mp(&Message{Source: 1, Destination: 2}); err == nil {
+		t.Fatal("expected route to be rejected")
+	}
+	if err := mp(&Message{Source: 2, Destination: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSenderDenylistMessageProcessor(t *testing.T) {
+	sender := "0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b"
+	mp, err := NewMessageProcessor("senderDenylist", map[string]interface{}{
+		"senders": []interface{}{sender},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mp(&Message{Sender: common.HexToAddress(sender)}); err == nil {
+		t.Fatal("expected sender to be rejected")
+	}
+	if err := mp(&Message{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAmountLimitsMessageProcessor(t *testing.T) {
+	mp, err := NewMessageProcessor("amountLimits", map[string]interface{}{
+		"limits": map[string]interface{}{
+			testResource: map[string]interface{}{"min": "10", "max": "100"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource := types.ResourceID{31: 1}
+
+	if err := mp(&Message{Type: FungibleTransfer, ResourceId: resource, Payload: []interface{}{big.NewInt(9).Bytes()}}); err == nil {
+		t.Fatal("expected amount lower than min to be rejected")
+	}
+	if err := mp(&Message{Type: FungibleTransfer, ResourceId: resource, Payload: []interface{}{big.NewInt(101).Bytes()}}); err == nil {
+		t.Fatal("expected amount higher than max to be rejected")
+	}
+	if err := mp(&Message{Type: FungibleTransfer, ResourceId: resource, Payload: []interface{}{big.NewInt(50).Bytes()}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp(&Message{Type: NonFungibleTransfer, ResourceId: resource, Payload: []interface{}{big.NewInt(1).Bytes()}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp(&Message{Type: FungibleTransfer, ResourceId: types.ResourceID{31: 2}, Payload: []interface{}{big.NewInt(101).Bytes()}}); err != nil {
+		t.Fatal("expected resource without limits not to be checked")
+	}
+}
+
+func TestAmountLimitsMinHigherThanMax(t *testing.T) {
+	_, err := NewMessageProcessor("amountLimits", map[string]interface{}{
+		"limits": map[string]interface{}{
+			testResource: map[string]interface{}{"min": "100", "max": "10"},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error for min higher than max")
+	}
+}
+
+func TestAmountLimitsInvalidResource(t *testing.T) {
+	_, err := NewMessageProcessor("amountLimits", map[string]interface{}{
+		"limits": map[string]interface{}{
+			"0x01": map[string]interface{}{"min": "10"},
+		},
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid resource")
+	}
+}
+
+func TestAdjustDecimalsMessageProcessorFromParams(t *testing.T) {
+	mp, err := NewMessageProcessor("adjustDecimals", map[string]interface{}{
+		"decimals": map[string]interface{}{"1": 18, "2": 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := big.NewInt(0).SetString("145556700000000000000", 10)
+	msg := &Message{Source: 1, Destination: 2, Payload: []interface{}{a.Bytes()}}
+	if err := mp(msg); err != nil {
+		t.Fatal(err)
+	}
+	amount := new(big.Int).SetBytes(msg.Payload[0].([]byte))
+	if amount.Cmp(big.NewInt(14555)) != 0 {
+		t.Fatal(amount.String())
+	}
+}
+
+func TestHoldAboveAmountMessageProcessor(t *testing.T) {
+	mp, err := NewMessageProcessor("holdAboveAmount", map[string]interface{}{
+		"thresholds": map[string]interface{}{testResource: "100"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resource := types.ResourceID{31: 1}
+
+	err = mp(&Message{Type: FungibleTransfer, ResourceId: resource, Payload: []interface{}{big.NewInt(101).Bytes()}})
+	if !errors.Is(err, ErrMessageHeld) {
+		t.Fatal("expected amount higher than threshold to be held")
+	}
+	if err := mp(&Message{Type: FungibleTransfer, ResourceId: resource, Payload: []interface{}{big.NewInt(100).Bytes()}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mp(&Message{Type: FungibleTransfer, ResourceId: types.ResourceID{31: 2}, Payload: []interface{}{big.NewInt(101).Bytes()}}); err != nil {
+		t.Fatal("expected resource without threshold not to be held")
+	}
+}
+
+func TestHoldAboveAmountRequiresThreshold(t *testing.T) {
+	_, err := NewMessageProcessor("holdAboveAmount", map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected error for missing threshold")
+	}
+}
@@ -97,15 +97,262 @@ func (mr *MockRelayedChainMockRecorder) PollEvents(ctx, sysErr, msgChan interfac
 }
 
 // Write mocks base method.
-func (m *MockRelayedChain) Write(message *message.Message) error {
+func (m *MockRelayedChain) Write(ctx context.Context, message *message.Message) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Write", message)
+	ret := m.ctrl.Call(m, "Write", ctx, message)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Write indicates an expected call of Write.
-func (mr *MockRelayedChainMockRecorder) Write(message interface{}) *gomock.Call {
+func (mr *MockRelayedChainMockRecorder) Write(ctx, message interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockRelayedChain)(nil).Write), message)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockRelayedChain)(nil).Write), ctx, message)
+}
+
+// MockBackgroundWriter is a mock of BackgroundWriter interface.
+type MockBackgroundWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackgroundWriterMockRecorder
+}
+
+// MockBackgroundWriterMockRecorder is the mock recorder for MockBackgroundWriter.
+type MockBackgroundWriterMockRecorder struct {
+	mock *MockBackgroundWriter
+}
+
+// NewMockBackgroundWriter creates a new mock instance.
+func NewMockBackgroundWriter(ctrl *gomock.Controller) *MockBackgroundWriter {
+	mock := &MockBackgroundWriter{ctrl: ctrl}
+	mock.recorder = &MockBackgroundWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackgroundWriter) EXPECT() *MockBackgroundWriterMockRecorder {
+	return m.recorder
+}
+
+// Wait mocks base method.
+func (m *MockBackgroundWriter) Wait() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Wait")
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockBackgroundWriterMockRecorder) Wait() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockBackgroundWriter)(nil).Wait))
+}
+
+// MockMessageStore is a mock of MessageStore interface.
+type MockMessageStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageStoreMockRecorder
+}
+
+// MockMessageStoreMockRecorder is the mock recorder for MockMessageStore.
+type MockMessageStoreMockRecorder struct {
+	mock *MockMessageStore
+}
+
+// NewMockMessageStore creates a new mock instance.
+func NewMockMessageStore(ctrl *gomock.Controller) *MockMessageStore {
+	mock := &MockMessageStore{ctrl: ctrl}
+	mock.recorder = &MockMessageStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageStore) EXPECT() *MockMessageStoreMockRecorder {
+	return m.recorder
+}
+
+// ApproveHeldMessage mocks base method.
+func (m *MockMessageStore) ApproveHeldMessage(source, destination uint8, depositNonce uint64) (*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveHeldMessage", source, destination, depositNonce)
+	ret0, _ := ret[0].(*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveHeldMessage indicates an expected call of ApproveHeldMessage.
+func (mr *MockMessageStoreMockRecorder) ApproveHeldMessage(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveHeldMessage", reflect.TypeOf((*MockMessageStore)(nil).ApproveHeldMessage), source, destination, depositNonce)
+}
+
+// DeleteHeldMessage mocks base method.
+func (m_2 *MockMessageStore) DeleteHeldMessage(m *message.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "DeleteHeldMessage", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHeldMessage indicates an expected call of DeleteHeldMessage.
+func (mr *MockMessageStoreMockRecorder) DeleteHeldMessage(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHeldMessage", reflect.TypeOf((*MockMessageStore)(nil).DeleteHeldMessage), m)
+}
+
+// DeleteMessage mocks base method.
+func (m_2 *MockMessageStore) DeleteMessage(m *message.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "DeleteMessage", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMessage indicates an expected call of DeleteMessage.
+func (mr *MockMessageStoreMockRecorder) DeleteMessage(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessageStore)(nil).DeleteMessage), m)
+}
+
+// GetDeadLetters mocks base method.
+func (m *MockMessageStore) GetDeadLetters() ([]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeadLetters")
+	ret0, _ := ret[0].([]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeadLetters indicates an expected call of GetDeadLetters.
+func (mr *MockMessageStoreMockRecorder) GetDeadLetters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeadLetters", reflect.TypeOf((*MockMessageStore)(nil).GetDeadLetters))
+}
+
+// GetHeldMessages mocks base method.
+func (m *MockMessageStore) GetHeldMessages() ([]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldMessages")
+	ret0, _ := ret[0].([]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeldMessages indicates an expected call of GetHeldMessages.
+func (mr *MockMessageStoreMockRecorder) GetHeldMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldMessages", reflect.TypeOf((*MockMessageStore)(nil).GetHeldMessages))
+}
+
+// GetMessageStatus mocks base method.
+func (m_2 *MockMessageStore) GetMessageStatus(m *message.Message) (message.MessageStatus, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "GetMessageStatus", m)
+	ret0, _ := ret[0].(message.MessageStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageStatus indicates an expected call of GetMessageStatus.
+func (mr *MockMessageStoreMockRecorder) GetMessageStatus(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageStatus", reflect.TypeOf((*MockMessageStore)(nil).GetMessageStatus), m)
+}
+
+// GetMessages mocks base method.
+func (m *MockMessageStore) GetMessages() ([]*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessages")
+	ret0, _ := ret[0].([]*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessages indicates an expected call of GetMessages.
+func (mr *MockMessageStoreMockRecorder) GetMessages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageStore)(nil).GetMessages))
+}
+
+// RejectHeldMessage mocks base method.
+func (m *MockMessageStore) RejectHeldMessage(source, destination uint8, depositNonce uint64) (*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectHeldMessage", source, destination, depositNonce)
+	ret0, _ := ret[0].(*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectHeldMessage indicates an expected call of RejectHeldMessage.
+func (mr *MockMessageStoreMockRecorder) RejectHeldMessage(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectHeldMessage", reflect.TypeOf((*MockMessageStore)(nil).RejectHeldMessage), source, destination, depositNonce)
+}
+
+// RequeueDeadLetter mocks base method.
+func (m *MockMessageStore) RequeueDeadLetter(source, destination uint8, depositNonce uint64) (*message.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueDeadLetter", source, destination, depositNonce)
+	ret0, _ := ret[0].(*message.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueDeadLetter indicates an expected call of RequeueDeadLetter.
+func (mr *MockMessageStoreMockRecorder) RequeueDeadLetter(source, destination, depositNonce interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueDeadLetter", reflect.TypeOf((*MockMessageStore)(nil).RequeueDeadLetter), source, destination, depositNonce)
+}
+
+// StoreDeadLetter mocks base method.
+func (m_2 *MockMessageStore) StoreDeadLetter(m *message.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "StoreDeadLetter", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreDeadLetter indicates an expected call of StoreDeadLetter.
+func (mr *MockMessageStoreMockRecorder) StoreDeadLetter(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreDeadLetter", reflect.TypeOf((*MockMessageStore)(nil).StoreDeadLetter), m)
+}
+
+// StoreHeldMessage mocks base method.
+func (m_2 *MockMessageStore) StoreHeldMessage(m *message.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "StoreHeldMessage", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreHeldMessage indicates an expected call of StoreHeldMessage.
+func (mr *MockMessageStoreMockRecorder) StoreHeldMessage(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreHeldMessage", reflect.TypeOf((*MockMessageStore)(nil).StoreHeldMessage), m)
+}
+
+// StoreMessage mocks base method.
+func (m_2 *MockMessageStore) StoreMessage(m *message.Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "StoreMessage", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMessage indicates an expected call of StoreMessage.
+func (mr *MockMessageStoreMockRecorder) StoreMessage(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMessage", reflect.TypeOf((*MockMessageStore)(nil).StoreMessage), m)
+}
+
+// StoreMessageStatus mocks base method.
+func (m_2 *MockMessageStore) StoreMessageStatus(m *message.Message, status message.MessageStatus) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "StoreMessageStatus", m, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreMessageStatus indicates an expected call of StoreMessageStatus.
+func (mr *MockMessageStoreMockRecorder) StoreMessageStatus(m, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreMessageStatus", reflect.TypeOf((*MockMessageStore)(nil).StoreMessageStatus), m, status)
 }
@@ -0,0 +1,103 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	mock_message "github.com/VaivalGithub/chainsafe-core/relayer/message/mock"
+	mock_relayer "github.com/VaivalGithub/chainsafe-core/relayer/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *RouteTestSuite) TestNotifiesObserverAboutProcessedMessage() {
+	m := &message.Message{Destination: 1}
+	mockObserver := mock_message.NewMockObserver(gomock.NewController(s.T()))
+	mockObserver.EXPECT().MessageProcessed(gomock.Any())
+	mockObserver.EXPECT().MessageWritten(gomock.Any())
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterObserver(mockObserver)
+
+	relayer.route(context.Background(), m)
+}
+
+func (s *RouteTestSuite) TestNotifiesObserverAboutRejectedMessage() {
+	m := &message.Message{Destination: 1}
+	mockObserver := mock_message.NewMockObserver(gomock.NewController(s.T()))
+	mockObserver.EXPECT().MessageFailed(m, gomock.Any())
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusRejected).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+		func(m *message.Message) error { return fmt.Errorf("error") },
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterObserver(mockObserver)
+
+	relayer.route(context.Background(), m)
+}
+
+func (s *RouteTestSuite) TestNotifiesObserverAboutDeadLetteredMessage() {
+	m := &message.Message{Destination: 1}
+	mockObserver := mock_message.NewMockObserver(gomock.NewController(s.T()))
+	mockObserver.EXPECT().MessageProcessed(gomock.Any())
+	mockObserver.EXPECT().MessageFailed(m, gomock.Not(gomock.Nil()))
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(fmt.Errorf("error"))
+	s.mockMessageStore.EXPECT().StoreDeadLetter(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterRetryPolicy(1, RetryPolicy{MaxAttempts: 1})
+	relayer.RegisterObserver(mockObserver)
+
+	relayer.route(context.Background(), m)
+}
+
+type observableChain struct {
+	*mock_relayer.MockRelayedChain
+	observer message.Observer
+}
+
+func (c *observableChain) SetObserver(o message.Observer) {
+	c.observer = o
+}
+
+func (s *RouteTestSuite) TestDoesNotSetChainObserverWithoutRegisteredObservers() {
+	chain := &observableChain{MockRelayedChain: s.mockRelayedChain}
+
+	NewRelayer([]RelayedChain{chain}, s.mockMetrics, s.mockMessageStore)
+
+	s.Nil(chain.observer)
+}
+
+func (s *RouteTestSuite) TestSetsChainObserverOnceObserverIsRegistered() {
+	chain := &observableChain{MockRelayedChain: s.mockRelayedChain}
+	relayer := NewRelayer([]RelayedChain{chain}, s.mockMetrics, s.mockMessageStore)
+
+	relayer.RegisterObserver(mock_message.NewMockObserver(gomock.NewController(s.T())))
+
+	s.NotNil(chain.observer)
+}
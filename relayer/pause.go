@@ -0,0 +1,118 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/rs/zerolog/log"
+)
+
+// PauseChain stops polling events of the chain and routing messages from or to it.
+// Messages of the chain stay in the outbox until it is resumed.
+// It returns once polling of the chain stopped.
+func (r *Relayer) PauseChain(domainID uint8) error {
+	r.lock.Lock()
+	if _, ok := r.findChain(domainID); !ok {
+		r.lock.Unlock()
+		return fmt.Errorf("chain with domain ID %d not found", domainID)
+	}
+	if r.pausedChains == nil {
+		r.pausedChains = make(map[uint8]bool)
+	}
+	r.pausedChains[domainID] = true
+	stopped := r.stopPolling(domainID)
+	r.lock.Unlock()
+
+	// blockstore can be modified once the chain is paused so polling
+	// has to return before it stores another block
+	<-stopped
+	log.Info().Msgf("Chain %v paused", domainID)
+	return nil
+}
+
+// ResumeChain restarts polling events of the paused chain from the last stored block
+// and routes messages of the chain left in the outbox
+func (r *Relayer) ResumeChain(domainID uint8) error {
+	r.lock.Lock()
+	c, ok := r.findChain(domainID)
+	if !ok {
+		r.lock.Unlock()
+		return fmt.Errorf("chain with domain ID %d not found", domainID)
+	}
+	if !r.pausedChains[domainID] {
+		r.lock.Unlock()
+		return fmt.Errorf("chain with domain ID %d is not paused", domainID)
+	}
+	delete(r.pausedChains, domainID)
+	running := r.messages != nil
+	if running && !r.standby {
+		r.startPolling(c)
+	}
+	r.lock.Unlock()
+
+	log.Info().Msgf("Chain %v resumed", domainID)
+	if running {
+		go r.replayStored(func(m *message.Message) bool {
+			return m.Source == domainID || m.Destination == domainID
+		})
+	}
+	return nil
+}
+
+// IsChainPaused checks if the chain is paused
+func (r *Relayer) IsChainPaused(domainID uint8) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.pausedChains[domainID]
+}
+
+// PauseRoute stops routing messages from source to destination chain.
+// Messages of the route stay in the outbox until it is resumed.
+func (r *Relayer) PauseRoute(source, destination uint8) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.pausedRoutes == nil {
+		r.pausedRoutes = make(map[message.Route]bool)
+	}
+	r.pausedRoutes[message.Route{Source: source, Destination: destination}] = true
+	log.Info().Msgf("Route from %v to %v paused", source, destination)
+}
+
+// ResumeRoute routes messages of the paused route left in the outbox
+func (r *Relayer) ResumeRoute(source, destination uint8) {
+	r.lock.Lock()
+	delete(r.pausedRoutes, message.Route{Source: source, Destination: destination})
+	running := r.messages != nil
+	r.lock.Unlock()
+
+	log.Info().Msgf("Route from %v to %v resumed", source, destination)
+	if running {
+		go r.replayStored(func(m *message.Message) bool {
+			return m.Source == source && m.Destination == destination
+		})
+	}
+}
+
+// isPaused checks if the relayer is on standby or message route or one of its chains is paused
+func (r *Relayer) isPaused(m *message.Message) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.standby || r.pausedChains[m.Source] || r.pausedChains[m.Destination] ||
+		r.pausedRoutes[message.Route{Source: m.Source, Destination: m.Destination}]
+}
+
+// findChain returns added chain by domain ID. It has to be called with the lock held.
+func (r *Relayer) findChain(domainID uint8) (RelayedChain, bool) {
+	for _, c := range r.relayedChains {
+		if c.DomainID() == domainID {
+			return c, true
+		}
+	}
+	return nil, false
+}
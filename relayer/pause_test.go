@@ -0,0 +1,182 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	mock_message "github.com/VaivalGithub/chainsafe-core/relayer/message/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *RouteTestSuite) TestPausedRouteLeavesMessageInOutbox() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.PauseRoute(2, 1)
+
+	relayer.route(context.Background(), &message.Message{Source: 2, Destination: 1})
+}
+
+func (s *RouteTestSuite) TestResumedRouteIsRouted() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.PauseRoute(2, 1)
+	relayer.ResumeRoute(2, 1)
+
+	relayer.route(context.Background(), &message.Message{Source: 2, Destination: 1})
+}
+
+func (s *RouteTestSuite) TestPausedChainLeavesMessageInOutbox() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.PauseChain(1)
+	s.Nil(err)
+	s.True(relayer.IsChainPaused(1))
+
+	relayer.route(context.Background(), &message.Message{Source: 2, Destination: 1})
+}
+
+func (s *RouteTestSuite) TestReleasesMessageIfRoutePausedWhileRetrying() {
+	m := &message.Message{Source: 2, Destination: 1}
+	mockObserver := mock_message.NewMockObserver(gomock.NewController(s.T()))
+	mockObserver.EXPECT().MessageProcessed(gomock.Any())
+	mockObserver.EXPECT().MessageReleased(m)
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterObserver(mockObserver)
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, m *message.Message) error {
+		relayer.PauseRoute(2, 1)
+		return fmt.Errorf("error")
+	})
+
+	relayer.route(context.Background(), m)
+}
+
+func (s *RouteTestSuite) TestPauseChainReturnsErrorIfChainNotFound() {
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.PauseChain(1)
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestResumeChainReturnsErrorIfChainNotPaused() {
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.ResumeChain(1)
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestPauseChainWaitsForPollingToStop() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	polling := make(chan struct{})
+	stopped := false
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{}, nil)
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		close(polling)
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		stopped = true
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	go relayer.Start(ctx, make(chan error))
+	<-polling
+
+	err := relayer.PauseChain(1)
+
+	s.Nil(err)
+	s.True(stopped)
+}
+
+func (s *RouteTestSuite) TestPauseAndResumeChainRestartsPolling() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pollCtx := make(chan context.Context, 2)
+	replayed := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{}, nil)
+	s.mockMessageStore.EXPECT().GetMessages().DoAndReturn(func() ([]*message.Message, error) {
+		close(replayed)
+		return []*message.Message{}, nil
+	})
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		pollCtx <- ctx
+	}).Times(2)
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	go relayer.Start(ctx, make(chan error))
+	firstCtx := <-pollCtx
+
+	err := relayer.PauseChain(1)
+	s.Nil(err)
+	s.NotNil(firstCtx.Err())
+
+	err = relayer.ResumeChain(1)
+	s.Nil(err)
+	secondCtx := <-pollCtx
+	s.Nil(secondCtx.Err())
+	s.False(relayer.IsChainPaused(1))
+	<-replayed
+}
+
+func (s *RouteTestSuite) TestReplayMessageResetsStatus() {
+	m := &message.Message{Source: 2, Destination: 1, DepositNonce: 3}
+	s.mockMessageStore.EXPECT().StoreMessage(m).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(m, message.MessageStatusReceived).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.ReplayMessage(m)
+
+	s.Nil(err)
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+)
+
+// DefaultWorkerPoolConfig is used for destinations without registered worker pool config
+var DefaultWorkerPoolConfig = WorkerPoolConfig{
+	Workers:    4,
+	QueueDepth: 100,
+	Ordered:    true,
+}
+
+// WorkerPoolConfig defines how messages are written to a single destination
+type WorkerPoolConfig struct {
+	// Workers is the maximum number of concurrent writes to the destination
+	Workers int
+	// QueueDepth is the capacity of a worker queue after which routing of new messages blocks.
+	// Queued messages with higher Metadata.Priority are written first.
+	QueueDepth int
+	// Ordered preserves deposit nonce order per (source, destination) route by
+	// always assigning messages from the same source to the same worker queue
+	Ordered bool
+}
+
+type workerPool struct {
+	queues []*messageQueue
+	stop   <-chan struct{}
+	wg     sync.WaitGroup
+}
+
+// newWorkerPool starts pool workers that call handler for each submitted message.
+// Ordered pools have a queue per worker, unordered pools share a single queue between workers.
+// Ordered queues never reorder messages from the same source while unordered queues
+// always dequeue the highest priority message first.
+// Workers exit once stop is closed, leaving queued messages in the outbox for the next start.
+func newWorkerPool(config WorkerPoolConfig, stop <-chan struct{}, handler func(m *message.Message)) *workerPool {
+	workers := config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	queueCount := 1
+	if config.Ordered {
+		queueCount = workers
+	}
+
+	p := &workerPool{
+		queues: make([]*messageQueue, queueCount),
+		stop:   stop,
+	}
+	for i := range p.queues {
+		p.queues[i] = newMessageQueue(config.QueueDepth, config.Ordered)
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work(p.queues[i%queueCount], handler)
+	}
+	return p
+}
+
+func (p *workerPool) work(queue *messageQueue, handler func(m *message.Message)) {
+	defer p.wg.Done()
+	for {
+		m, ok := queue.pop()
+		if !ok {
+			select {
+			case <-p.stop:
+				return
+			case <-queue.ready:
+			}
+			continue
+		}
+		// stop is checked again to avoid starting new writes after shutdown was requested
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+		handler(m)
+	}
+}
+
+// submit queues message for writing and blocks while the queue is full.
+// It returns false if the message was not queued because the pool is stopping or ctx is cancelled.
+func (p *workerPool) submit(ctx context.Context, m *message.Message) bool {
+	queue := p.queues[int(m.Source)%len(p.queues)]
+	for {
+		select {
+		case <-p.stop:
+			return false
+		default:
+		}
+		if queue.push(m) {
+			return true
+		}
+		select {
+		case <-queue.space:
+		case <-p.stop:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// queued returns number of messages waiting in pool queues
+func (p *workerPool) queued() int {
+	queued := 0
+	for _, q := range p.queues {
+		queued += q.len()
+	}
+	return queued
+}
+
+// wait blocks until all pool workers exit
+func (p *workerPool) wait() {
+	p.wg.Wait()
+}
+
+// messageQueue is a bounded queue that dequeues messages with higher Metadata.Priority first.
+// Messages with the same priority are dequeued in the order they were queued.
+// Ordered queues only let the oldest message of each source compete on priority
+// so that messages from the same source are never reordered.
+type messageQueue struct {
+	lock     sync.Mutex
+	messages []*message.Message
+	capacity int
+	ordered  bool
+	// ready is signalled when a message is queued
+	ready chan struct{}
+	// space is signalled when a message is dequeued
+	space chan struct{}
+}
+
+func newMessageQueue(capacity int, ordered bool) *messageQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &messageQueue{
+		capacity: capacity,
+		ordered:  ordered,
+		ready:    make(chan struct{}, 1),
+		space:    make(chan struct{}, 1),
+	}
+}
+
+// push queues message returning false if the queue is full
+func (q *messageQueue) push(m *message.Message) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.messages) >= q.capacity {
+		return false
+	}
+	q.messages = append(q.messages, m)
+	signal(q.ready)
+	if len(q.messages) < q.capacity {
+		// wake up next waiting submitter
+		signal(q.space)
+	}
+	return true
+}
+
+// pop dequeues the next message returning false if the queue is empty
+func (q *messageQueue) pop() (*message.Message, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.messages) == 0 {
+		return nil, false
+	}
+
+	var seen [256]bool
+	next := -1
+	for i, m := range q.messages {
+		if q.ordered {
+			if seen[m.Source] {
+				continue
+			}
+			seen[m.Source] = true
+		}
+		if next == -1 || m.Metadata.Priority > q.messages[next].Metadata.Priority {
+			next = i
+		}
+	}
+
+	m := q.messages[next]
+	q.messages = append(q.messages[:next], q.messages[next+1:]...)
+	signal(q.space)
+	if len(q.messages) > 0 {
+		// wake up next idle worker
+		signal(q.ready)
+	}
+	return m, true
+}
+
+func (q *messageQueue) len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.messages)
+}
+
+// signal notifies a waiting goroutine without blocking if one is already notified
+func signal(c chan struct{}) {
+	select {
+	case c <- struct{}{}:
+	default:
+	}
+}
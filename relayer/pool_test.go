@@ -0,0 +1,163 @@
+package relayer
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkerPoolTestSuite struct {
+	suite.Suite
+}
+
+func TestRunWorkerPoolTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerPoolTestSuite))
+}
+
+func (s *WorkerPoolTestSuite) TestOrderedPoolPreservesNonceOrderPerSource() {
+	var lock sync.Mutex
+	handled := make(map[uint8][]uint64)
+	var wg sync.WaitGroup
+	wg.Add(30)
+	pool := newWorkerPool(WorkerPoolConfig{Workers: 3, QueueDepth: 5, Ordered: true}, make(chan struct{}), func(m *message.Message) {
+		defer wg.Done()
+		lock.Lock()
+		defer lock.Unlock()
+		handled[m.Source] = append(handled[m.Source], m.DepositNonce)
+	})
+
+	for nonce := uint64(0); nonce < 10; nonce++ {
+		for source := uint8(0); source < 3; source++ {
+			pool.submit(context.Background(), &message.Message{Source: source, DepositNonce: nonce})
+		}
+	}
+	wg.Wait()
+
+	for source := uint8(0); source < 3; source++ {
+		s.Equal(handled[source], []uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	}
+}
+
+func (s *WorkerPoolTestSuite) TestPoolLimitsConcurrentWrites() {
+	var current, max int32
+	var wg sync.WaitGroup
+	wg.Add(20)
+	pool := newWorkerPool(WorkerPoolConfig{Workers: 2, QueueDepth: 20}, make(chan struct{}), func(m *message.Message) {
+		defer wg.Done()
+		c := atomic.AddInt32(&current, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if c <= m || atomic.CompareAndSwapInt32(&max, m, c) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&current, -1)
+	})
+
+	for i := 0; i < 20; i++ {
+		pool.submit(context.Background(), &message.Message{Source: uint8(i)})
+	}
+	wg.Wait()
+
+	s.True(max <= 2)
+}
+
+func (s *WorkerPoolTestSuite) TestStoppedPoolDoesNotStartQueuedMessages() {
+	stop := make(chan struct{})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var handled int32
+	pool := newWorkerPool(WorkerPoolConfig{Workers: 1, QueueDepth: 5, Ordered: true}, stop, func(m *message.Message) {
+		if atomic.AddInt32(&handled, 1) == 1 {
+			close(started)
+		}
+		<-release
+	})
+
+	for i := 0; i < 3; i++ {
+		pool.submit(context.Background(), &message.Message{DepositNonce: uint64(i)})
+	}
+	<-started
+	close(stop)
+	close(release)
+	pool.wait()
+
+	s.Equal(atomic.LoadInt32(&handled), int32(1))
+	s.False(pool.submit(context.Background(), &message.Message{}))
+}
+
+func (s *WorkerPoolTestSuite) TestQueuedReturnsNumberOfWaitingMessages() {
+	stop := make(chan struct{})
+	close(stop)
+	pool := newWorkerPool(WorkerPoolConfig{Workers: 2, QueueDepth: 10, Ordered: true}, stop, func(m *message.Message) {})
+	pool.wait()
+
+	pool.queues[0].push(&message.Message{Source: 0})
+	pool.queues[1].push(&message.Message{Source: 1})
+	pool.queues[1].push(&message.Message{Source: 3})
+
+	s.Equal(3, pool.queued())
+}
+
+func (s *WorkerPoolTestSuite) TestUnorderedQueueDequeuesHigherPriorityFirst() {
+	q := newMessageQueue(10, false)
+	q.push(&message.Message{Source: 1, DepositNonce: 1})
+	q.push(&message.Message{Source: 1, DepositNonce: 2, Metadata: message.Metadata{Priority: 3}})
+	q.push(&message.Message{Source: 2, DepositNonce: 3, Metadata: message.Metadata{Priority: 1}})
+	q.push(&message.Message{Source: 2, DepositNonce: 4, Metadata: message.Metadata{Priority: 3}})
+
+	nonces := make([]uint64, 0)
+	for m, ok := q.pop(); ok; m, ok = q.pop() {
+		nonces = append(nonces, m.DepositNonce)
+	}
+
+	s.Equal([]uint64{2, 4, 3, 1}, nonces)
+}
+
+func (s *WorkerPoolTestSuite) TestOrderedQueueDoesNotReorderMessagesFromSameSource() {
+	q := newMessageQueue(10, true)
+	q.push(&message.Message{Source: 1, DepositNonce: 1})
+	q.push(&message.Message{Source: 1, DepositNonce: 2, Metadata: message.Metadata{Priority: 3}})
+	q.push(&message.Message{Source: 2, DepositNonce: 3, Metadata: message.Metadata{Priority: 2}})
+
+	nonces := make([]uint64, 0)
+	for m, ok := q.pop(); ok; m, ok = q.pop() {
+		nonces = append(nonces, m.DepositNonce)
+	}
+
+	s.Equal([]uint64{3, 1, 2}, nonces)
+}
+
+func (s *WorkerPoolTestSuite) TestQueuePushFailsWhenFull() {
+	q := newMessageQueue(1, false)
+
+	s.True(q.push(&message.Message{}))
+	s.False(q.push(&message.Message{}))
+}
+
+func (s *WorkerPoolTestSuite) TestSubmitBlocksUntilQueueHasSpace() {
+	stop := make(chan struct{})
+	release := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(3)
+	pool := newWorkerPool(WorkerPoolConfig{Workers: 1, QueueDepth: 1}, stop, func(m *message.Message) {
+		defer wg.Done()
+		<-release
+	})
+
+	for i := 0; i < 3; i++ {
+		s.True(pool.submit(context.Background(), &message.Message{DepositNonce: uint64(i)}))
+		if i == 1 {
+			close(release)
+		}
+	}
+	wg.Wait()
+	close(stop)
+	pool.wait()
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./relayer/profitability/profitability.go
+
+// Package mock_profitability is a generated GoMock package.
+package mock_profitability
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	message "github.com/VaivalGithub/chainsafe-core/relayer/message"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCostEstimator is a mock of CostEstimator interface.
+type MockCostEstimator struct {
+	ctrl     *gomock.Controller
+	recorder *MockCostEstimatorMockRecorder
+}
+
+// MockCostEstimatorMockRecorder is the mock recorder for MockCostEstimator.
+type MockCostEstimatorMockRecorder struct {
+	mock *MockCostEstimator
+}
+
+// NewMockCostEstimator creates a new mock instance.
+func NewMockCostEstimator(ctrl *gomock.Controller) *MockCostEstimator {
+	mock := &MockCostEstimator{ctrl: ctrl}
+	mock.recorder = &MockCostEstimatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCostEstimator) EXPECT() *MockCostEstimatorMockRecorder {
+	return m.recorder
+}
+
+// EstimateVoteCost mocks base method.
+func (m_2 *MockCostEstimator) EstimateVoteCost(ctx context.Context, m *message.Message) (*big.Int, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "EstimateVoteCost", ctx, m)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateVoteCost indicates an expected call of EstimateVoteCost.
+func (mr *MockCostEstimatorMockRecorder) EstimateVoteCost(ctx, m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateVoteCost", reflect.TypeOf((*MockCostEstimator)(nil).EstimateVoteCost), ctx, m)
+}
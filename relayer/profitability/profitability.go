@@ -0,0 +1,144 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package profitability
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/rs/zerolog/log"
+)
+
+// EstimationTimeout limits how long the vote cost estimation of a single message can take
+const EstimationTimeout = 30 * time.Second
+
+type CostEstimator interface {
+	// EstimateVoteCost returns the cost of voting on the message proposal in wei of the destination native token
+	EstimateVoteCost(ctx context.Context, m *message.Message) (*big.Int, error)
+}
+
+// Rule defines what a relayer earns for relaying deposits of a resource.
+// Deposit is profitable if either of the set earnings covers the vote cost.
+type Rule struct {
+	// Fee is a flat relayer fee per deposit in wei of the destination native token. It is not used if nil.
+	Fee *big.Int
+	// Rate converts fungible transfer amount into wei of the destination native token. It is not used if nil.
+	Rate *big.Float
+}
+
+// Checker skips or holds deposits that cost more to vote on than they earn the relayer
+type Checker struct {
+	rules       map[types.ResourceID]Rule
+	defaultRule *Rule
+	hold        bool
+	estimators  map[uint8]CostEstimator
+	lock        sync.RWMutex
+}
+
+// NewChecker creates profitability checker with rules per resource and optional default rule
+// for resources without own rule. Unprofitable deposits are held for manual approval if hold is set
+// or skipped otherwise.
+func NewChecker(rules map[types.ResourceID]Rule, defaultRule *Rule, hold bool) *Checker {
+	return &Checker{
+		rules:       rules,
+		defaultRule: defaultRule,
+		hold:        hold,
+		estimators:  make(map[uint8]CostEstimator),
+	}
+}
+
+// RegisterEstimator registers vote cost estimator of the destination chain
+func (c *Checker) RegisterEstimator(domainID uint8, estimator CostEstimator) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.estimators[domainID] = estimator
+}
+
+// UnregisterEstimator removes vote cost estimator of the destination chain
+func (c *Checker) UnregisterEstimator(domainID uint8) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.estimators, domainID)
+}
+
+// Process is a message.MessageProcessor that rejects or holds deposits whose vote cost on
+// the destination chain exceeds what the relayer earns for them.
+// Deposits without a rule or estimator are not checked and deposits are relayed
+// if the cost can't be estimated so that estimation outages don't stop the bridge.
+func (c *Checker) Process(m *message.Message) error {
+	if m.Type == message.TokenRegistration {
+		return nil
+	}
+	rule, ok := c.rule(m.ResourceId)
+	if !ok {
+		return nil
+	}
+	c.lock.RLock()
+	estimator, ok := c.estimators[m.Destination]
+	c.lock.RUnlock()
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), EstimationTimeout)
+	defer cancel()
+	cost, err := estimator.EstimateVoteCost(ctx, m)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed estimating vote cost of message %+v, skipping profitability check", m)
+		return nil
+	}
+
+	earnings, err := earnings(m, rule)
+	if err != nil {
+		return err
+	}
+	if earnings.Cmp(cost) >= 0 {
+		return nil
+	}
+
+	err = fmt.Errorf("deposit earns %s which does not cover vote cost %s on domain %d", earnings, cost, m.Destination)
+	if c.hold {
+		return fmt.Errorf("%w: %s", message.ErrMessageHeld, err)
+	}
+	return err
+}
+
+func (c *Checker) rule(resourceID types.ResourceID) (Rule, bool) {
+	rule, ok := c.rules[resourceID]
+	if ok {
+		return rule, true
+	}
+	if c.defaultRule != nil {
+		return *c.defaultRule, true
+	}
+	return Rule{}, false
+}
+
+// earnings returns the highest earnings of the deposit allowed by the rule
+func earnings(m *message.Message, rule Rule) (*big.Int, error) {
+	earnings := big.NewInt(0)
+	if rule.Fee != nil {
+		earnings.Set(rule.Fee)
+	}
+	if rule.Rate == nil || m.Type != message.FungibleTransfer {
+		return earnings, nil
+	}
+
+	payload, err := m.FungiblePayload()
+	if err != nil {
+		return nil, err
+	}
+	value, _ := new(big.Float).Mul(new(big.Float).SetInt(payload.Amount), rule.Rate).Int(nil)
+	if value.Cmp(earnings) > 0 {
+		earnings = value
+	}
+	return earnings, nil
+}
@@ -0,0 +1,116 @@
+package profitability_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/relayer/profitability"
+	mock_profitability "github.com/VaivalGithub/chainsafe-core/relayer/profitability/mock"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CheckerTestSuite struct {
+	suite.Suite
+	mockEstimator *mock_profitability.MockCostEstimator
+	resourceID    types.ResourceID
+}
+
+func TestRunCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckerTestSuite))
+}
+
+func (s *CheckerTestSuite) SetupSuite()    {}
+func (s *CheckerTestSuite) TearDownSuite() {}
+func (s *CheckerTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.mockEstimator = mock_profitability.NewMockCostEstimator(gomockController)
+	s.resourceID = types.ResourceID{31: 1}
+}
+func (s *CheckerTestSuite) TearDownTest() {}
+
+func (s *CheckerTestSuite) transfer(amount int64) *message.Message {
+	return message.NewTypedMessage(1, 2, 3, s.resourceID, &message.FungiblePayload{Amount: big.NewInt(amount), Recipient: []byte{1}}, message.Metadata{})
+}
+
+func (s *CheckerTestSuite) TestResourceWithoutRuleIsNotChecked() {
+	checker := profitability.NewChecker(map[types.ResourceID]profitability.Rule{}, nil, false)
+	checker.RegisterEstimator(2, s.mockEstimator)
+
+	err := checker.Process(s.transfer(1))
+
+	s.Nil(err)
+}
+
+func (s *CheckerTestSuite) TestDestinationWithoutEstimatorIsNotChecked() {
+	checker := profitability.NewChecker(nil, &profitability.Rule{Fee: big.NewInt(1)}, false)
+
+	err := checker.Process(s.transfer(1))
+
+	s.Nil(err)
+}
+
+func (s *CheckerTestSuite) TestEstimationFailureRelaysDeposit() {
+	checker := profitability.NewChecker(nil, &profitability.Rule{Fee: big.NewInt(1)}, false)
+	checker.RegisterEstimator(2, s.mockEstimator)
+	s.mockEstimator.EXPECT().EstimateVoteCost(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+	err := checker.Process(s.transfer(1))
+
+	s.Nil(err)
+}
+
+func (s *CheckerTestSuite) TestFeeCoversCost() {
+	checker := profitability.NewChecker(nil, &profitability.Rule{Fee: big.NewInt(100)}, false)
+	checker.RegisterEstimator(2, s.mockEstimator)
+	s.mockEstimator.EXPECT().EstimateVoteCost(gomock.Any(), gomock.Any()).Return(big.NewInt(100), nil)
+
+	err := checker.Process(s.transfer(1))
+
+	s.Nil(err)
+}
+
+func (s *CheckerTestSuite) TestUnprofitableDepositIsSkipped() {
+	checker := profitability.NewChecker(nil, &profitability.Rule{Fee: big.NewInt(99)}, false)
+	checker.RegisterEstimator(2, s.mockEstimator)
+	s.mockEstimator.EXPECT().EstimateVoteCost(gomock.Any(), gomock.Any()).Return(big.NewInt(100), nil)
+
+	err := checker.Process(s.transfer(1))
+
+	s.NotNil(err)
+	s.False(errors.Is(err, message.ErrMessageHeld))
+}
+
+func (s *CheckerTestSuite) TestUnprofitableDepositIsHeld() {
+	checker := profitability.NewChecker(nil, &profitability.Rule{Fee: big.NewInt(99)}, true)
+	checker.RegisterEstimator(2, s.mockEstimator)
+	s.mockEstimator.EXPECT().EstimateVoteCost(gomock.Any(), gomock.Any()).Return(big.NewInt(100), nil)
+
+	err := checker.Process(s.transfer(1))
+
+	s.True(errors.Is(err, message.ErrMessageHeld))
+}
+
+func (s *CheckerTestSuite) TestResourceRateCoversCost() {
+	checker := profitability.NewChecker(map[types.ResourceID]profitability.Rule{
+		s.resourceID: {Rate: big.NewFloat(0.5)},
+	}, &profitability.Rule{Fee: big.NewInt(1000)}, false)
+	checker.RegisterEstimator(2, s.mockEstimator)
+	s.mockEstimator.EXPECT().EstimateVoteCost(gomock.Any(), gomock.Any()).Return(big.NewInt(100), nil).Times(2)
+
+	s.Nil(checker.Process(s.transfer(200)))
+	s.NotNil(checker.Process(s.transfer(199)))
+}
+
+func (s *CheckerTestSuite) TestUnregisteredEstimatorIsNotUsed() {
+	checker := profitability.NewChecker(nil, &profitability.Rule{Fee: big.NewInt(1)}, false)
+	checker.RegisterEstimator(2, s.mockEstimator)
+	checker.UnregisterEstimator(2)
+
+	err := checker.Process(s.transfer(1))
+
+	s.Nil(err)
+}
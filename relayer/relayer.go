@@ -5,7 +5,10 @@ package relayer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/VaivalGithub/chainsafe-core/relayer/message"
 	"github.com/rs/zerolog/log"
@@ -16,15 +19,58 @@ type Metrics interface {
 }
 
 type RelayedChain interface {
+	// PollEvents sends messages of the chain to msgChan until ctx is cancelled.
+	// It returns once polling stopped and no further messages or blocks are stored.
 	PollEvents(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message)
-	Write(message *message.Message) error
+	// Write writes message to the chain. Cancelling ctx aborts writing
+	// along with any transaction that is not sent yet.
+	Write(ctx context.Context, message *message.Message) error
 	DomainID() uint8
 	// CheckFeeClaim() bool
 	// GetFeeClaim(msg *message.Message) error
 }
 
-func NewRelayer(chains []RelayedChain, metrics Metrics, messageProcessors ...message.MessageProcessor) *Relayer {
-	return &Relayer{relayedChains: chains, messageProcessors: messageProcessors, metrics: metrics}
+// BackgroundWriter is implemented by chains that follow up on written messages in the background
+// until the write ctx is cancelled. Wait blocks until the follow-ups return.
+type BackgroundWriter interface {
+	Wait()
+}
+
+// MessageStore is a persistent outbox that holds messages
+// until they are successfully written to the destination chain
+type MessageStore interface {
+	StoreMessage(m *message.Message) error
+	DeleteMessage(m *message.Message) error
+	GetMessages() ([]*message.Message, error)
+	StoreDeadLetter(m *message.Message) error
+	GetDeadLetters() ([]*message.Message, error)
+	RequeueDeadLetter(source, destination uint8, depositNonce uint64) (*message.Message, error)
+	ApproveHeldMessage(source, destination uint8, depositNonce uint64) (*message.Message, error)
+	RejectHeldMessage(source, destination uint8, depositNonce uint64) (*message.Message, error)
+	StoreHeldMessage(m *message.Message) error
+	GetHeldMessages() ([]*message.Message, error)
+	DeleteHeldMessage(m *message.Message) error
+	StoreMessageStatus(m *message.Message, status message.MessageStatus) error
+	GetMessageStatus(m *message.Message) (message.MessageStatus, error)
+}
+
+// DefaultShutdownTimeout is how long the relayer waits for in-flight writes to finish on shutdown
+const DefaultShutdownTimeout = 30 * time.Second
+
+func NewRelayer(chains []RelayedChain, metrics Metrics, messageStore MessageStore, messageProcessors ...message.MessageProcessor) *Relayer {
+	r := &Relayer{
+		relayedChains:     chains,
+		messageProcessors: messageProcessors,
+		metrics:           metrics,
+		messageStore:      messageStore,
+		shutdownTimeout:   DefaultShutdownTimeout,
+		stop:              make(chan struct{}),
+		observers:         &message.Observers{},
+	}
+	for _, c := range chains {
+		r.setChainObserver(c)
+	}
+	return r
 }
 
 type Relayer struct {
@@ -32,27 +78,97 @@ type Relayer struct {
 	relayedChains     []RelayedChain
 	registry          map[uint8]RelayedChain
 	messageProcessors []message.MessageProcessor
+	messageStore      MessageStore
+	retryPolicies     map[uint8]RetryPolicy
+	poolConfigs       map[uint8]WorkerPoolConfig
+	pools             map[uint8]*destinationPool
+	polls             map[uint8]*poll
+	pausedChains      map[uint8]bool
+	pausedRoutes      map[message.Route]bool
+	standby           bool
+	lastWrites        map[uint8]time.Time
+	shutdownTimeout   time.Duration
+	stop              chan struct{}
+	observers         *message.Observers
+	// lock guards chains, pools and their configs which can change while the relayer is running
+	lock sync.RWMutex
+
+	// set by Start so that chains added at runtime can be started
+	ctx      context.Context
+	writeCtx context.Context
+	sysErr   chan error
+	messages chan *message.Message
+}
+
+// destinationPool is a worker pool writing messages to a single destination chain.
+// It can be stopped and its writes cancelled independently of other destinations.
+type destinationPool struct {
+	*workerPool
+	stop         chan struct{}
+	cancelWrites context.CancelFunc
 }
 
 // Start function starts the relayer. Relayer routine is starting all the chains
-// and passing them with a channel that accepts unified cross chain message format
+// and passing them with a channel that accepts unified cross chain message format.
+// Once ctx is cancelled the relayer stops accepting new messages and returns after
+// in-flight writes finish or the shutdown timeout expires.
 func (r *Relayer) Start(ctx context.Context, sysErr chan error) {
 	log.Debug().Msgf("Starting relayer")
 
+	// writes are not cancelled together with ctx so that in-flight
+	// messages can be written to the destination while shutting down
+	writeCtx, cancelWrites := context.WithCancel(context.Background())
+	defer cancelWrites()
+
 	messagesChannel := make(chan *message.Message)
+	r.lock.Lock()
+	r.ctx = ctx
+	r.writeCtx = writeCtx
+	r.sysErr = sysErr
+	r.messages = messagesChannel
 	for _, c := range r.relayedChains {
-		log.Debug().Msgf("Starting chain %v", c.DomainID())
-		r.addRelayedChain(c)
-		go c.PollEvents(ctx, sysErr, messagesChannel)
+		r.startChain(c)
+	}
+	r.lock.Unlock()
+
+	err := r.replayMessages(ctx)
+	if err != nil {
+		r.drain(cancelWrites)
+		sysErr <- fmt.Errorf("error %w on replaying stored messages", err)
+		return
 	}
 
 	for {
 		select {
 		case m := <-messagesChannel:
-			go r.route(m)
+			if m.Type == message.Retraction {
+				r.retract(m)
+				continue
+			}
+
+			// deposits can be emitted again after restarting from an older block
+			// or after a chain reorganisation that retracted them is reverted
+			status := r.messageStatus(m)
+			if isProcessed(status) && status != message.MessageStatusRetracted {
+				log.Info().Msgf("Skipping already processed message %+v", m)
+				continue
+			}
+
+			// message is persisted before routing so it is not lost
+			// if the relayer stops before it is written to the destination
+			err := r.messageStore.StoreMessage(m)
+			if err != nil {
+				log.Error().Err(err).Msgf("failed persisting message %+v", m)
+			}
+			// approval of a held message is kept if its deposit is emitted again
+			if status == message.MessageStatusUnknown || status == message.MessageStatusRetracted {
+				r.storeMessageStatus(m, message.MessageStatusReceived)
+			}
+			r.dispatch(ctx, m)
 			continue
 
 		case <-ctx.Done():
+			r.drain(cancelWrites)
 			return
 
 		}
@@ -60,26 +176,317 @@ func (r *Relayer) Start(ctx context.Context, sysErr chan error) {
 
 }
 
+// SetShutdownTimeout sets how long the relayer waits for in-flight writes to finish
+// on shutdown before cancelling them
+func (r *Relayer) SetShutdownTimeout(timeout time.Duration) {
+	r.shutdownTimeout = timeout
+}
+
+// AddChain registers chain with the relayer. If the relayer is running the chain starts
+// polling events and messages left in the outbox for it are routed again.
+// Retry policy and worker pool of the chain have to be registered before it is added.
+func (r *Relayer) AddChain(c RelayedChain) error {
+	domainID := c.DomainID()
+
+	r.lock.Lock()
+	if _, ok := r.findChain(domainID); ok {
+		r.lock.Unlock()
+		return fmt.Errorf("chain with domain ID %d already added", domainID)
+	}
+	select {
+	case <-r.stop:
+		r.lock.Unlock()
+		return errors.New("relayer stopped")
+	default:
+	}
+
+	r.relayedChains = append(r.relayedChains, c)
+	r.setChainObserver(c)
+	running := r.messages != nil
+	if running {
+		r.startChain(c)
+	}
+	r.lock.Unlock()
+
+	if running {
+		go r.replayStored(func(m *message.Message) bool {
+			return m.Destination == domainID
+		})
+	}
+	return nil
+}
+
+// RegisterObserver registers observer notified about lifecycle transitions of messages.
+// Observer is also notified about transitions reported by chains that implement message.Observable.
+func (r *Relayer) RegisterObserver(o message.Observer) {
+	r.observers.Register(o)
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, c := range r.relayedChains {
+		r.setChainObserver(c)
+	}
+}
+
+// setChainObserver passes relayer observers to the chain if it reports lifecycle
+// transitions and at least one observer is registered
+func (r *Relayer) setChainObserver(c RelayedChain) {
+	if r.observers.Len() == 0 {
+		return
+	}
+	if observable, ok := c.(message.Observable); ok {
+		observable.SetObserver(r.observers)
+	}
+}
+
+// RemoveChain stops polling events of the chain and writing messages to it.
+// It blocks until polling of the chain returns and in-flight writes to the chain finish, cancelling them once the
+// shutdown timeout expires. Messages that were not written stay in the outbox
+// and are routed again once the chain is added back.
+func (r *Relayer) RemoveChain(domainID uint8) error {
+	r.lock.Lock()
+	index := -1
+	for i, rc := range r.relayedChains {
+		if rc.DomainID() == domainID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		r.lock.Unlock()
+		return fmt.Errorf("chain with domain ID %d not found", domainID)
+	}
+
+	removed := r.relayedChains[index]
+	r.relayedChains = append(r.relayedChains[:index:index], r.relayedChains[index+1:]...)
+	delete(r.registry, domainID)
+	stopped := r.stopPolling(domainID)
+	delete(r.pausedChains, domainID)
+	pool, ok := r.pools[domainID]
+	delete(r.pools, domainID)
+	r.lock.Unlock()
+
+	log.Info().Msgf("Removing chain %v", domainID)
+	// chain with the same domain can be added again once this returns
+	// so polling has to stop before it stores another block
+	<-stopped
+	if ok {
+		close(pool.stop)
+		r.waitForPools([]*destinationPool{pool}, pool.cancelWrites)
+		pool.cancelWrites()
+	}
+	waitForBackgroundWrites([]RelayedChain{removed})
+	return nil
+}
+
+// startChain starts worker pool of the chain and polling its events.
+// It has to be called with the lock held.
+func (r *Relayer) startChain(c RelayedChain) {
+	domainID := c.DomainID()
+	log.Debug().Msgf("Starting chain %v", domainID)
+	r.addRelayedChain(c)
+	r.startWorkerPool(r.writeCtx, domainID)
+	if !r.pausedChains[domainID] && !r.standby {
+		r.startPolling(c)
+	}
+}
+
+// poll is polling of chain events that is closed once polling returns
+type poll struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startPolling starts polling events of the chain. It has to be called with the lock held.
+func (r *Relayer) startPolling(c RelayedChain) {
+	if r.polls == nil {
+		r.polls = make(map[uint8]*poll)
+	}
+	pollCtx, cancelPoll := context.WithCancel(r.ctx)
+	p := &poll{
+		cancel: cancelPoll,
+		done:   make(chan struct{}),
+	}
+	r.polls[c.DomainID()] = p
+	go func() {
+		defer close(p.done)
+		c.PollEvents(pollCtx, r.sysErr, r.messages)
+	}()
+}
+
+// stopPolling stops polling events of the chain. It has to be called with the lock held.
+// Returned channel is closed once polling returns so it has to be waited for without the lock
+// as polling can wait for its messages to be consumed.
+func (r *Relayer) stopPolling(domainID uint8) <-chan struct{} {
+	p, ok := r.polls[domainID]
+	if !ok {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	p.cancel()
+	delete(r.polls, domainID)
+	return p.done
+}
+
+// drain stops worker pools from starting new writes and waits for in-flight writes to finish.
+// Writes still running after the shutdown timeout are cancelled. Messages that were
+// not written stay in the outbox and are replayed on the next start.
+func (r *Relayer) drain(cancelWrites context.CancelFunc) {
+	log.Info().Msgf("Stopping relayer, waiting up to %s for in-flight messages", r.shutdownTimeout)
+
+	r.lock.Lock()
+	close(r.stop)
+	pools := make([]*destinationPool, 0, len(r.pools))
+	for _, p := range r.pools {
+		close(p.stop)
+		pools = append(pools, p)
+	}
+	chains := make([]RelayedChain, len(r.relayedChains))
+	copy(chains, r.relayedChains)
+	r.lock.Unlock()
+
+	r.waitForPools(pools, cancelWrites)
+	// background follow-ups of written messages are stopped before the relayer
+	// returns so that they do not notify observers using already closed stores
+	cancelWrites()
+	waitForBackgroundWrites(chains)
+}
+
+// waitForBackgroundWrites waits for chains following up on written messages in the background.
+// Write ctx of the chains has to be cancelled before.
+func waitForBackgroundWrites(chains []RelayedChain) {
+	for _, c := range chains {
+		if writer, ok := c.(BackgroundWriter); ok {
+			writer.Wait()
+		}
+	}
+}
+
+// waitForPools waits for in-flight writes of stopped pools to finish
+// and calls cancelWrites once the shutdown timeout expires
+func (r *Relayer) waitForPools(pools []*destinationPool, cancelWrites context.CancelFunc) {
+	done := make(chan struct{})
+	go func() {
+		for _, p := range pools {
+			p.wait()
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		log.Info().Msg("All in-flight messages processed")
+	case <-time.After(r.shutdownTimeout):
+		log.Warn().Msg("Shutdown timeout expired, cancelling in-flight writes")
+		cancelWrites()
+		<-done
+	}
+}
+
+// replayMessages routes messages left in the outbox by the previous run
+func (r *Relayer) replayMessages(ctx context.Context) error {
+	msgs, err := r.messageStore.GetMessages()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		log.Info().Msgf("Replaying stored message %+v", m)
+		r.dispatch(ctx, m)
+	}
+	return nil
+}
+
+// replayStored routes matching messages left in the outbox while their
+// destination chain was not added or their route was paused
+func (r *Relayer) replayStored(match func(m *message.Message) bool) {
+	msgs, err := r.messageStore.GetMessages()
+	if err != nil {
+		log.Error().Err(err).Msg("failed fetching stored messages")
+		return
+	}
+
+	for _, m := range msgs {
+		if !match(m) {
+			continue
+		}
+		log.Info().Msgf("Replaying stored message %+v", m)
+		r.dispatch(r.ctx, m)
+	}
+}
+
+// dispatch submits message to the worker pool of the destination chain.
+// It blocks while the destination queue is full.
+func (r *Relayer) dispatch(ctx context.Context, m *message.Message) {
+	pool, ok := r.pool(m.Destination)
+	if !ok {
+		// route logs and leaves message in the outbox
+		r.route(ctx, m)
+		return
+	}
+	if !pool.submit(ctx, m) {
+		log.Debug().Msgf("Relayer stopping, message %+v left in the outbox", m)
+	}
+}
+
 // Route function winds destination writer by mapping DestinationID from message to registered writer.
-func (r *Relayer) route(m *message.Message) {
-	r.metrics.TrackDepositMessage(m)
+// Message is removed from the outbox once it has been written to the destination,
+// rejected or held by one of the message processors or moved to dead letters after exhausting retries.
+// Cancelling ctx aborts the write and leaves the message in the outbox.
+func (r *Relayer) route(ctx context.Context, m *message.Message) {
+	status := r.messageStatus(m)
+	if isProcessed(status) {
+		log.Info().Msgf("Skipping already processed message %+v", m)
+		r.deleteMessage(m)
+		return
+	}
+
+	if r.isPaused(m) {
+		log.Info().Msgf("Route of message %+v paused, message left in the outbox", m)
+		return
+	}
 
-	destChain, ok := r.registry[m.Destination]
+	r.metrics.TrackDepositMessage(m)
 
+	destChain, ok := r.chain(m.Destination)
 	if !ok {
 		log.Error().Msgf("no resolver for destID %v to send message registered", m.Destination)
 		return
 	}
-	
 
+	// processors can modify message so original message is kept
+	// unchanged in case it has to be dead-lettered and requeued later
+	processed := copyMessage(m)
+	// observers are notified if route stops without writing or failing the message
+	// so they can release state kept for it by message processors
+	settled := false
+	defer func() {
+		if !settled {
+			r.observers.MessageReleased(m)
+		}
+	}()
 	for _, mp := range r.messageProcessors {
-		if err := mp(m); err != nil {
-			log.Error().Err(fmt.Errorf("error %w processing mesage %v", err, m))
+		if err := mp(processed); err != nil {
+			if errors.Is(err, message.ErrMessageHeld) {
+				if status == message.MessageStatusApproved {
+					log.Info().Err(err).Msgf("Relaying manually approved message %+v", processed)
+					continue
+				}
+				r.holdMessage(m, err)
+				return
+			}
+			log.Error().Err(err).Msgf("Rejecting message %+v", processed)
+			settled = true
+			r.observers.MessageFailed(m, err)
+			r.storeMessageStatus(m, message.MessageStatusRejected)
+			r.deleteMessage(m)
 			return
 		}
 	}
+	r.observers.MessageProcessed(processed)
 
-	log.Debug().Msgf("Sending message %+v to destination %v", m, m.Destination)
+	log.Debug().Msgf("Sending message %+v to destination %v", processed, processed.Destination)
 	// // fee method here.
 	// boolVal := destChain.CheckFeeClaim()
 	// if boolVal {
@@ -88,10 +495,314 @@ func (r *Relayer) route(m *message.Message) {
 	// 		log.Error().Msgf("Claiming fees Error %+w", err)
 	// 	}
 	// }
-	if err := destChain.Write(m); err != nil {
-		log.Error().Err(err).Msgf("writing message %+v", m)
+	policy := r.retryPolicy(m.Destination)
+	for attempt := 1; ; attempt++ {
+		err := destChain.Write(ctx, processed)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			log.Warn().Err(err).Msgf("Writing message %+v cancelled, message left in the outbox", processed)
+			return
+		}
+		log.Error().Err(err).Int("attempt", attempt).Msgf("writing message %+v", processed)
+
+		notRetryable := errors.Is(err, message.ErrNotRetryable)
+		if attempt >= policy.MaxAttempts || notRetryable {
+			failure := fmt.Errorf("exhausted %d write attempts: %w", attempt, err)
+			if notRetryable {
+				failure = err
+			}
+			log.Error().Err(failure).Msgf("Message %+v can not be written, moving it to dead letters", m)
+			storeErr := r.messageStore.StoreDeadLetter(m)
+			if storeErr != nil {
+				// message stays in the outbox and is retried on next start
+				log.Error().Err(storeErr).Msgf("failed storing dead letter %+v", m)
+				return
+			}
+			settled = true
+			r.observers.MessageFailed(m, failure)
+			r.deleteMessage(m)
+			return
+		}
+		select {
+		case <-r.stop:
+			log.Info().Msgf("Relayer stopping, message %+v left in the outbox", processed)
+			return
+		default:
+		}
+		if r.isPaused(m) {
+			log.Info().Msgf("Route of message %+v paused, message left in the outbox", processed)
+			return
+		}
+		if err := Sleep(ctx, policy.delay(attempt)); err != nil {
+			log.Warn().Msgf("Retrying message %+v cancelled, message left in the outbox", processed)
+			return
+		}
+	}
+
+	settled = true
+	r.recordWrite(m.Destination)
+	r.observers.MessageWritten(processed)
+	// deposit can be marked executed from destination bridge events while it was written
+	if r.messageStatus(m) != message.MessageStatusExecuted {
+		r.storeMessageStatus(m, message.MessageStatusVoted)
+	}
+	r.deleteMessage(m)
+}
+
+func (r *Relayer) recordWrite(domainID uint8) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.lastWrites == nil {
+		r.lastWrites = make(map[uint8]time.Time)
+	}
+	r.lastWrites[domainID] = time.Now()
+}
+
+// LastWrite returns time of the last message successfully written to the destination chain
+// since the relayer started. Zero time is returned if no message was written yet.
+func (r *Relayer) LastWrite(domainID uint8) time.Time {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.lastWrites[domainID]
+}
+
+// QueueDepth returns number of messages waiting to be written to the destination chain
+func (r *Relayer) QueueDepth(domainID uint8) int {
+	pool, ok := r.pool(domainID)
+	if !ok {
+		return 0
+	}
+	return pool.queued()
+}
+
+// RegisterRetryPolicy sets retry policy used when writing messages to the destination domain
+func (r *Relayer) RegisterRetryPolicy(domainID uint8, policy RetryPolicy) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.retryPolicies == nil {
+		r.retryPolicies = make(map[uint8]RetryPolicy)
+	}
+	r.retryPolicies[domainID] = policy
+}
+
+// RegisterWorkerPool sets worker pool config used when writing messages to the destination domain.
+// It has to be called before the chain is started.
+func (r *Relayer) RegisterWorkerPool(domainID uint8, config WorkerPoolConfig) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.poolConfigs == nil {
+		r.poolConfigs = make(map[uint8]WorkerPoolConfig)
+	}
+	r.poolConfigs[domainID] = config
+}
+
+func (r *Relayer) startWorkerPool(ctx context.Context, domainID uint8) {
+	if r.pools == nil {
+		r.pools = make(map[uint8]*destinationPool)
+	}
+	config, ok := r.poolConfigs[domainID]
+	if !ok {
+		config = DefaultWorkerPoolConfig
+	}
+
+	writeCtx, cancelWrites := context.WithCancel(ctx)
+	stop := make(chan struct{})
+	r.pools[domainID] = &destinationPool{
+		workerPool: newWorkerPool(config, stop, func(m *message.Message) {
+			r.route(writeCtx, m)
+		}),
+		stop:         stop,
+		cancelWrites: cancelWrites,
+	}
+}
+
+func (r *Relayer) pool(domainID uint8) (*destinationPool, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	pool, ok := r.pools[domainID]
+	return pool, ok
+}
+
+func (r *Relayer) chain(domainID uint8) (RelayedChain, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	c, ok := r.registry[domainID]
+	return c, ok
+}
+
+func (r *Relayer) retryPolicy(domainID uint8) RetryPolicy {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	policy, ok := r.retryPolicies[domainID]
+	if !ok {
+		return DefaultRetryPolicy
+	}
+	return policy
+}
+
+// PendingMessages returns messages in the outbox that are not written to their destination yet
+func (r *Relayer) PendingMessages() ([]*message.Message, error) {
+	return r.messageStore.GetMessages()
+}
+
+// ReplayMessage routes message again even if it was already processed.
+// It is used to re-relay deposits that were not executed on the destination.
+// If the relayer is not running the message is routed on the next start.
+func (r *Relayer) ReplayMessage(m *message.Message) error {
+	err := r.messageStore.StoreMessage(m)
+	if err != nil {
+		return err
+	}
+	err = r.messageStore.StoreMessageStatus(m, message.MessageStatusReceived)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Replaying message %+v", m)
+	if _, ok := r.pool(m.Destination); ok {
+		go r.dispatch(context.Background(), m)
+	}
+	return nil
+}
+
+// DeadLetters returns messages that exhausted all write retries
+func (r *Relayer) DeadLetters() ([]*message.Message, error) {
+	return r.messageStore.GetDeadLetters()
+}
+
+// RequeueDeadLetter moves dead-lettered message back to the outbox and routes it again.
+// If the relayer is not running the message is routed on the next start.
+func (r *Relayer) RequeueDeadLetter(source, destination uint8, depositNonce uint64) error {
+	m, err := r.messageStore.RequeueDeadLetter(source, destination, depositNonce)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.pool(m.Destination); ok {
+		go r.dispatch(context.Background(), m)
+	}
+	return nil
+}
+
+// holdMessage moves message from the outbox to held messages
+func (r *Relayer) holdMessage(m *message.Message, reason error) {
+	log.Warn().Err(reason).Msgf("Holding message %+v", m)
+	err := r.messageStore.StoreHeldMessage(m)
+	if err != nil {
+		// message stays in the outbox and is processed again on next start
+		log.Error().Err(err).Msgf("failed storing held message %+v", m)
 		return
 	}
+	r.storeMessageStatus(m, message.MessageStatusHeld)
+	r.deleteMessage(m)
+}
+
+// HeldMessages returns messages held by message processors
+func (r *Relayer) HeldMessages() ([]*message.Message, error) {
+	return r.messageStore.GetHeldMessages()
+}
+
+// ApproveHeldMessage moves held message back to the outbox and routes it again skipping holds
+// of message processors. If the relayer is not running the message is routed on the next start.
+func (r *Relayer) ApproveHeldMessage(source, destination uint8, depositNonce uint64) error {
+	m, err := r.messageStore.ApproveHeldMessage(source, destination, depositNonce)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Held message %+v approved", m)
+	if _, ok := r.pool(m.Destination); ok {
+		go r.dispatch(context.Background(), m)
+	}
+	return nil
+}
+
+// RejectHeldMessage removes held message so that it is never relayed
+func (r *Relayer) RejectHeldMessage(source, destination uint8, depositNonce uint64) error {
+	m, err := r.messageStore.RejectHeldMessage(source, destination, depositNonce)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msgf("Held message %+v rejected", m)
+	return nil
+}
+
+// messageStatus fetches status of the deposit returning MessageStatusUnknown
+// if it can not be fetched so that the deposit is processed again
+func (r *Relayer) messageStatus(m *message.Message) message.MessageStatus {
+	status, err := r.messageStore.GetMessageStatus(m)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed fetching status of message %+v", m)
+		return message.MessageStatusUnknown
+	}
+	return status
+}
+
+// isProcessed checks if the deposit was already voted for or executed on the destination,
+// held by message processors, rejected or retracted
+func isProcessed(status message.MessageStatus) bool {
+	switch status {
+	case message.MessageStatusVoted, message.MessageStatusExecuted, message.MessageStatusHeld, message.MessageStatusRejected, message.MessageStatusRetracted:
+		return true
+	default:
+		return false
+	}
+}
+
+// MessageStatus returns local processing status of the deposit
+func (r *Relayer) MessageStatus(source, destination uint8, depositNonce uint64) (message.MessageStatus, error) {
+	return r.messageStore.GetMessageStatus(&message.Message{Source: source, Destination: destination, DepositNonce: depositNonce})
+}
+
+func (r *Relayer) storeMessageStatus(m *message.Message, status message.MessageStatus) {
+	err := r.messageStore.StoreMessageStatus(m, status)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed storing %s status of message %+v", message.MessageStatusMap[status], m)
+	}
+}
+
+// retract removes deposit that no longer exists on the source chain after a reorganisation
+// from the outbox or held messages. Deposits that were already voted for can not be retracted
+// and are reported as failed.
+func (r *Relayer) retract(m *message.Message) {
+	status := r.messageStatus(m)
+	switch status {
+	case message.MessageStatusUnknown, message.MessageStatusRejected, message.MessageStatusRetracted:
+		return
+	case message.MessageStatusVoted, message.MessageStatusExecuted:
+		err := fmt.Errorf("deposit %d from %d to %d was relayed before it was removed by a chain reorganisation", m.DepositNonce, m.Source, m.Destination)
+		log.Error().Err(err).Msg("Unable to retract deposit")
+		r.observers.MessageFailed(m, err)
+		return
+	case message.MessageStatusHeld:
+		err := r.messageStore.DeleteHeldMessage(m)
+		if err != nil {
+			log.Error().Err(err).Msgf("failed removing retracted message %+v from held messages", m)
+		}
+	}
+
+	log.Warn().Msgf("Retracting deposit %d from %d to %d removed by a chain reorganisation", m.DepositNonce, m.Source, m.Destination)
+	// status is stored first so that the message is skipped if it is already queued for writing
+	r.storeMessageStatus(m, message.MessageStatusRetracted)
+	r.deleteMessage(m)
+	r.observers.MessageReleased(m)
+}
+
+func (r *Relayer) deleteMessage(m *message.Message) {
+	err := r.messageStore.DeleteMessage(m)
+	if err != nil {
+		log.Error().Err(err).Msgf("failed removing message %+v from outbox", m)
+	}
 }
 
 func (r *Relayer) addRelayedChain(c RelayedChain) {
@@ -101,3 +812,11 @@ func (r *Relayer) addRelayedChain(c RelayedChain) {
 	domainID := c.DomainID()
 	r.registry[domainID] = c
 }
+
+// copyMessage creates a copy of the message with its own payload slice
+func copyMessage(m *message.Message) *message.Message {
+	c := *m
+	c.Payload = make([]interface{}, len(m.Payload))
+	copy(c.Payload, m.Payload)
+	return &c
+}
@@ -1,12 +1,15 @@
 package relayer
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	mock_message "github.com/VaivalGithub/chainsafe-core/relayer/message/mock"
 	mock_relayer "github.com/VaivalGithub/chainsafe-core/relayer/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -15,6 +18,7 @@ type RouteTestSuite struct {
 	suite.Suite
 	mockRelayedChain *mock_relayer.MockRelayedChain
 	mockMetrics      *mock_relayer.MockMetrics
+	mockMessageStore *mock_relayer.MockMessageStore
 }
 
 func TestRunRouteTestSuite(t *testing.T) {
@@ -27,16 +31,20 @@ func (s *RouteTestSuite) SetupTest() {
 	gomockController := gomock.NewController(s.T())
 	s.mockRelayedChain = mock_relayer.NewMockRelayedChain(gomockController)
 	s.mockMetrics = mock_relayer.NewMockMetrics(gomockController)
+	s.mockMessageStore = mock_relayer.NewMockMessageStore(gomockController)
+	Sleep = func(ctx context.Context, d time.Duration) error { return ctx.Err() }
 }
 func (s *RouteTestSuite) TearDownTest() {}
 
 func (s *RouteTestSuite) TestLogsErrorIfDestinationDoesNotExist() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
 	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
 	relayer := Relayer{
-		metrics: s.mockMetrics,
+		metrics:      s.mockMetrics,
+		messageStore: s.mockMessageStore,
 	}
 
-	relayer.route(&message.Message{})
+	relayer.route(context.Background(), &message.Message{})
 }
 
 // TestRouter tests relayers router
@@ -59,48 +67,760 @@ func (s *RouteTestSuite) TestAdjustDecimalsForERC20AmountMessageProcessor() {
 }
 
 func (s *RouteTestSuite) TestLogsErrorIfMessageProcessorReturnsError() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
 	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
 	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusRejected).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
 	relayer := NewRelayer(
 		[]RelayedChain{},
 		s.mockMetrics,
+		s.mockMessageStore,
 		func(m *message.Message) error { return fmt.Errorf("error") },
 	)
 	relayer.addRelayedChain(s.mockRelayedChain)
 
-	relayer.route(&message.Message{
+	relayer.route(context.Background(), &message.Message{
 		Destination: 1,
 	})
 }
 
-func (s *RouteTestSuite) TestLogsErrorIfWriteReturnsError() {
+func (s *RouteTestSuite) TestDeadLettersMessageIfWriteRetriesExhausted() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
 	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
 	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
-	s.mockRelayedChain.EXPECT().Write(gomock.Any()).Return(fmt.Errorf("Error"))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Times(2).Return(fmt.Errorf("Error"))
+	s.mockMessageStore.EXPECT().StoreDeadLetter(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
 	relayer := NewRelayer(
 		[]RelayedChain{},
 		s.mockMetrics,
+		s.mockMessageStore,
 		func(m *message.Message) error { return nil },
 	)
 	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterRetryPolicy(1, RetryPolicy{MaxAttempts: 2})
 
-	relayer.route(&message.Message{
+	relayer.route(context.Background(), &message.Message{
 		Destination: 1,
 	})
 }
 
+func (s *RouteTestSuite) TestDeadLettersNotRetryableMessageWithoutRetrying() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Times(1).Return(
+		fmt.Errorf("relayer removed: %w", message.ErrNotRetryable),
+	)
+	s.mockMessageStore.EXPECT().StoreDeadLetter(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterRetryPolicy(1, RetryPolicy{MaxAttempts: 5})
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestKeepsMessageInOutboxIfStoringDeadLetterFails() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Error"))
+	s.mockMessageStore.EXPECT().StoreDeadLetter(gomock.Any()).Return(fmt.Errorf("error"))
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterRetryPolicy(1, RetryPolicy{MaxAttempts: 1})
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestRetriesWriteUntilSuccessful() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	gomock.InOrder(
+		s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Error")),
+		s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil),
+	)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestDeadLetteredMessageIsNotModifiedByProcessors() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Error"))
+	s.mockMessageStore.EXPECT().StoreDeadLetter(&message.Message{
+		Destination: 1,
+		Payload:     []interface{}{[]byte{1}},
+	}).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+		func(m *message.Message) error {
+			m.Payload[0] = []byte{2}
+			return nil
+		},
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.RegisterRetryPolicy(1, RetryPolicy{MaxAttempts: 1})
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+		Payload:     []interface{}{[]byte{1}},
+	})
+}
+
+func (s *RouteTestSuite) TestRequeueDeadLetterRoutesMessage() {
+	done := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
+	s.mockMessageStore.EXPECT().RequeueDeadLetter(uint8(2), uint8(1), uint64(3)).Return(&message.Message{Source: 2, Destination: 1, DepositNonce: 3}, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).DoAndReturn(func(m *message.Message) error {
+		close(done)
+		return nil
+	})
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.startWorkerPool(context.Background(), 1)
+
+	err := relayer.RequeueDeadLetter(2, 1, 3)
+
+	s.Nil(err)
+	<-done
+}
+
 func (s *RouteTestSuite) TestWritesToDestChainIfMessageValid() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
 	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
 	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
-	s.mockRelayedChain.EXPECT().Write(gomock.Any()).Return(nil)
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
 	relayer := NewRelayer(
 		[]RelayedChain{},
 		s.mockMetrics,
+		s.mockMessageStore,
 		func(m *message.Message) error { return nil },
 	)
 	relayer.addRelayedChain(s.mockRelayedChain)
 
-	relayer.route(&message.Message{
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+
+	s.False(relayer.LastWrite(1).IsZero())
+}
+
+func (s *RouteTestSuite) TestKeepsExecutedStatusOfWrittenMessage() {
+	gomock.InOrder(
+		s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil),
+		s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusExecuted, nil),
+	)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestReplaysStoredMessages() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{{Destination: 1}}, nil)
+	done := make(chan struct{})
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).DoAndReturn(func(m *message.Message) error {
+		close(done)
+		return nil
+	})
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	err := relayer.replayMessages(context.Background())
+
+	s.Nil(err)
+	<-done
+}
+
+func (s *RouteTestSuite) TestReplayReturnsErrorIfStoreFails() {
+	s.mockMessageStore.EXPECT().GetMessages().Return(nil, fmt.Errorf("error"))
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.replayMessages(context.Background())
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestKeepsMessageInOutboxIfWriteCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, m *message.Message) error {
+		cancel()
+		return ctx.Err()
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	relayer.route(ctx, &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestStopsRetryingOnShutdown() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(fmt.Errorf("Error"))
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	close(relayer.stop)
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestStartWaitsForInFlightWritesOnShutdown() {
+	ctx, cancel := context.WithCancel(context.Background())
+	writing := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{{Destination: 1}}, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any())
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, m *message.Message) error {
+		close(writing)
+		time.Sleep(10 * time.Millisecond)
+		return ctx.Err()
+	})
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	go func() {
+		<-writing
+		cancel()
+	}()
+	relayer.Start(ctx, make(chan error))
+}
+
+type backgroundWriterChain struct {
+	*mock_relayer.MockRelayedChain
+	writeCtx chan context.Context
+	waited   bool
+}
+
+func (c *backgroundWriterChain) Write(ctx context.Context, m *message.Message) error {
+	c.writeCtx <- ctx
+	return c.MockRelayedChain.Write(ctx, m)
+}
+
+func (c *backgroundWriterChain) Wait() {
+	c.waited = (<-c.writeCtx).Err() != nil
+}
+
+func (s *RouteTestSuite) TestStartWaitsForBackgroundWritesOnShutdown() {
+	ctx, cancel := context.WithCancel(context.Background())
+	written := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil).Times(2)
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{{Destination: 1}}, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any())
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).DoAndReturn(func(m *message.Message) error {
+		close(written)
+		return nil
+	})
+	chain := &backgroundWriterChain{MockRelayedChain: s.mockRelayedChain, writeCtx: make(chan context.Context, 1)}
+	relayer := NewRelayer(
+		[]RelayedChain{chain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	go func() {
+		<-written
+		cancel()
+	}()
+	relayer.Start(ctx, make(chan error))
+
+	s.True(chain.waited)
+}
+
+func (s *RouteTestSuite) TestStartCancelsInFlightWritesAfterShutdownTimeout() {
+	ctx, cancel := context.WithCancel(context.Background())
+	writing := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{{Destination: 1}}, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any())
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, m *message.Message) error {
+		close(writing)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.SetShutdownTimeout(time.Millisecond)
+
+	go func() {
+		<-writing
+		cancel()
+	}()
+	relayer.Start(ctx, make(chan error))
+}
+
+func (s *RouteTestSuite) TestSkipsAlreadyProcessedMessage() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(&message.Message{Source: 2, Destination: 1, DepositNonce: 3}).Return(message.MessageStatusVoted, nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	relayer.route(context.Background(), &message.Message{
+		Source:       2,
+		Destination:  1,
+		DepositNonce: 3,
+	})
+}
+
+func (s *RouteTestSuite) TestRoutesMessageIfStatusFetchFails() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusUnknown, fmt.Errorf("error")).Times(2)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestStartSkipsAlreadyProcessedMessages() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{}, nil)
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		msgChan <- &message.Message{Source: 2, Destination: 1, DepositNonce: 3}
+		cancel()
+	})
+	s.mockMessageStore.EXPECT().GetMessageStatus(&message.Message{Source: 2, Destination: 1, DepositNonce: 3}).Return(message.MessageStatusExecuted, nil)
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	relayer.Start(ctx, make(chan error))
+}
+
+func (s *RouteTestSuite) TestHoldsMessageIfProcessorHoldsIt() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockMessageStore.EXPECT().StoreHeldMessage(&message.Message{Destination: 1}).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusHeld).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+		func(m *message.Message) error {
+			return fmt.Errorf("%w: limit exceeded", message.ErrMessageHeld)
+		},
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestKeepsMessageInOutboxIfStoringHeldMessageFails() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockMessageStore.EXPECT().StoreHeldMessage(gomock.Any()).Return(fmt.Errorf("error"))
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+		func(m *message.Message) error {
+			return message.ErrMessageHeld
+		},
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestRelaysApprovedMessageHeldByProcessor() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusApproved, nil).Times(2)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+		func(m *message.Message) error {
+			return message.ErrMessageHeld
+		},
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+
+	relayer.route(context.Background(), &message.Message{
+		Destination: 1,
+	})
+}
+
+func (s *RouteTestSuite) TestSkipsRejectedMessage() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusRejected, nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	relayer.route(context.Background(), &message.Message{
 		Destination: 1,
 	})
 }
+
+func (s *RouteTestSuite) TestApproveHeldMessageRoutesMessage() {
+	done := make(chan struct{})
+	s.mockMessageStore.EXPECT().ApproveHeldMessage(uint8(2), uint8(1), uint64(3)).Return(&message.Message{Source: 2, Destination: 1, DepositNonce: 3}, nil)
+	s.mockMessageStore.EXPECT().GetMessageStatus(&message.Message{Source: 2, Destination: 1, DepositNonce: 3}).Return(message.MessageStatusApproved, nil).Times(2)
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any())
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1))
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).DoAndReturn(func(m *message.Message) error {
+		close(done)
+		return nil
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.addRelayedChain(s.mockRelayedChain)
+	relayer.startWorkerPool(context.Background(), 1)
+
+	err := relayer.ApproveHeldMessage(2, 1, 3)
+
+	s.Nil(err)
+	<-done
+}
+
+func (s *RouteTestSuite) TestRejectHeldMessage() {
+	s.mockMessageStore.EXPECT().RejectHeldMessage(uint8(2), uint8(1), uint64(3)).Return(&message.Message{Source: 2, Destination: 1, DepositNonce: 3}, nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.RejectHeldMessage(2, 1, 3)
+
+	s.Nil(err)
+}
+
+func (s *RouteTestSuite) TestAddChainStartsChainOnRunningRelayer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(chan struct{})
+	polling := make(chan struct{})
+	replayed := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessages().DoAndReturn(func() ([]*message.Message, error) {
+		close(started)
+		return []*message.Message{}, nil
+	})
+	s.mockMessageStore.EXPECT().GetMessages().DoAndReturn(func() ([]*message.Message, error) {
+		close(replayed)
+		return []*message.Message{{Destination: 2}}, nil
+	})
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		close(polling)
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	go relayer.Start(ctx, make(chan error))
+	<-started
+
+	err := relayer.AddChain(s.mockRelayedChain)
+
+	s.Nil(err)
+	<-polling
+	<-replayed
+	_, ok := relayer.pool(1)
+	s.True(ok)
+}
+
+func (s *RouteTestSuite) TestAddChainReturnsErrorIfChainAlreadyAdded() {
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.AddChain(s.mockRelayedChain)
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestRemoveChainStopsPollingAndWriting() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pollCtx := make(chan context.Context, 1)
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{}, nil)
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		pollCtx <- ctx
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	go relayer.Start(ctx, make(chan error))
+	chainCtx := <-pollCtx
+
+	err := relayer.RemoveChain(1)
+
+	s.Nil(err)
+	s.NotNil(chainCtx.Err())
+	_, ok := relayer.pool(1)
+	s.False(ok)
+	_, ok = relayer.chain(1)
+	s.False(ok)
+}
+
+func (s *RouteTestSuite) TestRemoveAndAddChainWithSameDomainDoesNotOverlapPolling() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	polling := make(chan struct{})
+	stopped := false
+	secondPolling := make(chan bool, 1)
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{}, nil).MinTimes(1)
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		close(polling)
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		stopped = true
+	})
+	secondChain := mock_relayer.NewMockRelayedChain(gomock.NewController(s.T()))
+	secondChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	secondChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		secondPolling <- stopped
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	go relayer.Start(ctx, make(chan error))
+	<-polling
+
+	err := relayer.RemoveChain(1)
+	s.Nil(err)
+	err = relayer.AddChain(secondChain)
+	s.Nil(err)
+
+	s.True(<-secondPolling)
+}
+
+func (s *RouteTestSuite) TestRemoveChainReturnsErrorIfChainNotFound() {
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.RemoveChain(1)
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestRetractRemovesMessageFromOutbox() {
+	m := message.NewRetractionMessage(2, 1, 3)
+	s.mockMessageStore.EXPECT().GetMessageStatus(m).Return(message.MessageStatusReceived, nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(m, message.MessageStatusRetracted).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(m).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	relayer.retract(m)
+}
+
+func (s *RouteTestSuite) TestRetractReleasesMessage() {
+	m := message.NewRetractionMessage(2, 1, 3)
+	mockObserver := mock_message.NewMockObserver(gomock.NewController(s.T()))
+	mockObserver.EXPECT().MessageReleased(m)
+	s.mockMessageStore.EXPECT().GetMessageStatus(m).Return(message.MessageStatusReceived, nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(m, message.MessageStatusRetracted).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(m).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.RegisterObserver(mockObserver)
+
+	relayer.retract(m)
+}
+
+func (s *RouteTestSuite) TestRetractRemovesHeldMessage() {
+	m := message.NewRetractionMessage(2, 1, 3)
+	s.mockMessageStore.EXPECT().GetMessageStatus(m).Return(message.MessageStatusHeld, nil)
+	s.mockMessageStore.EXPECT().DeleteHeldMessage(m).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(m, message.MessageStatusRetracted).Return(nil)
+	s.mockMessageStore.EXPECT().DeleteMessage(m).Return(nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	relayer.retract(m)
+}
+
+func (s *RouteTestSuite) TestRetractNotifiesObserverIfMessageAlreadyVoted() {
+	m := message.NewRetractionMessage(2, 1, 3)
+	mockObserver := mock_message.NewMockObserver(gomock.NewController(s.T()))
+	mockObserver.EXPECT().MessageFailed(m, gomock.Not(gomock.Nil()))
+	s.mockMessageStore.EXPECT().GetMessageStatus(m).Return(message.MessageStatusVoted, nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.RegisterObserver(mockObserver)
+
+	relayer.retract(m)
+}
+
+func (s *RouteTestSuite) TestStartRelaysRetractedMessageEmittedAgain() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := &message.Message{Source: 2, Destination: 1, DepositNonce: 3}
+	s.mockMessageStore.EXPECT().GetMessages().Return([]*message.Message{}, nil)
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		msgChan <- m
+	})
+	s.mockMessageStore.EXPECT().GetMessageStatus(m).Return(message.MessageStatusRetracted, nil)
+	s.mockMessageStore.EXPECT().StoreMessage(m).Return(nil)
+	s.mockMessageStore.EXPECT().StoreMessageStatus(m, message.MessageStatusReceived).DoAndReturn(func(m *message.Message, status message.MessageStatus) error {
+		cancel()
+		return nil
+	})
+	s.mockMessageStore.EXPECT().GetMessageStatus(m).Return(message.MessageStatusReceived, nil).AnyTimes()
+	s.mockMetrics.EXPECT().TrackDepositMessage(gomock.Any()).AnyTimes()
+	s.mockRelayedChain.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	s.mockMessageStore.EXPECT().StoreMessageStatus(gomock.Any(), message.MessageStatusVoted).Return(nil).AnyTimes()
+	s.mockMessageStore.EXPECT().DeleteMessage(gomock.Any()).Return(nil).AnyTimes()
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	relayer.Start(ctx, make(chan error))
+}
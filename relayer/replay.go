@@ -0,0 +1,59 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/rs/zerolog/log"
+)
+
+// ReplayableChain is a chain that can emit messages of a historic block range again
+type ReplayableChain interface {
+	Replay(ctx context.Context, from *big.Int, to *big.Int, msgChan chan *message.Message) error
+}
+
+// ReplayBlocks emits messages of the from-to block range of the source chain again without
+// moving its blockstore cursor. Messages are routed as if they were emitted by polling,
+// so messages that were already processed are skipped. It blocks until the range is replayed.
+func (r *Relayer) ReplayBlocks(ctx context.Context, domainID uint8, from *big.Int, to *big.Int) error {
+	r.lock.RLock()
+	c, ok := r.registry[domainID]
+	msgChan := r.messages
+	relayerCtx := r.ctx
+	standby := r.standby
+	r.lock.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("chain with domain ID %d not found", domainID)
+	}
+	replayable, ok := c.(ReplayableChain)
+	if !ok {
+		return fmt.Errorf("chain with domain ID %d does not support replaying blocks", domainID)
+	}
+	if msgChan == nil {
+		return errors.New("relayer not started")
+	}
+	if standby {
+		return errors.New("relayer on standby")
+	}
+
+	// replay is aborted once the relayer stops as messages are no longer consumed
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-relayerCtx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	log.Info().Msgf("Replaying blocks %s-%s of chain %d", from, to, domainID)
+	return replayable.Replay(ctx, from, to, msgChan)
+}
@@ -0,0 +1,101 @@
+package relayer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	mock_relayer "github.com/VaivalGithub/chainsafe-core/relayer/mock"
+	"github.com/golang/mock/gomock"
+)
+
+// replayableChain is relayed chain that replays blocks with the replay function
+type replayableChain struct {
+	*mock_relayer.MockRelayedChain
+	replay func(ctx context.Context, from *big.Int, to *big.Int, msgChan chan *message.Message) error
+}
+
+func (c *replayableChain) Replay(ctx context.Context, from *big.Int, to *big.Int, msgChan chan *message.Message) error {
+	return c.replay(ctx, from, to, msgChan)
+}
+
+func (s *RouteTestSuite) TestReplayBlocksFailsForUnknownChain() {
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.ReplayBlocks(context.Background(), 1, big.NewInt(1), big.NewInt(10))
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestReplayBlocksFailsIfChainCanNotReplay() {
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.ReplayBlocks(context.Background(), 1, big.NewInt(1), big.NewInt(10))
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestReplayBlocksFailsIfRelayerNotStarted() {
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	relayer := NewRelayer(
+		[]RelayedChain{&replayableChain{MockRelayedChain: s.mockRelayedChain}},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	err := relayer.ReplayBlocks(context.Background(), 1, big.NewInt(1), big.NewInt(10))
+
+	s.NotNil(err)
+}
+
+func (s *RouteTestSuite) TestReplayBlocksRoutesReplayedMessages() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	started := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessages().DoAndReturn(func() ([]*message.Message, error) {
+		close(started)
+		return []*message.Message{}, nil
+	})
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	polling := make(chan struct{})
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		close(polling)
+	})
+	m := &message.Message{Source: 1, Destination: 2, DepositNonce: 3}
+	routed := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessageStatus(m).DoAndReturn(func(m *message.Message) (message.MessageStatus, error) {
+		close(routed)
+		return message.MessageStatusExecuted, nil
+	})
+	chain := &replayableChain{
+		MockRelayedChain: s.mockRelayedChain,
+		replay: func(ctx context.Context, from *big.Int, to *big.Int, msgChan chan *message.Message) error {
+			s.Equal(big.NewInt(1), from)
+			s.Equal(big.NewInt(10), to)
+			msgChan <- m
+			return nil
+		},
+	}
+	relayer := NewRelayer(
+		[]RelayedChain{chain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	go relayer.Start(ctx, make(chan error))
+	<-started
+	<-polling
+
+	err := relayer.ReplayBlocks(context.Background(), 1, big.NewInt(1), big.NewInt(10))
+
+	s.Nil(err)
+	<-routed
+}
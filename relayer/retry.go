@@ -0,0 +1,58 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var (
+	// Sleep waits for the provided duration or until the context is cancelled
+	Sleep = func(ctx context.Context, d time.Duration) error {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	// DefaultRetryPolicy is used for destinations without registered retry policy
+	DefaultRetryPolicy = RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     5 * time.Second,
+		MaxBackoff:  5 * time.Minute,
+		Jitter:      0.2,
+	}
+)
+
+// RetryPolicy defines how many times and how often writing a message
+// to the destination chain is attempted before the message is dead-lettered
+type RetryPolicy struct {
+	// MaxAttempts is the total number of write attempts
+	MaxAttempts int
+	// Backoff is the delay before the first retry which is doubled on each next retry
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+	// Jitter randomizes delay by up to +/- Jitter fraction of it
+	Jitter float64
+}
+
+// delay calculates exponential backoff delay before provided retry attempt starting from 1
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	delay := float64(p.Backoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(delay)
+}
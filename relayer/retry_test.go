@@ -0,0 +1,39 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RetryPolicyTestSuite struct {
+	suite.Suite
+}
+
+func TestRunRetryPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryPolicyTestSuite))
+}
+
+func (s *RetryPolicyTestSuite) TestDelayIsExponential() {
+	policy := RetryPolicy{Backoff: time.Second, MaxBackoff: time.Minute}
+
+	s.Equal(policy.delay(1), time.Second)
+	s.Equal(policy.delay(2), 2*time.Second)
+	s.Equal(policy.delay(4), 8*time.Second)
+}
+
+func (s *RetryPolicyTestSuite) TestDelayIsCappedByMaxBackoff() {
+	policy := RetryPolicy{Backoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	s.Equal(policy.delay(10), 10*time.Second)
+}
+
+func (s *RetryPolicyTestSuite) TestDelayWithJitterIsInRange() {
+	policy := RetryPolicy{Backoff: 10 * time.Second, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		delay := policy.delay(1)
+		s.True(delay >= 5*time.Second && delay <= 15*time.Second)
+	}
+}
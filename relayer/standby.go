@@ -0,0 +1,62 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package relayer
+
+import (
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/rs/zerolog/log"
+)
+
+// Standby stops polling events of all chains and routing messages so that another
+// relayer instance using the same key can relay them instead.
+// Messages stay in the outbox until the relayer is activated.
+func (r *Relayer) Standby() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.standby {
+		return
+	}
+	r.standby = true
+	for domainID := range r.polls {
+		r.stopPolling(domainID)
+	}
+
+	log.Info().Msg("Relayer on standby")
+}
+
+// Activate restarts polling events of chains that are not paused from the last
+// stored block and routes messages left in the outbox while on standby
+func (r *Relayer) Activate() {
+	r.lock.Lock()
+	if !r.standby {
+		r.lock.Unlock()
+		return
+	}
+	r.standby = false
+	running := r.messages != nil
+	if running {
+		for _, c := range r.relayedChains {
+			if !r.pausedChains[c.DomainID()] {
+				r.startPolling(c)
+			}
+		}
+	}
+	r.lock.Unlock()
+
+	log.Info().Msg("Relayer activated")
+	if running {
+		go r.replayStored(func(m *message.Message) bool {
+			return true
+		})
+	}
+}
+
+// IsActive checks if the relayer is polling events and routing messages
+func (r *Relayer) IsActive() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return !r.standby
+}
@@ -0,0 +1,59 @@
+package relayer
+
+import (
+	"context"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/golang/mock/gomock"
+)
+
+func (s *RouteTestSuite) TestStandbyLeavesMessageInOutbox() {
+	s.mockMessageStore.EXPECT().GetMessageStatus(gomock.Any()).Return(message.MessageStatusReceived, nil)
+	relayer := NewRelayer(
+		[]RelayedChain{},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+
+	relayer.Standby()
+	s.False(relayer.IsActive())
+
+	relayer.route(context.Background(), &message.Message{Source: 2, Destination: 1})
+}
+
+func (s *RouteTestSuite) TestStandbyDoesNotPollUntilActivated() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pollCtx := make(chan context.Context, 1)
+	started := make(chan struct{})
+	replayed := make(chan struct{})
+	s.mockMessageStore.EXPECT().GetMessages().DoAndReturn(func() ([]*message.Message, error) {
+		close(started)
+		return []*message.Message{}, nil
+	})
+	s.mockMessageStore.EXPECT().GetMessages().DoAndReturn(func() ([]*message.Message, error) {
+		close(replayed)
+		return []*message.Message{}, nil
+	})
+	s.mockRelayedChain.EXPECT().DomainID().Return(uint8(1)).AnyTimes()
+	s.mockRelayedChain.EXPECT().PollEvents(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, sysErr chan<- error, msgChan chan *message.Message) {
+		pollCtx <- ctx
+	})
+	relayer := NewRelayer(
+		[]RelayedChain{s.mockRelayedChain},
+		s.mockMetrics,
+		s.mockMessageStore,
+	)
+	relayer.Standby()
+	go relayer.Start(ctx, make(chan error))
+	<-started
+
+	relayer.Activate()
+	activeCtx := <-pollCtx
+	s.Nil(activeCtx.Err())
+	s.True(relayer.IsActive())
+	<-replayed
+
+	relayer.Standby()
+	s.NotNil(activeCtx.Err())
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// DepositID identifies deposit made on the chain by its destination and nonce.
+// Token registrations share nonces with deposits and are marked separately.
+// DataHash is a hash of the deposit resource ID and payload so that a different
+// deposit made with the same nonce after a reorganisation is not mistaken for it.
+type DepositID struct {
+	Destination       uint8       `json:"destination"`
+	DepositNonce      uint64      `json:"depositNonce"`
+	TokenRegistration bool        `json:"tokenRegistration,omitempty"`
+	DataHash          common.Hash `json:"dataHash"`
+}
+
+type depositData struct {
+	ResourceID types.ResourceID
+	Payload    [][]byte
+}
+
+// NewDepositID identifies deposit of the message
+func NewDepositID(m *message.Message) DepositID {
+	data := depositData{ResourceID: m.ResourceId, Payload: make([][]byte, 0, len(m.Payload))}
+	for _, p := range m.Payload {
+		if b, ok := p.([]byte); ok {
+			data.Payload = append(data.Payload, b)
+		}
+	}
+	// encoding of resource ID and byte slices can not fail
+	encoded, _ := rlp.EncodeToBytes(&data)
+
+	return DepositID{
+		Destination:       m.Destination,
+		DepositNonce:      m.DepositNonce,
+		TokenRegistration: m.IsTokenRegistration(),
+		DataHash:          crypto.Keccak256Hash(encoded),
+	}
+}
+
+// ProcessedBlock is a block processed by the listener along with deposits resolved from it
+type ProcessedBlock struct {
+	Hash     common.Hash `json:"hash"`
+	Deposits []DepositID `json:"deposits"`
+}
+
+// HashStore keeps hashes of recently processed blocks so that
+// the listener can detect blocks removed by chain reorganisations
+type HashStore struct {
+	db KeyValueStore
+}
+
+func NewHashStore(db KeyValueStore) *HashStore {
+	return &HashStore{
+		db: db,
+	}
+}
+
+// StoreProcessedBlock stores processed block by its number per domainID
+func (hs *HashStore) StoreProcessedBlock(domainID uint8, block uint64, processed *ProcessedBlock) error {
+	value, err := json.Marshal(processed)
+	if err != nil {
+		return err
+	}
+
+	return hs.db.SetByKey(hashKey(domainID, block), value)
+}
+
+// GetProcessedBlock returns processed block by its number or nil if it is not stored
+func (hs *HashStore) GetProcessedBlock(domainID uint8, block uint64) (*ProcessedBlock, error) {
+	value, err := hs.db.GetByKey(hashKey(domainID, block))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	processed := &ProcessedBlock{}
+	err = json.Unmarshal(value, processed)
+	if err != nil {
+		return nil, err
+	}
+	return processed, nil
+}
+
+// DeleteProcessedBlock removes processed block by its number
+func (hs *HashStore) DeleteProcessedBlock(domainID uint8, block uint64) error {
+	return hs.db.DeleteByKey(hashKey(domainID, block))
+}
+
+func hashKey(domainID uint8, block uint64) []byte {
+	return []byte(fmt.Sprintf("chain:%d:hash:%d", domainID, block))
+}
@@ -0,0 +1,79 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_store "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type HashStoreTestSuite struct {
+	suite.Suite
+	hashStore     *store.HashStore
+	keyValueStore *mock_store.MockKeyValueStore
+}
+
+func TestRunHashStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(HashStoreTestSuite))
+}
+
+func (s *HashStoreTestSuite) SetupSuite()    {}
+func (s *HashStoreTestSuite) TearDownSuite() {}
+func (s *HashStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueStore = mock_store.NewMockKeyValueStore(gomockController)
+	s.hashStore = store.NewHashStore(s.keyValueStore)
+}
+func (s *HashStoreTestSuite) TearDownTest() {}
+
+func (s *HashStoreTestSuite) TestStoredProcessedBlockIsReturned() {
+	processed := &store.ProcessedBlock{
+		Hash:     common.HexToHash("0x1"),
+		Deposits: []store.DepositID{{Destination: 2, DepositNonce: 3}},
+	}
+	var value []byte
+	s.keyValueStore.EXPECT().SetByKey([]byte("chain:1:hash:100"), gomock.Any()).DoAndReturn(func(key, v []byte) error {
+		value = v
+		return nil
+	})
+	s.keyValueStore.EXPECT().GetByKey([]byte("chain:1:hash:100")).DoAndReturn(func(key []byte) ([]byte, error) {
+		return value, nil
+	})
+
+	err := s.hashStore.StoreProcessedBlock(1, 100, processed)
+	s.Nil(err)
+
+	stored, err := s.hashStore.GetProcessedBlock(1, 100)
+	s.Nil(err)
+	s.Equal(processed, stored)
+}
+
+func (s *HashStoreTestSuite) TestMissingProcessedBlockIsNil() {
+	s.keyValueStore.EXPECT().GetByKey([]byte("chain:1:hash:100")).Return(nil, leveldb.ErrNotFound)
+
+	stored, err := s.hashStore.GetProcessedBlock(1, 100)
+
+	s.Nil(err)
+	s.Nil(stored)
+}
+
+func (s *HashStoreTestSuite) TestGetProcessedBlockFails() {
+	s.keyValueStore.EXPECT().GetByKey([]byte("chain:1:hash:100")).Return(nil, errors.New("error"))
+
+	_, err := s.hashStore.GetProcessedBlock(1, 100)
+
+	s.NotNil(err)
+}
+
+func (s *HashStoreTestSuite) TestDeleteProcessedBlock() {
+	s.keyValueStore.EXPECT().DeleteByKey([]byte("chain:1:hash:100")).Return(nil)
+
+	err := s.hashStore.DeleteProcessedBlock(1, 100)
+
+	s.Nil(err)
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	transferPrefix       = "limits:transfer:"
+	circuitBreakerPrefix = "limits:breaker:"
+)
+
+// Transfer is a fungible transfer counted towards transfer volume of a resource
+type Transfer struct {
+	Source       uint8
+	Destination  uint8
+	DepositNonce uint64
+	Amount       *big.Int
+	// Timestamp is unix time in seconds at which the transfer was allowed
+	Timestamp int64
+}
+
+type LimitStore struct {
+	db KeyValueStore
+}
+
+func NewLimitStore(db KeyValueStore) *LimitStore {
+	return &LimitStore{
+		db: db,
+	}
+}
+
+// StoreTransfer persists transfer of the resource. Storing the same deposit again overwrites it.
+func (ls *LimitStore) StoreTransfer(resourceID types.ResourceID, t *Transfer) error {
+	value, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	return ls.db.SetByKey(transferKey(resourceID, t), value)
+}
+
+// GetTransfers returns all stored transfers of the resource
+func (ls *LimitStore) GetTransfers(resourceID types.ResourceID) ([]*Transfer, error) {
+	values, err := ls.db.GetByPrefix([]byte(fmt.Sprintf("%s%x:", transferPrefix, resourceID)))
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*Transfer, len(values))
+	for i, v := range values {
+		t := &Transfer{}
+		err := json.Unmarshal(v, t)
+		if err != nil {
+			return nil, err
+		}
+		transfers[i] = t
+	}
+	return transfers, nil
+}
+
+// DeleteTransfer removes transfer of the resource
+func (ls *LimitStore) DeleteTransfer(resourceID types.ResourceID, t *Transfer) error {
+	return ls.db.DeleteByKey(transferKey(resourceID, t))
+}
+
+// StoreCircuitBreaker persists circuit breaker state of the resource
+func (ls *LimitStore) StoreCircuitBreaker(resourceID types.ResourceID, tripped bool) error {
+	key := []byte(fmt.Sprintf("%s%x", circuitBreakerPrefix, resourceID))
+	if !tripped {
+		return ls.db.DeleteByKey(key)
+	}
+	return ls.db.SetByKey(key, []byte{1})
+}
+
+// IsCircuitBreakerTripped returns true if circuit breaker of the resource is tripped
+func (ls *LimitStore) IsCircuitBreakerTripped(resourceID types.ResourceID) (bool, error) {
+	_, err := ls.db.GetByKey([]byte(fmt.Sprintf("%s%x", circuitBreakerPrefix, resourceID)))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func transferKey(resourceID types.ResourceID, t *Transfer) []byte {
+	return []byte(fmt.Sprintf("%s%x:%03d:%03d:%020d", transferPrefix, resourceID, t.Source, t.Destination, t.DepositNonce))
+}
@@ -0,0 +1,101 @@
+package store_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_store "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/VaivalGithub/chainsafe-core/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type LimitStoreTestSuite struct {
+	suite.Suite
+	limitStore    *store.LimitStore
+	keyValueStore *mock_store.MockKeyValueStore
+	resourceID    types.ResourceID
+}
+
+func TestRunLimitStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(LimitStoreTestSuite))
+}
+
+func (s *LimitStoreTestSuite) SetupSuite()    {}
+func (s *LimitStoreTestSuite) TearDownSuite() {}
+func (s *LimitStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueStore = mock_store.NewMockKeyValueStore(gomockController)
+	s.limitStore = store.NewLimitStore(s.keyValueStore)
+	s.resourceID = types.ResourceID{31: 1}
+}
+func (s *LimitStoreTestSuite) TearDownTest() {}
+
+func (s *LimitStoreTestSuite) TestStoreTransfer() {
+	key := "limits:transfer:0000000000000000000000000000000000000000000000000000000000000001:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().SetByKey([]byte(key), gomock.Any()).Return(nil)
+
+	err := s.limitStore.StoreTransfer(s.resourceID, &store.Transfer{Source: 1, Destination: 2, DepositNonce: 3, Amount: big.NewInt(10)})
+
+	s.Nil(err)
+}
+
+func (s *LimitStoreTestSuite) TestGetTransfers() {
+	transfer := &store.Transfer{Source: 1, Destination: 2, DepositNonce: 3, Amount: big.NewInt(10), Timestamp: 100}
+	value, _ := json.Marshal(transfer)
+	prefix := "limits:transfer:0000000000000000000000000000000000000000000000000000000000000001:"
+	s.keyValueStore.EXPECT().GetByPrefix([]byte(prefix)).Return([][]byte{value}, nil)
+
+	transfers, err := s.limitStore.GetTransfers(s.resourceID)
+
+	s.Nil(err)
+	s.Equal(transfers, []*store.Transfer{transfer})
+}
+
+func (s *LimitStoreTestSuite) TestGetTransfers_FailedFetch() {
+	s.keyValueStore.EXPECT().GetByPrefix(gomock.Any()).Return(nil, errors.New("error"))
+
+	_, err := s.limitStore.GetTransfers(s.resourceID)
+
+	s.NotNil(err)
+}
+
+func (s *LimitStoreTestSuite) TestStoreCircuitBreaker_Tripped() {
+	key := "limits:breaker:0000000000000000000000000000000000000000000000000000000000000001"
+	s.keyValueStore.EXPECT().SetByKey([]byte(key), []byte{1}).Return(nil)
+
+	err := s.limitStore.StoreCircuitBreaker(s.resourceID, true)
+
+	s.Nil(err)
+}
+
+func (s *LimitStoreTestSuite) TestStoreCircuitBreaker_Reset() {
+	key := "limits:breaker:0000000000000000000000000000000000000000000000000000000000000001"
+	s.keyValueStore.EXPECT().DeleteByKey([]byte(key)).Return(nil)
+
+	err := s.limitStore.StoreCircuitBreaker(s.resourceID, false)
+
+	s.Nil(err)
+}
+
+func (s *LimitStoreTestSuite) TestIsCircuitBreakerTripped_NotFound() {
+	s.keyValueStore.EXPECT().GetByKey(gomock.Any()).Return(nil, leveldb.ErrNotFound)
+
+	tripped, err := s.limitStore.IsCircuitBreakerTripped(s.resourceID)
+
+	s.Nil(err)
+	s.False(tripped)
+}
+
+func (s *LimitStoreTestSuite) TestIsCircuitBreakerTripped_Tripped() {
+	s.keyValueStore.EXPECT().GetByKey(gomock.Any()).Return([]byte{1}, nil)
+
+	tripped, err := s.limitStore.IsCircuitBreakerTripped(s.resourceID)
+
+	s.Nil(err)
+	s.True(tripped)
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	outboxPrefix     = "outbox:"
+	deadLetterPrefix = "deadletter:"
+	statusPrefix     = "status:"
+	heldPrefix       = "held:"
+	// registrationNamespace separates keys of token registrations from deposits with the same nonce
+	registrationNamespace = "registration:"
+)
+
+type MessageStore struct {
+	db KeyValueStore
+}
+
+func NewMessageStore(db KeyValueStore) *MessageStore {
+	return &MessageStore{
+		db: db,
+	}
+}
+
+// StoreMessage persists message into the outbox until it is deleted
+// after being successfully written to the destination chain
+func (ms *MessageStore) StoreMessage(m *message.Message) error {
+	value, err := encodeMessage(m)
+	if err != nil {
+		return err
+	}
+
+	return ms.db.SetByKey(messageKey(outboxPrefix, m), value)
+}
+
+// DeleteMessage removes message from the outbox
+func (ms *MessageStore) DeleteMessage(m *message.Message) error {
+	return ms.db.DeleteByKey(messageKey(outboxPrefix, m))
+}
+
+// GetMessages returns all messages from the outbox ordered by source, destination and deposit nonce
+func (ms *MessageStore) GetMessages() ([]*message.Message, error) {
+	return ms.getMessagesByPrefix(outboxPrefix)
+}
+
+// StoreDeadLetter persists message that exhausted write retries into the dead letters bucket
+func (ms *MessageStore) StoreDeadLetter(m *message.Message) error {
+	value, err := encodeMessage(m)
+	if err != nil {
+		return err
+	}
+
+	return ms.db.SetByKey(messageKey(deadLetterPrefix, m), value)
+}
+
+// GetDeadLetters returns all dead-lettered messages
+func (ms *MessageStore) GetDeadLetters() ([]*message.Message, error) {
+	return ms.getMessagesByPrefix(deadLetterPrefix)
+}
+
+// RequeueDeadLetter moves dead-lettered message back to the outbox
+func (ms *MessageStore) RequeueDeadLetter(source, destination uint8, depositNonce uint64) (*message.Message, error) {
+	m, err := ms.getMessage(deadLetterPrefix, source, destination, depositNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ms.StoreMessage(m)
+	if err != nil {
+		return nil, err
+	}
+	err = ms.db.DeleteByKey(messageKey(deadLetterPrefix, m))
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StoreHeldMessage persists message that is held from relaying into the held messages bucket
+func (ms *MessageStore) StoreHeldMessage(m *message.Message) error {
+	value, err := encodeMessage(m)
+	if err != nil {
+		return err
+	}
+
+	return ms.db.SetByKey(messageKey(heldPrefix, m), value)
+}
+
+// GetHeldMessages returns all held messages
+func (ms *MessageStore) GetHeldMessages() ([]*message.Message, error) {
+	return ms.getMessagesByPrefix(heldPrefix)
+}
+
+// ApproveHeldMessage moves held message back to the outbox and marks it as approved
+// so that it is relayed even if message processors hold it again
+func (ms *MessageStore) ApproveHeldMessage(source, destination uint8, depositNonce uint64) (*message.Message, error) {
+	m, err := ms.getMessage(heldPrefix, source, destination, depositNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ms.StoreMessage(m)
+	if err != nil {
+		return nil, err
+	}
+	err = ms.StoreMessageStatus(m, message.MessageStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+	err = ms.db.DeleteByKey(messageKey(heldPrefix, m))
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RejectHeldMessage removes held message and marks it as rejected so that it is never relayed
+func (ms *MessageStore) RejectHeldMessage(source, destination uint8, depositNonce uint64) (*message.Message, error) {
+	m, err := ms.getMessage(heldPrefix, source, destination, depositNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ms.StoreMessageStatus(m, message.MessageStatusRejected)
+	if err != nil {
+		return nil, err
+	}
+	err = ms.db.DeleteByKey(messageKey(heldPrefix, m))
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DeleteHeldMessage removes message from held messages without changing its status
+func (ms *MessageStore) DeleteHeldMessage(m *message.Message) error {
+	return ms.db.DeleteByKey(messageKey(heldPrefix, m))
+}
+
+// StoreMessageStatus persists processing status of the deposit identified by message source, destination and deposit nonce
+func (ms *MessageStore) StoreMessageStatus(m *message.Message, status message.MessageStatus) error {
+	return ms.db.SetByKey(messageKey(statusPrefix, m), []byte{byte(status)})
+}
+
+// GetMessageStatus returns processing status of the deposit identified by message source, destination and deposit nonce.
+// If the deposit was never seen MessageStatusUnknown is returned.
+func (ms *MessageStore) GetMessageStatus(m *message.Message) (message.MessageStatus, error) {
+	value, err := ms.db.GetByKey(messageKey(statusPrefix, m))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return message.MessageStatusUnknown, nil
+		}
+		return message.MessageStatusUnknown, err
+	}
+	if len(value) != 1 {
+		return message.MessageStatusUnknown, fmt.Errorf("invalid status value %v", value)
+	}
+	return message.MessageStatus(value[0]), nil
+}
+
+// getMessage fetches message of the deposit identified by source, destination and deposit nonce
+func (ms *MessageStore) getMessage(prefix string, source, destination uint8, depositNonce uint64) (*message.Message, error) {
+	key := messageKey(prefix, &message.Message{Source: source, Destination: destination, DepositNonce: depositNonce})
+	value, err := ms.db.GetByKey(key)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return decodeMessage(value)
+}
+
+func (ms *MessageStore) getMessagesByPrefix(prefix string) ([]*message.Message, error) {
+	values, err := ms.db.GetByPrefix([]byte(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*message.Message, len(values))
+	for i, v := range values {
+		m, err := decodeMessage(v)
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = m
+	}
+	return msgs, nil
+}
+
+// messageKey builds message key so that lexicographical key order
+// matches order of deposit nonces for the same route.
+// Token registrations are keyed in a separate namespace as they share nonces with deposits.
+func messageKey(prefix string, m *message.Message) []byte {
+	if m.IsTokenRegistration() {
+		prefix += registrationNamespace
+	}
+	return []byte(fmt.Sprintf("%s%03d:%03d:%020d", prefix, m.Source, m.Destination, m.DepositNonce))
+}
+
+func encodeMessage(m *message.Message) ([]byte, error) {
+	return json.Marshal(m)
+}
+
+func decodeMessage(value []byte) (*message.Message, error) {
+	m := &message.Message{}
+	err := json.Unmarshal(value, m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,245 @@
+package store_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/relayer/message"
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_store "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type MessageStoreTestSuite struct {
+	suite.Suite
+	messageStore  *store.MessageStore
+	keyValueStore *mock_store.MockKeyValueStore
+	storedMessage *message.Message
+}
+
+func TestRunMessageStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageStoreTestSuite))
+}
+
+func (s *MessageStoreTestSuite) SetupSuite()    {}
+func (s *MessageStoreTestSuite) TearDownSuite() {}
+func (s *MessageStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueStore = mock_store.NewMockKeyValueStore(gomockController)
+	s.messageStore = store.NewMessageStore(s.keyValueStore)
+	s.storedMessage = message.NewMessage(1, 2, 3, [32]byte{1}, message.FungibleTransfer, []interface{}{[]byte{1}, []byte{2}}, message.Metadata{Priority: 1})
+	s.storedMessage.Sender = common.HexToAddress("0x5C1F5961696BaD2e73f73417f07EF55C62a2dC5b")
+}
+func (s *MessageStoreTestSuite) TearDownTest() {}
+
+func (s *MessageStoreTestSuite) TestStoreMessage_FailedStore() {
+	key := "outbox:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().SetByKey([]byte(key), gomock.Any()).Return(errors.New("error"))
+
+	err := s.messageStore.StoreMessage(s.storedMessage)
+
+	s.NotNil(err)
+}
+
+func (s *MessageStoreTestSuite) TestStoreMessage_InvalidPayload() {
+	m := message.NewMessage(1, 2, 3, [32]byte{1}, message.FungibleTransfer, []interface{}{"invalid"}, message.Metadata{})
+
+	err := s.messageStore.StoreMessage(m)
+
+	s.NotNil(err)
+}
+
+func (s *MessageStoreTestSuite) TestDeleteMessage() {
+	key := "outbox:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().DeleteByKey([]byte(key)).Return(nil)
+
+	err := s.messageStore.DeleteMessage(s.storedMessage)
+
+	s.Nil(err)
+}
+
+func (s *MessageStoreTestSuite) TestGetMessages_FailedFetch() {
+	s.keyValueStore.EXPECT().GetByPrefix([]byte("outbox:")).Return(nil, errors.New("error"))
+
+	_, err := s.messageStore.GetMessages()
+
+	s.NotNil(err)
+}
+
+func (s *MessageStoreTestSuite) TestGetMessages_ReturnsStoredMessages() {
+	var value []byte
+	s.keyValueStore.EXPECT().SetByKey(gomock.Any(), gomock.Any()).DoAndReturn(func(key, v []byte) error {
+		value = v
+		return nil
+	})
+	err := s.messageStore.StoreMessage(s.storedMessage)
+	s.Nil(err)
+	s.keyValueStore.EXPECT().GetByPrefix([]byte("outbox:")).Return([][]byte{value}, nil)
+
+	msgs, err := s.messageStore.GetMessages()
+
+	s.Nil(err)
+	s.Equal(msgs, []*message.Message{s.storedMessage})
+}
+
+func (s *MessageStoreTestSuite) TestStoreDeadLetter() {
+	key := "deadletter:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().SetByKey([]byte(key), gomock.Any()).Return(nil)
+
+	err := s.messageStore.StoreDeadLetter(s.storedMessage)
+
+	s.Nil(err)
+}
+
+func (s *MessageStoreTestSuite) TestRequeueDeadLetter_NotFound() {
+	key := "deadletter:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+
+	_, err := s.messageStore.RequeueDeadLetter(1, 2, 3)
+
+	s.Equal(err, store.ErrNotFound)
+}
+
+func (s *MessageStoreTestSuite) TestRequeueDeadLetter_MovesMessageToOutbox() {
+	var value []byte
+	s.keyValueStore.EXPECT().SetByKey([]byte("deadletter:001:002:00000000000000000003"), gomock.Any()).DoAndReturn(func(key, v []byte) error {
+		value = v
+		return nil
+	})
+	err := s.messageStore.StoreDeadLetter(s.storedMessage)
+	s.Nil(err)
+	s.keyValueStore.EXPECT().GetByKey([]byte("deadletter:001:002:00000000000000000003")).Return(value, nil)
+	s.keyValueStore.EXPECT().SetByKey([]byte("outbox:001:002:00000000000000000003"), value).Return(nil)
+	s.keyValueStore.EXPECT().DeleteByKey([]byte("deadletter:001:002:00000000000000000003")).Return(nil)
+
+	m, err := s.messageStore.RequeueDeadLetter(1, 2, 3)
+
+	s.Nil(err)
+	s.Equal(m, s.storedMessage)
+}
+
+func (s *MessageStoreTestSuite) TestStoreMessageStatus() {
+	key := "status:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().SetByKey([]byte(key), []byte{byte(message.MessageStatusVoted)}).Return(nil)
+
+	err := s.messageStore.StoreMessageStatus(s.storedMessage, message.MessageStatusVoted)
+
+	s.Nil(err)
+}
+
+func (s *MessageStoreTestSuite) TestStoreMessageStatus_TokenRegistration() {
+	key := "status:registration:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().SetByKey([]byte(key), []byte{byte(message.MessageStatusVoted)}).Return(nil)
+	m := message.NewTokenRegistrationMessage(&message.Message2{Source: 1, Destination: 2, DepositNonce: 3})
+
+	err := s.messageStore.StoreMessageStatus(m, message.MessageStatusVoted)
+
+	s.Nil(err)
+}
+
+func (s *MessageStoreTestSuite) TestGetMessageStatus_TokenRegistrationRetraction() {
+	key := "status:registration:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().GetByKey([]byte(key)).Return([]byte{byte(message.MessageStatusVoted)}, nil)
+
+	status, err := s.messageStore.GetMessageStatus(message.NewTokenRegistrationRetractionMessage(1, 2, 3))
+
+	s.Nil(err)
+	s.Equal(status, message.MessageStatusVoted)
+}
+
+func (s *MessageStoreTestSuite) TestGetMessageStatus_DepositRetraction() {
+	key := "status:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().GetByKey([]byte(key)).Return([]byte{byte(message.MessageStatusVoted)}, nil)
+
+	status, err := s.messageStore.GetMessageStatus(message.NewRetractionMessage(1, 2, 3))
+
+	s.Nil(err)
+	s.Equal(status, message.MessageStatusVoted)
+}
+
+func (s *MessageStoreTestSuite) TestGetMessageStatus_NotFound() {
+	key := "status:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+
+	status, err := s.messageStore.GetMessageStatus(s.storedMessage)
+
+	s.Nil(err)
+	s.Equal(status, message.MessageStatusUnknown)
+}
+
+func (s *MessageStoreTestSuite) TestGetMessageStatus_FailedFetch() {
+	key := "status:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().GetByKey([]byte(key)).Return(nil, errors.New("error"))
+
+	_, err := s.messageStore.GetMessageStatus(s.storedMessage)
+
+	s.NotNil(err)
+}
+
+func (s *MessageStoreTestSuite) TestGetMessageStatus_Stored() {
+	key := "status:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().GetByKey([]byte(key)).Return([]byte{byte(message.MessageStatusExecuted)}, nil)
+
+	status, err := s.messageStore.GetMessageStatus(s.storedMessage)
+
+	s.Nil(err)
+	s.Equal(status, message.MessageStatusExecuted)
+}
+
+func (s *MessageStoreTestSuite) TestStoreHeldMessage() {
+	key := "held:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().SetByKey([]byte(key), gomock.Any()).Return(nil)
+
+	err := s.messageStore.StoreHeldMessage(s.storedMessage)
+
+	s.Nil(err)
+}
+
+func (s *MessageStoreTestSuite) TestApproveHeldMessage_NotFound() {
+	key := "held:001:002:00000000000000000003"
+	s.keyValueStore.EXPECT().GetByKey([]byte(key)).Return(nil, leveldb.ErrNotFound)
+
+	_, err := s.messageStore.ApproveHeldMessage(1, 2, 3)
+
+	s.Equal(err, store.ErrNotFound)
+}
+
+func (s *MessageStoreTestSuite) TestApproveHeldMessage_MovesMessageToOutbox() {
+	var value []byte
+	s.keyValueStore.EXPECT().SetByKey([]byte("held:001:002:00000000000000000003"), gomock.Any()).DoAndReturn(func(key, v []byte) error {
+		value = v
+		return nil
+	})
+	err := s.messageStore.StoreHeldMessage(s.storedMessage)
+	s.Nil(err)
+	s.keyValueStore.EXPECT().GetByKey([]byte("held:001:002:00000000000000000003")).Return(value, nil)
+	s.keyValueStore.EXPECT().SetByKey([]byte("outbox:001:002:00000000000000000003"), value).Return(nil)
+	s.keyValueStore.EXPECT().SetByKey([]byte("status:001:002:00000000000000000003"), []byte{byte(message.MessageStatusApproved)}).Return(nil)
+	s.keyValueStore.EXPECT().DeleteByKey([]byte("held:001:002:00000000000000000003")).Return(nil)
+
+	m, err := s.messageStore.ApproveHeldMessage(1, 2, 3)
+
+	s.Nil(err)
+	s.Equal(m, s.storedMessage)
+}
+
+func (s *MessageStoreTestSuite) TestRejectHeldMessage() {
+	var value []byte
+	s.keyValueStore.EXPECT().SetByKey([]byte("held:001:002:00000000000000000003"), gomock.Any()).DoAndReturn(func(key, v []byte) error {
+		value = v
+		return nil
+	})
+	err := s.messageStore.StoreHeldMessage(s.storedMessage)
+	s.Nil(err)
+	s.keyValueStore.EXPECT().GetByKey([]byte("held:001:002:00000000000000000003")).Return(value, nil)
+	s.keyValueStore.EXPECT().SetByKey([]byte("status:001:002:00000000000000000003"), []byte{byte(message.MessageStatusRejected)}).Return(nil)
+	s.keyValueStore.EXPECT().DeleteByKey([]byte("held:001:002:00000000000000000003")).Return(nil)
+
+	m, err := s.messageStore.RejectHeldMessage(1, 2, 3)
+
+	s.Nil(err)
+	s.Equal(m, s.storedMessage)
+}
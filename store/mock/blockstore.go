@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: blockstore/blockstore.go
+// Source: ./store/store.go
 
 // Package mock_blockstore is a generated GoMock package.
 package mock_blockstore
@@ -136,3 +136,264 @@ func (mr *MockKeyValueWriterMockRecorder) SetByKey(key, value interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetByKey", reflect.TypeOf((*MockKeyValueWriter)(nil).SetByKey), key, value)
 }
+
+// MockKeyValueStore is a mock of KeyValueStore interface.
+type MockKeyValueStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyValueStoreMockRecorder
+}
+
+// MockKeyValueStoreMockRecorder is the mock recorder for MockKeyValueStore.
+type MockKeyValueStoreMockRecorder struct {
+	mock *MockKeyValueStore
+}
+
+// NewMockKeyValueStore creates a new mock instance.
+func NewMockKeyValueStore(ctrl *gomock.Controller) *MockKeyValueStore {
+	mock := &MockKeyValueStore{ctrl: ctrl}
+	mock.recorder = &MockKeyValueStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyValueStore) EXPECT() *MockKeyValueStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteByKey mocks base method.
+func (m *MockKeyValueStore) DeleteByKey(key []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByKey indicates an expected call of DeleteByKey.
+func (mr *MockKeyValueStoreMockRecorder) DeleteByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByKey", reflect.TypeOf((*MockKeyValueStore)(nil).DeleteByKey), key)
+}
+
+// GetByKey mocks base method.
+func (m *MockKeyValueStore) GetByKey(key []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockKeyValueStoreMockRecorder) GetByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockKeyValueStore)(nil).GetByKey), key)
+}
+
+// GetByPrefix mocks base method.
+func (m *MockKeyValueStore) GetByPrefix(prefix []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPrefix", prefix)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPrefix indicates an expected call of GetByPrefix.
+func (mr *MockKeyValueStoreMockRecorder) GetByPrefix(prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPrefix", reflect.TypeOf((*MockKeyValueStore)(nil).GetByPrefix), prefix)
+}
+
+// SetByKey mocks base method.
+func (m *MockKeyValueStore) SetByKey(key, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetByKey", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetByKey indicates an expected call of SetByKey.
+func (mr *MockKeyValueStoreMockRecorder) SetByKey(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetByKey", reflect.TypeOf((*MockKeyValueStore)(nil).SetByKey), key, value)
+}
+
+// MockKeyValueConditionalStore is a mock of KeyValueConditionalStore interface.
+type MockKeyValueConditionalStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyValueConditionalStoreMockRecorder
+}
+
+// MockKeyValueConditionalStoreMockRecorder is the mock recorder for MockKeyValueConditionalStore.
+type MockKeyValueConditionalStoreMockRecorder struct {
+	mock *MockKeyValueConditionalStore
+}
+
+// NewMockKeyValueConditionalStore creates a new mock instance.
+func NewMockKeyValueConditionalStore(ctrl *gomock.Controller) *MockKeyValueConditionalStore {
+	mock := &MockKeyValueConditionalStore{ctrl: ctrl}
+	mock.recorder = &MockKeyValueConditionalStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyValueConditionalStore) EXPECT() *MockKeyValueConditionalStoreMockRecorder {
+	return m.recorder
+}
+
+// CompareAndSetByKey mocks base method.
+func (m *MockKeyValueConditionalStore) CompareAndSetByKey(key, old, value []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSetByKey", key, old, value)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSetByKey indicates an expected call of CompareAndSetByKey.
+func (mr *MockKeyValueConditionalStoreMockRecorder) CompareAndSetByKey(key, old, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSetByKey", reflect.TypeOf((*MockKeyValueConditionalStore)(nil).CompareAndSetByKey), key, old, value)
+}
+
+// GetByKey mocks base method.
+func (m *MockKeyValueConditionalStore) GetByKey(key []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockKeyValueConditionalStoreMockRecorder) GetByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockKeyValueConditionalStore)(nil).GetByKey), key)
+}
+
+// SetByKey mocks base method.
+func (m *MockKeyValueConditionalStore) SetByKey(key, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetByKey", key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetByKey indicates an expected call of SetByKey.
+func (mr *MockKeyValueConditionalStoreMockRecorder) SetByKey(key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetByKey", reflect.TypeOf((*MockKeyValueConditionalStore)(nil).SetByKey), key, value)
+}
+
+// MockKeyValueConditionalWriter is a mock of KeyValueConditionalWriter interface.
+type MockKeyValueConditionalWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyValueConditionalWriterMockRecorder
+}
+
+// MockKeyValueConditionalWriterMockRecorder is the mock recorder for MockKeyValueConditionalWriter.
+type MockKeyValueConditionalWriterMockRecorder struct {
+	mock *MockKeyValueConditionalWriter
+}
+
+// NewMockKeyValueConditionalWriter creates a new mock instance.
+func NewMockKeyValueConditionalWriter(ctrl *gomock.Controller) *MockKeyValueConditionalWriter {
+	mock := &MockKeyValueConditionalWriter{ctrl: ctrl}
+	mock.recorder = &MockKeyValueConditionalWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyValueConditionalWriter) EXPECT() *MockKeyValueConditionalWriterMockRecorder {
+	return m.recorder
+}
+
+// CompareAndSetByKey mocks base method.
+func (m *MockKeyValueConditionalWriter) CompareAndSetByKey(key, old, value []byte) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareAndSetByKey", key, old, value)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareAndSetByKey indicates an expected call of CompareAndSetByKey.
+func (mr *MockKeyValueConditionalWriterMockRecorder) CompareAndSetByKey(key, old, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSetByKey", reflect.TypeOf((*MockKeyValueConditionalWriter)(nil).CompareAndSetByKey), key, old, value)
+}
+
+// MockKeyValueDeleter is a mock of KeyValueDeleter interface.
+type MockKeyValueDeleter struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyValueDeleterMockRecorder
+}
+
+// MockKeyValueDeleterMockRecorder is the mock recorder for MockKeyValueDeleter.
+type MockKeyValueDeleterMockRecorder struct {
+	mock *MockKeyValueDeleter
+}
+
+// NewMockKeyValueDeleter creates a new mock instance.
+func NewMockKeyValueDeleter(ctrl *gomock.Controller) *MockKeyValueDeleter {
+	mock := &MockKeyValueDeleter{ctrl: ctrl}
+	mock.recorder = &MockKeyValueDeleterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyValueDeleter) EXPECT() *MockKeyValueDeleterMockRecorder {
+	return m.recorder
+}
+
+// DeleteByKey mocks base method.
+func (m *MockKeyValueDeleter) DeleteByKey(key []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByKey indicates an expected call of DeleteByKey.
+func (mr *MockKeyValueDeleterMockRecorder) DeleteByKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByKey", reflect.TypeOf((*MockKeyValueDeleter)(nil).DeleteByKey), key)
+}
+
+// MockKeyValuePrefixReader is a mock of KeyValuePrefixReader interface.
+type MockKeyValuePrefixReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyValuePrefixReaderMockRecorder
+}
+
+// MockKeyValuePrefixReaderMockRecorder is the mock recorder for MockKeyValuePrefixReader.
+type MockKeyValuePrefixReaderMockRecorder struct {
+	mock *MockKeyValuePrefixReader
+}
+
+// NewMockKeyValuePrefixReader creates a new mock instance.
+func NewMockKeyValuePrefixReader(ctrl *gomock.Controller) *MockKeyValuePrefixReader {
+	mock := &MockKeyValuePrefixReader{ctrl: ctrl}
+	mock.recorder = &MockKeyValuePrefixReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyValuePrefixReader) EXPECT() *MockKeyValuePrefixReaderMockRecorder {
+	return m.recorder
+}
+
+// GetByPrefix mocks base method.
+func (m *MockKeyValuePrefixReader) GetByPrefix(prefix []byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPrefix", prefix)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPrefix indicates an expected call of GetByPrefix.
+func (mr *MockKeyValuePrefixReaderMockRecorder) GetByPrefix(prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPrefix", reflect.TypeOf((*MockKeyValuePrefixReader)(nil).GetByPrefix), prefix)
+}
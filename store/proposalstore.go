@@ -0,0 +1,108 @@
+// Copyright 2021 ChainSafe Systems
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const proposalPrefix = "proposal:"
+
+// ProposalState is local state of a proposal on the bridge of a domain
+// built from proposal and vote events emitted by the bridge
+type ProposalState struct {
+	Source       uint8            `json:"source"`
+	DepositNonce uint64           `json:"depositNonce"`
+	DataHash     common.Hash      `json:"dataHash"`
+	Status       uint8            `json:"status"`
+	Voters       []common.Address `json:"voters"`
+	// ProposedBlock is the block of the first event of the proposal
+	ProposedBlock uint64 `json:"proposedBlock"`
+	// UpdatedBlock is the block of the latest event of the proposal
+	UpdatedBlock uint64 `json:"updatedBlock"`
+}
+
+// VoteCount returns number of relayers that voted for the proposal
+func (p *ProposalState) VoteCount() uint8 {
+	return uint8(len(p.Voters))
+}
+
+// IsVotedBy checks if the relayer voted for the proposal
+func (p *ProposalState) IsVotedBy(relayer common.Address) bool {
+	for _, voter := range p.Voters {
+		if voter == relayer {
+			return true
+		}
+	}
+	return false
+}
+
+type ProposalStore struct {
+	db KeyValueStore
+}
+
+func NewProposalStore(db KeyValueStore) *ProposalStore {
+	return &ProposalStore{
+		db: db,
+	}
+}
+
+// StoreProposal stores state of the proposal on the bridge of the domain
+func (ps *ProposalStore) StoreProposal(domainID uint8, p *ProposalState) error {
+	value, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return ps.db.SetByKey(proposalKey(domainID, p.Source, p.DepositNonce), value)
+}
+
+// GetProposal returns state of the proposal on the bridge of the domain or nil if it is not tracked
+func (ps *ProposalStore) GetProposal(domainID uint8, source uint8, depositNonce uint64) (*ProposalState, error) {
+	value, err := ps.db.GetByKey(proposalKey(domainID, source, depositNonce))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	p := &ProposalState{}
+	err = json.Unmarshal(value, p)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// GetProposals returns states of all proposals tracked on the bridge of the domain
+// ordered by their source and deposit nonce
+func (ps *ProposalStore) GetProposals(domainID uint8) ([]*ProposalState, error) {
+	values, err := ps.db.GetByPrefix([]byte(fmt.Sprintf("%s%03d:", proposalPrefix, domainID)))
+	if err != nil {
+		return nil, err
+	}
+
+	proposals := make([]*ProposalState, len(values))
+	for i, v := range values {
+		p := &ProposalState{}
+		err = json.Unmarshal(v, p)
+		if err != nil {
+			return nil, err
+		}
+		proposals[i] = p
+	}
+	return proposals, nil
+}
+
+// proposalKey builds proposal key so that lexicographical key order
+// matches order of deposit nonces from the same source
+func proposalKey(domainID uint8, source uint8, depositNonce uint64) []byte {
+	return []byte(fmt.Sprintf("%s%03d:%03d:%020d", proposalPrefix, domainID, source, depositNonce))
+}
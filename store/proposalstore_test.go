@@ -0,0 +1,98 @@
+package store_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/VaivalGithub/chainsafe-core/store"
+	mock_store "github.com/VaivalGithub/chainsafe-core/store/mock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+type ProposalStoreTestSuite struct {
+	suite.Suite
+	proposalStore *store.ProposalStore
+	keyValueStore *mock_store.MockKeyValueStore
+}
+
+func TestRunProposalStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(ProposalStoreTestSuite))
+}
+
+func (s *ProposalStoreTestSuite) SetupSuite()    {}
+func (s *ProposalStoreTestSuite) TearDownSuite() {}
+func (s *ProposalStoreTestSuite) SetupTest() {
+	gomockController := gomock.NewController(s.T())
+	s.keyValueStore = mock_store.NewMockKeyValueStore(gomockController)
+	s.proposalStore = store.NewProposalStore(s.keyValueStore)
+}
+func (s *ProposalStoreTestSuite) TearDownTest() {}
+
+func (s *ProposalStoreTestSuite) TestStoredProposalIsReturned() {
+	proposal := &store.ProposalState{
+		Source:        2,
+		DepositNonce:  3,
+		DataHash:      common.HexToHash("0x1"),
+		Status:        1,
+		Voters:        []common.Address{common.HexToAddress("0x2")},
+		ProposedBlock: 100,
+		UpdatedBlock:  100,
+	}
+	key := []byte("proposal:001:002:00000000000000000003")
+	var value []byte
+	s.keyValueStore.EXPECT().SetByKey(key, gomock.Any()).DoAndReturn(func(key, v []byte) error {
+		value = v
+		return nil
+	})
+	s.keyValueStore.EXPECT().GetByKey(key).DoAndReturn(func(key []byte) ([]byte, error) {
+		return value, nil
+	})
+
+	err := s.proposalStore.StoreProposal(1, proposal)
+	s.Nil(err)
+
+	stored, err := s.proposalStore.GetProposal(1, 2, 3)
+	s.Nil(err)
+	s.Equal(proposal, stored)
+}
+
+func (s *ProposalStoreTestSuite) TestMissingProposalIsNil() {
+	s.keyValueStore.EXPECT().GetByKey([]byte("proposal:001:002:00000000000000000003")).Return(nil, leveldb.ErrNotFound)
+
+	stored, err := s.proposalStore.GetProposal(1, 2, 3)
+
+	s.Nil(err)
+	s.Nil(stored)
+}
+
+func (s *ProposalStoreTestSuite) TestGetProposalsOfDomain() {
+	proposal := &store.ProposalState{Source: 2, DepositNonce: 3}
+	value, _ := json.Marshal(proposal)
+	s.keyValueStore.EXPECT().GetByPrefix([]byte("proposal:001:")).Return([][]byte{value}, nil)
+
+	proposals, err := s.proposalStore.GetProposals(1)
+
+	s.Nil(err)
+	s.Equal([]*store.ProposalState{proposal}, proposals)
+}
+
+func (s *ProposalStoreTestSuite) TestGetProposalsFails() {
+	s.keyValueStore.EXPECT().GetByPrefix(gomock.Any()).Return(nil, errors.New("error"))
+
+	_, err := s.proposalStore.GetProposals(1)
+
+	s.NotNil(err)
+}
+
+func (s *ProposalStoreTestSuite) TestProposalVoters() {
+	relayer := common.HexToAddress("0x2")
+	proposal := &store.ProposalState{Voters: []common.Address{relayer}}
+
+	s.Equal(uint8(1), proposal.VoteCount())
+	s.True(proposal.IsVotedBy(relayer))
+	s.False(proposal.IsVotedBy(common.HexToAddress("0x3")))
+}
@@ -18,3 +18,33 @@ type KeyValueReader interface {
 type KeyValueWriter interface {
 	SetByKey(key []byte, value []byte) error
 }
+
+// KeyValueStore is a KeyValueReaderWriter that also supports deleting keys
+// and reading every value stored under a key prefix
+type KeyValueStore interface {
+	KeyValueReaderWriter
+	KeyValueDeleter
+	KeyValuePrefixReader
+}
+
+// KeyValueConditionalStore is a KeyValueReaderWriter that also supports conditional writes.
+// Conditional writes have to be atomic for every process sharing the store.
+type KeyValueConditionalStore interface {
+	KeyValueReaderWriter
+	KeyValueConditionalWriter
+}
+
+type KeyValueConditionalWriter interface {
+	// CompareAndSetByKey sets value of key only if its current value equals old, nil old
+	// requires the key to be missing. It returns false without writing if the value differs.
+	CompareAndSetByKey(key []byte, old []byte, value []byte) (bool, error)
+}
+
+type KeyValueDeleter interface {
+	DeleteByKey(key []byte) error
+}
+
+type KeyValuePrefixReader interface {
+	// GetByPrefix returns values of all keys starting with prefix, ordered by key
+	GetByPrefix(prefix []byte) ([][]byte, error)
+}
@@ -1,3 +1,44 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
 type ResourceID [32]byte
+
+// MarshalText encodes resource ID as 0x prefixed hex string
+func (r ResourceID) MarshalText() ([]byte, error) {
+	return []byte(hexutil.Encode(r[:])), nil
+}
+
+// UnmarshalText decodes resource ID from 0x prefixed hex string
+func (r *ResourceID) UnmarshalText(text []byte) error {
+	b, err := hexutil.Decode(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid resource ID %s: %w", text, err)
+	}
+	if len(b) != len(r) {
+		return fmt.Errorf("invalid resource ID %s: expected %d bytes", text, len(r))
+	}
+	copy(r[:], b)
+	return nil
+}
+
+// UnmarshalJSON decodes resource ID from hex string while still accepting
+// the array of numbers produced before resource IDs were marshalled as text
+func (r *ResourceID) UnmarshalJSON(input []byte) error {
+	var s string
+	if err := json.Unmarshal(input, &s); err == nil {
+		return r.UnmarshalText([]byte(s))
+	}
+
+	var legacy [32]byte
+	if err := json.Unmarshal(input, &legacy); err != nil {
+		return fmt.Errorf("invalid resource ID %s: %w", input, err)
+	}
+	*r = legacy
+	return nil
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestResourceIDJSONRoundTrip(t *testing.T) {
+	r := ResourceID{0: 1, 31: 255}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"0x01000000000000000000000000000000000000000000000000000000000000ff"` {
+		t.Fatal(string(b))
+	}
+
+	var decoded ResourceID
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != r {
+		t.Fatalf("expected %x, got %x", r, decoded)
+	}
+}
+
+func TestResourceIDUnmarshalLegacyJSON(t *testing.T) {
+	legacy, err := json.Marshal([32]byte{31: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded ResourceID
+	if err := json.Unmarshal(legacy, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != (ResourceID{31: 2}) {
+		t.Fatal(decoded)
+	}
+}
+
+func TestResourceIDUnmarshalInvalidLength(t *testing.T) {
+	var decoded ResourceID
+	if err := json.Unmarshal([]byte(`"0x01"`), &decoded); err == nil {
+		t.Fatal("expected error for short resource ID")
+	}
+}